@@ -5,31 +5,65 @@ import (
 	"flag"
 	"fmt"
 	"os"
+	"strings"
 	"time"
 
 	"github.com/mikelady/kingmaker/internal/analyzer"
 	"github.com/mikelady/kingmaker/internal/cli"
 	"github.com/mikelady/kingmaker/internal/config"
 	"github.com/mikelady/kingmaker/internal/fetcher"
+	"github.com/mikelady/kingmaker/internal/hooks"
 	"github.com/mikelady/kingmaker/internal/httpclient"
+	"github.com/mikelady/kingmaker/internal/keywords"
 	"github.com/mikelady/kingmaker/internal/metadataprompt"
 	"github.com/mikelady/kingmaker/internal/model"
 	"github.com/mikelady/kingmaker/internal/openai"
 	"github.com/mikelady/kingmaker/internal/prompt"
 	"github.com/mikelady/kingmaker/internal/shorts"
+	"github.com/mikelady/kingmaker/internal/target"
+	"github.com/mikelady/kingmaker/internal/text"
 	"github.com/mikelady/kingmaker/internal/youtube"
 )
 
 func main() {
+	// Subcommands
+	if len(os.Args) > 1 {
+		switch os.Args[1] {
+		case "score":
+			runScore(os.Args[2:])
+			return
+		case "variants":
+			runVariants(os.Args[2:])
+			return
+		}
+	}
+
 	// Parse flags
 	query := flag.String("query", "", "Search query for YouTube videos (required)")
 	maxResults := flag.Int("max", 25, "Maximum number of videos to fetch")
 	maxPrompts := flag.Int("prompts", 5, "Maximum number of prompts to generate (clips mode)")
 	jsonOutput := flag.Bool("json", false, "Output as JSON")
 	verbose := flag.Bool("verbose", false, "Show detailed progress")
-	mode := flag.String("mode", "clips", "Mode: 'clips' for OpusClip prompts, 'metadata' for create-default prompt")
+	mode := flag.String("mode", "clips", "Mode: 'clips' for clip prompts, 'metadata' for a title/description prompt (OpusClip create-default)")
+	targetFlag := flag.String("target", target.OpusClip, "Clipping tool to write prompts for: "+strings.Join(target.Names(), "|"))
 	niche := flag.String("niche", "", "Content niche for metadata mode (e.g., 'AI vibe coding')")
 	includeAllVideos := flag.Bool("include-all-videos", false, "Include all videos, not just Shorts")
+	timezone := flag.String("timezone", "UTC", "IANA timezone for publishing time analysis (e.g., 'America/Mexico_City')")
+	analyzersFlag := flag.String("analyzers", "", "Comma-separated analyzers to run (default all): "+strings.Join(analyzer.BuiltinAnalyzers(), ","))
+	cluster := flag.Int("cluster", 0, "Topic cluster ID to target in clips mode (0 = all videos)")
+	scoringFlag := flag.String("scoring", "tfidf", "Keyword scoring: 'tf' (frequency), 'tfidf' or 'logodds' against a background corpus")
+	stopWordsFlag := flag.String("stopwords", "", "Comma-separated extra words to exclude from keywords")
+	stopWordsFile := flag.String("stopwords-file", "", "File of extra words to exclude from keywords (one per line, # comments)")
+	excludeQuery := flag.Bool("exclude-query", true, "Exclude the query's words and their inflections from keywords")
+	lang := flag.String("lang", "", "Language code for stop words and stemming (e.g., 'es', 'hi'); default detects per video")
+	stem := flag.Bool("stem", true, "Merge inflected keyword forms (code, coding, codes) into one keyword")
+	corpusPath := flag.String("corpus", "", "Background corpus file for keyword scoring; built up from each run's videos (default bundled corpus)")
+	clean := flag.Bool("clean", true, "Strip links, mentions, timestamps and repeated channel boilerplate from descriptions before keyword analysis")
+	gazetteerPath := flag.String("gazetteer", "", "File of extra entity names (one per line, aliases separated by '|', '=' prefix to match only when capitalized) added to the bundled gazetteer")
+	graphPath := flag.String("graph", "", "Write the keyword co-occurrence graph to a file (.graphml, .dot or .json)")
+	explain := flag.Bool("explain", false, "Show each prompt's strategy, sources and supporting videos (clips mode)")
+	templatesDir := flag.String("templates", "", "Directory of prompt templates (<strategy>.tmpl) overriding the bundled ones (clips mode)")
+	saveVideos := flag.String("save-videos", "", "Save the fetched videos to a JSON file for reuse (e.g., kingmaker score -videos)")
 	flag.Parse()
 
 	// Also accept query as positional argument
@@ -40,11 +74,15 @@ func main() {
 	if *query == "" {
 		fmt.Fprintln(os.Stderr, "Usage: kingmaker -query \"your search query\"")
 		fmt.Fprintln(os.Stderr, "   or: kingmaker \"your search query\"")
+		fmt.Fprintln(os.Stderr, "   or: kingmaker score -query \"your search query\" \"Draft title\"")
+		fmt.Fprintln(os.Stderr, "   or: kingmaker variants -query \"your search query\" \"topic\"")
 		fmt.Fprintln(os.Stderr, "\nModes:")
-		fmt.Fprintln(os.Stderr, "  -mode clips     Generate OpusClip search prompts (default)")
-		fmt.Fprintln(os.Stderr, "  -mode metadata  Generate create-default prompt for titles/descriptions")
+		fmt.Fprintln(os.Stderr, "  -mode clips     Generate clip search prompts (default)")
+		fmt.Fprintln(os.Stderr, "  -mode metadata  Generate a prompt for titles/descriptions (OpusClip create-default)")
+		fmt.Fprintln(os.Stderr, "\nTargets (-target): "+strings.Join(target.Names(), ", ")+" (default opusclip)")
 		fmt.Fprintln(os.Stderr, "\nRequired: YOUTUBE_API_KEY environment variable")
 		fmt.Fprintln(os.Stderr, "For metadata mode: OPENAI_API_KEY environment variable")
+		fmt.Fprintln(os.Stderr, "Optional: KINGMAKER_CONFIG path to a JSON config file with per-niche \"boring_words\" and \"hook_packs\"")
 		os.Exit(1)
 	}
 
@@ -54,6 +92,83 @@ func main() {
 		os.Exit(1)
 	}
 
+	// Resolve the clipping tool
+	tool, err := target.Parse(*targetFlag)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	// Resolve timezone for publishing analysis
+	location, err := time.LoadLocation(*timezone)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: invalid timezone %q: %v\n", *timezone, err)
+		os.Exit(1)
+	}
+
+	// Resolve analyzer selection
+	analyzerNames, err := analyzer.ParseAnalyzers(*analyzersFlag, nil)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	// Resolve keyword scoring and background corpus
+	scoring, err := keywords.ParseScoring(*scoringFlag)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+	var background *keywords.Corpus
+	if *corpusPath != "" {
+		background, err = keywords.LoadCorpus(*corpusPath)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+	}
+
+	// Collect custom stop words
+	stopWords := keywords.SplitWordList(*stopWordsFlag)
+	if *stopWordsFile != "" {
+		fileWords, err := keywords.LoadWordList(*stopWordsFile)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		stopWords = append(stopWords, fileWords...)
+	}
+
+	// Load the entity gazetteer
+	gazetteer := text.DefaultGazetteer()
+	if *gazetteerPath != "" {
+		gazetteer, err = text.LoadGazetteer(*gazetteerPath)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+	}
+
+	// Resolve keyword graph export format
+	var graphFormat analyzer.GraphFormat
+	if *graphPath != "" {
+		graphFormat, err = analyzer.GraphFormatFromPath(*graphPath)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+	}
+
+	// Load prompt templates before spending API quota
+	promptTemplates := prompt.DefaultTemplates()
+	if *templatesDir != "" {
+		promptTemplates, err = prompt.LoadTemplates(*templatesDir)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+	}
+
 	// Load config
 	cfg, err := config.Load()
 	if err != nil {
@@ -62,17 +177,32 @@ func main() {
 		os.Exit(1)
 	}
 
+	// Layer configured hook packs over the bundled ones
+	hookMatcher, err := loadHookMatcher(cfg)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+
 	// Check OpenAI API key for metadata mode
 	if *mode == "metadata" && cfg.OpenAIAPIKey == "" {
 		fmt.Fprintln(os.Stderr, "Error: OPENAI_API_KEY environment variable is required for metadata mode")
 		os.Exit(1)
 	}
 
+	// Use query as niche if not specified
+	nicheOrQuery := *niche
+	if nicheOrQuery == "" {
+		nicheOrQuery = *query
+	}
+
 	// CLI options
 	cliOpts := cli.Options{
 		JSON:        *jsonOutput,
 		ShowSummary: true,
 		Verbose:     *verbose,
+		Explain:     *explain,
+		Target:      tool,
 	}
 
 	// Initialize clients
@@ -85,45 +215,72 @@ func main() {
 		os.Exit(1)
 	}
 
-	var videos []model.Video
+	if *verbose && *mode == "metadata" && !*includeAllVideos {
+		cli.DisplayProgress(os.Stderr, "Note: metadata mode includes all videos (not just Shorts)", cliOpts)
+	}
+	// Metadata mode always includes all videos to analyze successful content
+	videos, err := fetchVideos(ctx, ytClient, cfg, *query, *maxResults, *includeAllVideos || *mode == "metadata", cliOpts)
+	if err != nil {
+		cli.DisplayError(os.Stderr, err, cliOpts)
+		os.Exit(1)
+	}
+	if *saveVideos != "" {
+		if err := model.SaveVideos(*saveVideos, videos); err != nil {
+			cli.DisplayError(os.Stderr, fmt.Errorf("failed to save videos: %w", err), cliOpts)
+			os.Exit(1)
+		}
+	}
 
-	if *includeAllVideos || *mode == "metadata" {
-		// Fetch all videos (no shorts filter)
-		// Metadata mode always includes all videos to analyze successful content
-		if *verbose && *mode == "metadata" && !*includeAllVideos {
-			cli.DisplayProgress(os.Stderr, "Note: metadata mode includes all videos (not just Shorts)", cliOpts)
+	// Analyze patterns
+	cli.DisplayProgress(os.Stderr, "Analyzing patterns...", cliOpts)
+	analyzerOpts := analyzer.DefaultOptions()
+	analyzerOpts.Location = location
+	analyzerOpts.IncludeLongForm = *includeAllVideos || *mode == "metadata"
+	analyzerOpts.Analyzers = analyzerNames
+	analyzerOpts.KeywordScoring = scoring
+	analyzerOpts.Background = background
+	analyzerOpts.StemKeywords = *stem
+	analyzerOpts.Language = *lang
+	analyzerOpts.StopWords = append(stopWords, cfg.BoringWordsFor(nicheOrQuery)...)
+	if *excludeQuery {
+		analyzerOpts.ExcludeQuery = *query
+	}
+	analyzerOpts.Clean = *clean
+	analyzerOpts.Gazetteer = gazetteer
+	analyzerOpts.Hooks = hookMatcher
+	patterns := analyzer.AnalyzeVideosWithOptions(videos, analyzerOpts)
+
+	// Report what description cleaning removed
+	if *verbose {
+		cli.DisplayCleaning(os.Stderr, patterns.Cleaning, cliOpts)
+	}
+
+	// Grow the user corpus with this run's videos for future runs
+	if background != nil {
+		texts := make([]string, 0, len(videos))
+		ids := make([]string, 0, len(videos))
+		for _, v := range videos {
+			texts = append(texts, v.Title+"\n"+v.Description)
+			ids = append(ids, v.ID)
 		}
-		cli.DisplayProgress(os.Stderr, fmt.Sprintf("Searching for videos: %q...", *query), cliOpts)
-		// Use SearchWithDuration with no filter
-		videos, err = ytClient.SearchWithDuration(ctx, *query, int64(*maxResults), youtube.DurationAny)
-		if err != nil {
-			cli.DisplayError(os.Stderr, fmt.Errorf("failed to fetch videos: %w", err), cliOpts)
-			os.Exit(1)
+		background.Add(texts, ids)
+		if err := background.Save(*corpusPath); err != nil {
+			cli.DisplayError(os.Stderr, fmt.Errorf("failed to save corpus: %w", err), cliOpts)
 		}
-		cli.DisplayProgress(os.Stderr, fmt.Sprintf("Found %d videos", len(videos)), cliOpts)
-	} else {
-		// Fetch shorts (original behavior)
-		httpClient := httpclient.NewNoRedirectClient(time.Duration(cfg.HTTPTimeout) * time.Second)
-		shortsChecker := shorts.NewChecker(httpClient)
-		shortsFetcher := fetcher.New(ytClient, shortsChecker)
+	}
 
-		cli.DisplayProgress(os.Stderr, fmt.Sprintf("Searching for Shorts: %q...", *query), cliOpts)
-		videos, err = shortsFetcher.FetchShorts(ctx, *query, int64(*maxResults))
-		if err != nil {
-			cli.DisplayError(os.Stderr, fmt.Errorf("failed to fetch Shorts: %w", err), cliOpts)
+	// Export the keyword co-occurrence graph
+	if *graphPath != "" {
+		if err := writeGraph(*graphPath, graphFormat, patterns.KeywordGraph); err != nil {
+			cli.DisplayError(os.Stderr, fmt.Errorf("failed to write keyword graph: %w", err), cliOpts)
 			os.Exit(1)
 		}
-		cli.DisplayProgress(os.Stderr, fmt.Sprintf("Found %d verified Shorts", len(videos)), cliOpts)
 	}
 
-	// Analyze patterns
-	cli.DisplayProgress(os.Stderr, "Analyzing patterns...", cliOpts)
-	patterns := analyzer.AnalyzeVideos(videos)
-
 	// Handle mode-specific output
 	if *mode == "metadata" {
 		// Generate metadata prompt using LLM
-		cli.DisplayProgress(os.Stderr, "Generating create-default prompt with LLM...", cliOpts)
+		cli.DisplayProgress(os.Stderr, fmt.Sprintf("Generating %s %s prompt with LLM...", tool.Label, tool.MetadataFeature), cliOpts)
 
 		openaiClient, err := openai.NewClient(cfg.OpenAIAPIKey)
 		if err != nil {
@@ -132,12 +289,9 @@ func main() {
 		}
 
 		gen := metadataprompt.NewGenerator(openaiClient)
-		nicheStr := *niche
-		if nicheStr == "" {
-			nicheStr = *query // Use query as niche if not specified
-		}
 		opts := metadataprompt.Options{
-			Niche: nicheStr,
+			Niche:  nicheOrQuery,
+			Target: tool,
 		}
 
 		metaPrompt, err := gen.Generate(ctx, patterns, opts)
@@ -157,10 +311,20 @@ func main() {
 		}
 	} else {
 		// Clips mode (original behavior)
-		cli.DisplayProgress(os.Stderr, "Generating OpusClip prompts...", cliOpts)
+		cli.DisplayProgress(os.Stderr, fmt.Sprintf("Generating %s prompts...", tool.Label), cliOpts)
+		if *cluster > 0 {
+			if _, ok := patterns.FindCluster(*cluster); !ok {
+				cli.DisplayError(os.Stderr, fmt.Errorf("topic cluster %d not found (%d clusters detected)", *cluster, len(patterns.Clusters)), cliOpts)
+				os.Exit(1)
+			}
+		}
 		promptOpts := prompt.Options{
 			MaxPrompts: *maxPrompts,
 			Query:      *query,
+			Cluster:    *cluster,
+			Videos:     videos,
+			Templates:  promptTemplates,
+			Target:     tool,
 		}
 		prompts := prompt.Generate(patterns, promptOpts)
 
@@ -174,3 +338,57 @@ func main() {
 		}
 	}
 }
+
+// loadHookMatcher builds the hook matcher from the bundled hook packs with
+// the configured packs layered over them.
+func loadHookMatcher(cfg *config.Config) (*hooks.Matcher, error) {
+	packs := hooks.DefaultPacks()
+	for _, path := range cfg.HookPacks {
+		pack, err := hooks.LoadPack(path)
+		if err != nil {
+			return nil, err
+		}
+		packs = append(packs, pack)
+	}
+	return hooks.NewMatcher(packs...)
+}
+
+// fetchVideos searches YouTube for the query. Unless allVideos is set, only
+// verified Shorts are kept.
+func fetchVideos(ctx context.Context, ytClient *youtube.Client, cfg *config.Config, query string, maxResults int, allVideos bool, cliOpts cli.Options) ([]model.Video, error) {
+	if allVideos {
+		cli.DisplayProgress(os.Stderr, fmt.Sprintf("Searching for videos: %q...", query), cliOpts)
+		// Use SearchWithDuration with no filter
+		videos, err := ytClient.SearchWithDuration(ctx, query, int64(maxResults), youtube.DurationAny)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch videos: %w", err)
+		}
+		cli.DisplayProgress(os.Stderr, fmt.Sprintf("Found %d videos", len(videos)), cliOpts)
+		return videos, nil
+	}
+
+	httpClient := httpclient.NewNoRedirectClient(time.Duration(cfg.HTTPTimeout) * time.Second)
+	shortsChecker := shorts.NewChecker(httpClient)
+	shortsFetcher := fetcher.New(ytClient, shortsChecker)
+
+	cli.DisplayProgress(os.Stderr, fmt.Sprintf("Searching for Shorts: %q...", query), cliOpts)
+	videos, err := shortsFetcher.FetchShorts(ctx, query, int64(maxResults))
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch Shorts: %w", err)
+	}
+	cli.DisplayProgress(os.Stderr, fmt.Sprintf("Found %d verified Shorts", len(videos)), cliOpts)
+	return videos, nil
+}
+
+// writeGraph writes the keyword graph to path in the given format.
+func writeGraph(path string, format analyzer.GraphFormat, g analyzer.KeywordGraph) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if err := g.Export(f, format); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
@@ -0,0 +1,139 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/mikelady/kingmaker/internal/analyzer"
+	"github.com/mikelady/kingmaker/internal/cli"
+	"github.com/mikelady/kingmaker/internal/config"
+	"github.com/mikelady/kingmaker/internal/hooks"
+	"github.com/mikelady/kingmaker/internal/model"
+	"github.com/mikelady/kingmaker/internal/text"
+	"github.com/mikelady/kingmaker/internal/youtube"
+)
+
+// nicheFlags are the flags of subcommands that learn a niche's title
+// patterns from fetched or saved videos.
+type nicheFlags struct {
+	query            *string
+	videosPath       *string
+	maxResults       *int
+	includeAllVideos *bool
+	lang             *string
+	gazetteerPath    *string
+	jsonOutput       *bool
+	verbose          *bool
+}
+
+// niche is a niche's learned title patterns with the matchers that found them.
+type niche struct {
+	patterns  analyzer.Patterns
+	hooks     *hooks.Matcher
+	gazetteer *text.Gazetteer
+	cliOpts   cli.Options
+}
+
+// addNicheFlags registers the niche flags on fs.
+func addNicheFlags(fs *flag.FlagSet) *nicheFlags {
+	return &nicheFlags{
+		query:            fs.String("query", "", "Search query for the niche's videos (required unless -videos is given)"),
+		videosPath:       fs.String("videos", "", "Load the niche's videos from a JSON file saved with -save-videos instead of fetching them"),
+		maxResults:       fs.Int("max", 50, "Maximum number of videos to fetch"),
+		includeAllVideos: fs.Bool("include-all-videos", false, "Include all videos, not just Shorts"),
+		lang:             fs.String("lang", "", "Language code for stop words and stemming (e.g., 'es', 'hi'); default detects per video"),
+		gazetteerPath:    fs.String("gazetteer", "", "File of extra entity names (one per line, aliases separated by '|', '=' prefix to match only when capitalized) added to the bundled gazetteer"),
+		jsonOutput:       fs.Bool("json", false, "Output as JSON"),
+		verbose:          fs.Bool("verbose", false, "Show detailed progress"),
+	}
+}
+
+// hasSource reports whether the flags name a niche to learn from.
+func (f *nicheFlags) hasSource() bool {
+	return *f.query != "" || *f.videosPath != ""
+}
+
+// load fetches or loads the niche's videos and analyzes their titles,
+// exiting on error.
+func (f *nicheFlags) load() niche {
+	// Load the entity gazetteer
+	gazetteer := text.DefaultGazetteer()
+	if *f.gazetteerPath != "" {
+		var err error
+		gazetteer, err = text.LoadGazetteer(*f.gazetteerPath)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+	}
+
+	// Load config; the API key is only needed to fetch videos
+	var cfg *config.Config
+	var err error
+	if *f.videosPath != "" {
+		cfg, err = config.LoadLocal()
+	} else {
+		cfg, err = config.Load()
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	hookMatcher, err := loadHookMatcher(cfg)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	cliOpts := cli.Options{
+		JSON:    *f.jsonOutput,
+		Verbose: *f.verbose,
+	}
+
+	// Fetch or load the niche corpus
+	var videos []model.Video
+	if *f.videosPath != "" {
+		videos, err = model.LoadVideos(*f.videosPath)
+		if err != nil {
+			cli.DisplayError(os.Stderr, err, cliOpts)
+			os.Exit(1)
+		}
+		cli.DisplayProgress(os.Stderr, fmt.Sprintf("Loaded %d videos", len(videos)), cliOpts)
+	} else {
+		ytClient, err := youtube.NewClient(cfg.YouTubeAPIKey)
+		if err != nil {
+			cli.DisplayError(os.Stderr, fmt.Errorf("failed to create YouTube client: %w", err), cliOpts)
+			os.Exit(1)
+		}
+		videos, err = fetchVideos(context.Background(), ytClient, cfg, *f.query, *f.maxResults, *f.includeAllVideos, cliOpts)
+		if err != nil {
+			cli.DisplayError(os.Stderr, err, cliOpts)
+			os.Exit(1)
+		}
+	}
+	if len(videos) == 0 {
+		cli.DisplayError(os.Stderr, fmt.Errorf("no videos to learn the niche from"), cliOpts)
+		os.Exit(1)
+	}
+
+	// Analyze the niche's titles
+	cli.DisplayProgress(os.Stderr, "Analyzing patterns...", cliOpts)
+	analyzerOpts := analyzer.DefaultOptions()
+	analyzerOpts.Analyzers = []string{analyzer.AnalyzerHooks, analyzer.AnalyzerKeywords, analyzer.AnalyzerKeyphrases, analyzer.AnalyzerEntities, analyzer.AnalyzerTitles, analyzer.AnalyzerTemplates}
+	analyzerOpts.StemKeywords = true
+	analyzerOpts.Language = *f.lang
+	analyzerOpts.ExcludeQuery = *f.query
+	analyzerOpts.StopWords = cfg.BoringWordsFor(*f.query)
+	analyzerOpts.Gazetteer = gazetteer
+	analyzerOpts.Hooks = hookMatcher
+
+	return niche{
+		patterns:  analyzer.AnalyzeVideosWithOptions(videos, analyzerOpts),
+		hooks:     hookMatcher,
+		gazetteer: gazetteer,
+		cliOpts:   cliOpts,
+	}
+}
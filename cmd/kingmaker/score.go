@@ -0,0 +1,49 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/mikelady/kingmaker/internal/cli"
+	"github.com/mikelady/kingmaker/internal/score"
+)
+
+// runScore implements "kingmaker score": it learns the niche's patterns from
+// fetched or saved videos and scores draft titles against them.
+func runScore(args []string) {
+	fs := flag.NewFlagSet("score", flag.ExitOnError)
+	nf := addNicheFlags(fs)
+	draftsPath := fs.String("file", "", "File of draft titles to score (one per line)")
+	fs.Parse(args)
+
+	// Drafts come from the arguments and the drafts file
+	drafts := fs.Args()
+	if *draftsPath != "" {
+		fileDrafts, err := score.LoadDrafts(*draftsPath)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		drafts = append(drafts, fileDrafts...)
+	}
+
+	if len(drafts) == 0 || !nf.hasSource() {
+		fmt.Fprintln(os.Stderr, "Usage: kingmaker score -query \"your search query\" \"Draft title\" [\"Another draft\" ...]")
+		fmt.Fprintln(os.Stderr, "   or: kingmaker score -videos videos.json -file drafts.txt")
+		fmt.Fprintln(os.Stderr, "\nWithout -videos: YOUTUBE_API_KEY environment variable")
+		os.Exit(1)
+	}
+
+	n := nf.load()
+
+	// Score the drafts
+	scoreOpts := score.Options{
+		Hooks:     n.hooks,
+		Gazetteer: n.gazetteer,
+	}
+	results := score.Titles(drafts, n.patterns, scoreOpts)
+
+	fmt.Fprintln(os.Stderr) // Blank line before results
+	cli.DisplayScores(os.Stdout, results, n.cliOpts)
+}
@@ -0,0 +1,42 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/mikelady/kingmaker/internal/cli"
+	"github.com/mikelady/kingmaker/internal/variants"
+)
+
+// runVariants implements "kingmaker variants": it learns the niche's
+// patterns from fetched or saved videos and fills its title formulas for a
+// topic, without an LLM.
+func runVariants(args []string) {
+	fs := flag.NewFlagSet("variants", flag.ExitOnError)
+	nf := addNicheFlags(fs)
+	count := fs.Int("n", 10, "Number of title variants to generate")
+	fs.Parse(args)
+
+	topic := strings.Join(fs.Args(), " ")
+	if topic == "" || !nf.hasSource() {
+		fmt.Fprintln(os.Stderr, "Usage: kingmaker variants -query \"your search query\" [-n 10] \"topic\"")
+		fmt.Fprintln(os.Stderr, "   or: kingmaker variants -videos videos.json \"topic\"")
+		fmt.Fprintln(os.Stderr, "\nWithout -videos: YOUTUBE_API_KEY environment variable")
+		os.Exit(1)
+	}
+
+	n := nf.load()
+
+	cli.DisplayProgress(os.Stderr, "Generating title variants...", n.cliOpts)
+	opts := variants.Options{
+		Count:     *count,
+		Hooks:     n.hooks,
+		Gazetteer: n.gazetteer,
+	}
+	results := variants.Generate(topic, n.patterns, opts)
+
+	fmt.Fprintln(os.Stderr) // Blank line before results
+	cli.DisplayVariants(os.Stdout, results, n.cliOpts)
+}
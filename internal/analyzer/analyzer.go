@@ -5,6 +5,7 @@ import (
 	"regexp"
 	"sort"
 	"strings"
+	"time"
 
 	"github.com/mikelady/kingmaker/internal/hooks"
 	"github.com/mikelady/kingmaker/internal/keywords"
@@ -27,34 +28,68 @@ type TitlePattern struct {
 
 // TitleMetrics contains metrics about video titles for optimization.
 type TitleMetrics struct {
-	AvgLength      int            // Average title length in characters
+	AvgLength      int            // Average title length in characters (graphemes, not bytes)
 	MinLength      int            // Minimum title length
 	MaxLength      int            // Maximum title length
 	AvgWords       int            // Average word count
+	TopLengthMin   int            // Shortest typical title of the top-quartile videos by views (25th percentile)
+	TopLengthMax   int            // Longest typical title of the top-quartile videos by views (75th percentile)
 	HookDensity    float64        // Proportion of titles with hooks (0.0-1.0)
 	CommonPatterns []TitlePattern // Detected title formula patterns
+	StyleFeatures  []StyleFeature // Title style features correlated with performance
 }
 
 // Patterns contains aggregated analysis results from video metadata.
 type Patterns struct {
-	TopHooks     []hooks.Hook
-	TopKeywords  []keywords.Keyword
-	TopHashtags  []Hashtag
-	TitleMetrics TitleMetrics
-	VideoCount   int
+	TopHooks           []hooks.Hook
+	TopKeywords        []keywords.Keyword
+	TopKeyphrases      []keywords.Keyphrase
+	TopEntities        []Entity
+	TopHashtags        []Hashtag
+	TitleMetrics       TitleMetrics
+	HookPlacement      HookPlacement
+	DescriptionMetrics DescriptionMetrics
+	TitleTemplates     []TitleTemplate
+	Clusters           []TopicCluster
+	KeywordGraph       KeywordGraph
+	Cleaning           text.CleaningReport // What description cleaning removed
+	Publishing         PublishingAnalysis
+	Durations          DurationAnalysis
+	Sections           []Section // Results of custom analyzers, in run order
+	VideoCount         int
 }
 
 // Options configures the analysis behavior.
 type Options struct {
-	TopKeywordsN int // Number of top keywords to return (default 10)
-	TopHashtagsN int // Number of top hashtags to return (default 10)
+	TopKeywordsN    int              // Number of top keywords to return (default 10)
+	TopHashtagsN    int              // Number of top hashtags to return (default 10)
+	TopKeyphrasesN  int              // Number of top keyphrases to return (default 10)
+	TopEntitiesN    int              // Number of top entities to return (default 10)
+	Location        *time.Location   // Timezone for publishing time analysis (default UTC)
+	IncludeLongForm bool             // Add long-form buckets to the duration analysis
+	Analyzers       []string         // Analyzers to run by name (empty = all built-in and registered)
+	Registry        *Registry        // Custom analyzer registry (default DefaultRegistry)
+	KeywordScoring  keywords.Scoring // Keyword scoring method (default term frequency)
+	Background      *keywords.Corpus // Background corpus for TF-IDF and log-odds scoring (default bundled)
+	StemKeywords    bool             // Merge inflected keyword forms (code, coding, codes) under the most common one
+	Language        string           // Force a language for stop words and stemming (default: per video)
+	StopWords       []string         // Extra words to exclude from keywords and keyphrases
+	ExcludeQuery    string           // Query whose tokens and their inflections are excluded from keywords
+	Clean           bool             // Strip links, mentions, timestamps and channel boilerplate from descriptions
+	Gazetteer       *text.Gazetteer  // Known entity names (default bundled gazetteer)
+	Hooks           *hooks.Matcher   // Hook definitions (default bundled hook packs)
 }
 
 // DefaultOptions returns the default analysis options.
 func DefaultOptions() Options {
 	return Options{
-		TopKeywordsN: 10,
-		TopHashtagsN: 10,
+		TopKeywordsN:   10,
+		TopHashtagsN:   10,
+		TopKeyphrasesN: 10,
+		TopEntitiesN:   10,
+		Location:       time.UTC,
+		KeywordScoring: keywords.ScoringFrequency,
+		Clean:          true,
 	}
 }
 
@@ -76,42 +111,89 @@ func AnalyzeVideosWithOptions(videos []model.Video, opts Options) Patterns {
 	if opts.TopHashtagsN <= 0 {
 		opts.TopHashtagsN = 10
 	}
+	if opts.TopKeyphrasesN <= 0 {
+		opts.TopKeyphrasesN = 10
+	}
+	if opts.TopEntitiesN <= 0 {
+		opts.TopEntitiesN = 10
+	}
+	if opts.Gazetteer == nil {
+		opts.Gazetteer = text.DefaultGazetteer()
+	}
+
+	// Clean descriptions before keyword and hashtag analysis
+	patterns := Patterns{VideoCount: len(videos)}
+	keywordVideos := videos
+	if opts.Clean {
+		keywordVideos, patterns.Cleaning = cleanVideos(videos)
+	}
 
 	// Extract titles and descriptions
-	titles := make([]string, 0, len(videos))
-	allTexts := make([]string, 0, len(videos)*2)
-	descriptions := make([]string, 0, len(videos))
+	in := analysisInput{
+		videos:        videos,
+		keywordVideos: keywordVideos,
+		titles:        make([]string, 0, len(videos)),
+		descriptions:  make([]string, 0, len(videos)),
+		allTexts:      make([]string, 0, len(videos)*2),
+		languages:     make([]string, 0, len(videos)*2),
+	}
 
-	for _, v := range videos {
+	for i, v := range videos {
+		lang := videoLanguage(v, opts.Language)
 		if v.Title != "" {
-			titles = append(titles, v.Title)
-			allTexts = append(allTexts, v.Title)
+			in.titles = append(in.titles, v.Title)
+			in.allTexts = append(in.allTexts, v.Title)
+			in.languages = append(in.languages, lang)
 		}
-		if v.Description != "" {
-			descriptions = append(descriptions, v.Description)
-			allTexts = append(allTexts, v.Description)
+		if desc := keywordVideos[i].Description; desc != "" {
+			in.descriptions = append(in.descriptions, desc)
+			in.allTexts = append(in.allTexts, desc)
+			in.languages = append(in.languages, lang)
 		}
 	}
 
-	// Extract hooks from titles
-	topHooks := hooks.ExtractHooks(titles)
+	// Run the selected built-in and custom analyzers
+	runAnalyzers(in, opts, &patterns)
 
-	// Extract keywords from all text
-	topKeywords := keywords.ExtractKeywords(allTexts, opts.TopKeywordsN)
+	return patterns
+}
 
-	// Extract and aggregate hashtags from descriptions
-	topHashtags := extractAndAggregateHashtags(descriptions, opts.TopHashtagsN)
+// cleanVideos returns copies of videos whose descriptions have links,
+// mentions, emails, timestamps and per-channel boilerplate removed.
+func cleanVideos(videos []model.Video) ([]model.Video, text.CleaningReport) {
+	descriptions := make([]string, len(videos))
+	channels := make([]string, len(videos))
+	for i, v := range videos {
+		descriptions[i] = v.Description
+		channels[i] = v.ChannelID
+		if channels[i] == "" {
+			channels[i] = v.Channel
+		}
+	}
 
-	// Calculate title metrics
-	titleMetrics := calculateTitleMetrics(titles, topHooks)
+	cleaned, report := text.CleanDescriptions(descriptions, channels)
+	result := make([]model.Video, len(videos))
+	for i, v := range videos {
+		v.Description = cleaned[i]
+		result[i] = v
+	}
+	return result, report
+}
 
-	return Patterns{
-		TopHooks:     topHooks,
-		TopKeywords:  topKeywords,
-		TopHashtags:  topHashtags,
-		TitleMetrics: titleMetrics,
-		VideoCount:   len(videos),
+// videoLanguage resolves the language of a video: the forced language if
+// set, else YouTube's defaultAudioLanguage, else the language detected from
+// its title and description, falling back to English.
+func videoLanguage(v model.Video, forced string) string {
+	if lang := text.NormalizeLanguage(forced); lang != "" {
+		return lang
+	}
+	if lang := text.NormalizeLanguage(v.DefaultAudioLanguage); lang != "" {
+		return lang
 	}
+	if lang := text.DetectLanguage(v.Title + "\n" + v.Description); lang != "" {
+		return lang
+	}
+	return "en"
 }
 
 // extractAndAggregateHashtags extracts hashtags from descriptions and returns top N by frequency.
@@ -154,7 +236,7 @@ var (
 )
 
 // calculateTitleMetrics computes metrics about video titles.
-func calculateTitleMetrics(titles []string, extractedHooks []hooks.Hook) TitleMetrics {
+func calculateTitleMetrics(titles []string, m *hooks.Matcher) TitleMetrics {
 	if len(titles) == 0 {
 		return TitleMetrics{}
 	}
@@ -164,7 +246,7 @@ func calculateTitleMetrics(titles []string, extractedHooks []hooks.Hook) TitleMe
 	maxLength := 0
 
 	for _, title := range titles {
-		length := len(title)
+		length := text.GraphemeCount(title)
 		words := len(strings.Fields(title))
 
 		totalLength += length
@@ -183,7 +265,7 @@ func calculateTitleMetrics(titles []string, extractedHooks []hooks.Hook) TitleMe
 	}
 
 	// Calculate hook density - proportion of titles with at least one hook
-	titlesWithHooks := countTitlesWithHooks(titles)
+	titlesWithHooks := countTitlesWithHooks(titles, m)
 	hookDensity := float64(titlesWithHooks) / float64(len(titles))
 
 	// Detect common patterns
@@ -203,12 +285,43 @@ func calculateTitleMetrics(titles []string, extractedHooks []hooks.Hook) TitleMe
 	}
 }
 
+// topPerformerLengthRange returns the interquartile range of title lengths
+// among the top quarter of videos by views (all videos when there are fewer
+// than four).
+func topPerformerLengthRange(videos []model.Video) (int, int) {
+	var titled []model.Video
+	for _, v := range videos {
+		if v.Title != "" {
+			titled = append(titled, v)
+		}
+	}
+	if len(titled) == 0 {
+		return 0, 0
+	}
+
+	sort.SliceStable(titled, func(i, j int) bool {
+		return titled[i].ViewCount > titled[j].ViewCount
+	})
+	top := titled
+	if len(titled) >= 4 {
+		top = titled[:len(titled)/4]
+	}
+
+	lengths := make([]int, len(top))
+	for i, v := range top {
+		lengths[i] = text.GraphemeCount(v.Title)
+	}
+	sort.Ints(lengths)
+	last := len(lengths) - 1
+	return lengths[last/4], lengths[(last*3+3)/4]
+}
+
 // countTitlesWithHooks counts how many titles contain at least one hook.
-// Uses hooks.HasHook for consistency with the hook detection in hooks package.
-func countTitlesWithHooks(titles []string) int {
+// Uses the matcher's HasHook for consistency with hook extraction.
+func countTitlesWithHooks(titles []string, m *hooks.Matcher) int {
 	count := 0
 	for _, title := range titles {
-		if hooks.HasHook(title) {
+		if m.HasHook(title) {
 			count++
 		}
 	}
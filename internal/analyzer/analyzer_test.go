@@ -156,6 +156,173 @@ func TestAnalyzeVideosWithOptions(t *testing.T) {
 	}
 }
 
+func TestAnalyzeVideosWithOptions_KeywordScoring(t *testing.T) {
+	videos := []model.Video{
+		{Title: "Sourdough video new video", Description: "shorts video"},
+		{Title: "Sourdough starter video", Description: "video tips"},
+	}
+
+	freq := AnalyzeVideosWithOptions(videos, DefaultOptions())
+	if len(freq.TopKeywords) == 0 || freq.TopKeywords[0].Word != "video" {
+		t.Errorf("frequency top keyword = %v, want video", freq.TopKeywords)
+	}
+
+	opts := DefaultOptions()
+	opts.KeywordScoring = keywords.ScoringTFIDF
+	tfidf := AnalyzeVideosWithOptions(videos, opts)
+	if len(tfidf.TopKeywords) == 0 || tfidf.TopKeywords[0].Word != "sourdough" {
+		t.Errorf("tfidf top keyword = %v, want sourdough", tfidf.TopKeywords)
+	}
+}
+
+func TestAnalyzeVideos_Keyphrases(t *testing.T) {
+	videos := []model.Video{
+		{Title: "Vibe coding with Claude"},
+		{Title: "My vibe coding setup"},
+		{Title: "Vibe coding for beginners"},
+	}
+
+	result := AnalyzeVideos(videos)
+	if len(result.TopKeyphrases) == 0 || result.TopKeyphrases[0].Phrase != "vibe coding" {
+		t.Errorf("TopKeyphrases = %v, want vibe coding first", result.TopKeyphrases)
+	}
+}
+
+func TestAnalyzeVideosWithOptions_StemKeywords(t *testing.T) {
+	videos := []model.Video{
+		{Title: "Coding with AI", Description: "code codes coded"},
+	}
+
+	opts := DefaultOptions()
+	opts.StemKeywords = true
+	result := AnalyzeVideosWithOptions(videos, opts)
+
+	if len(result.TopKeywords) == 0 || result.TopKeywords[0].Frequency != 4 {
+		t.Fatalf("expected merged code group first, got %v", result.TopKeywords)
+	}
+	if len(result.TopKeywords[0].Variants) != 4 {
+		t.Errorf("Variants = %v, want 4 forms", result.TopKeywords[0].Variants)
+	}
+}
+
+func TestAnalyzeVideos_MultilingualStopWords(t *testing.T) {
+	videos := []model.Video{
+		{Title: "Cómo hacer pan de masa madre", Description: "Receta para hacer pan en casa #panadería"},
+		{Title: "Pan de masa madre para principiantes", Description: "La mejor receta de pan"},
+	}
+
+	result := AnalyzeVideos(videos)
+
+	for _, kw := range result.TopKeywords {
+		if kw.Word == "de" || kw.Word == "para" || kw.Word == "la" {
+			t.Errorf("Spanish stop word %q should be filtered", kw.Word)
+		}
+	}
+	if len(result.TopKeywords) == 0 || result.TopKeywords[0].Word != "pan" {
+		t.Errorf("TopKeywords = %v, want pan first", result.TopKeywords)
+	}
+	if len(result.TopHashtags) != 1 || result.TopHashtags[0].Tag != "panadería" {
+		t.Errorf("TopHashtags = %v, want [panadería]", result.TopHashtags)
+	}
+}
+
+func TestVideoLanguage(t *testing.T) {
+	tests := []struct {
+		name   string
+		video  model.Video
+		forced string
+		want   string
+	}{
+		{"forced", model.Video{DefaultAudioLanguage: "es"}, "hi", "hi"},
+		{"default audio language", model.Video{Title: "How to code", DefaultAudioLanguage: "pt-BR"}, "", "pt"},
+		{"detected", model.Video{Title: "कोडिंग कैसे सीखें"}, "", "hi"},
+		{"fallback", model.Video{Title: "Python 🔥"}, "", "en"},
+	}
+	for _, tt := range tests {
+		if got := videoLanguage(tt.video, tt.forced); got != tt.want {
+			t.Errorf("%s: videoLanguage() = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestAnalyzeVideosWithOptions_ExcludeQueryAndStopWords(t *testing.T) {
+	videos := []model.Video{
+		{Title: "Vibe coding with Cursor", Description: "vibe code tutorial"},
+		{Title: "Vibe coded an app in Cursor", Description: "cursor tutorial"},
+	}
+
+	opts := DefaultOptions()
+	opts.ExcludeQuery = "vibe coding"
+	opts.StopWords = []string{"tutorial"}
+	result := AnalyzeVideosWithOptions(videos, opts)
+
+	if len(result.TopKeywords) == 0 || result.TopKeywords[0].Word != "cursor" {
+		t.Fatalf("TopKeywords = %v, want cursor first", result.TopKeywords)
+	}
+	for _, kw := range result.TopKeywords {
+		switch kw.Word {
+		case "vibe", "coding", "code", "coded", "tutorial":
+			t.Errorf("excluded keyword %q returned", kw.Word)
+		}
+	}
+}
+
+func TestAnalyzeVideos_CleansDescriptions(t *testing.T) {
+	footer := "\nFollow me on Instagram @sourdoughsam\nAs an Amazon Associate I earn from qualifying purchases #ad"
+	videos := []model.Video{
+		{ChannelID: "UC1", Title: "Sourdough starter", Description: "Feed your starter daily https://example.com/starter" + footer},
+		{ChannelID: "UC1", Title: "Sourdough loaf", Description: "0:00 Shaping the loaf" + footer},
+		{ChannelID: "UC1", Title: "Sourdough discard", Description: "Discard pancakes" + footer},
+	}
+
+	result := AnalyzeVideos(videos)
+
+	for _, kw := range result.TopKeywords {
+		switch kw.Word {
+		case "instagram", "amazon", "associate", "https", "example", "sourdoughsam":
+			t.Errorf("boilerplate keyword %q should be removed", kw.Word)
+		}
+	}
+	if len(result.TopHashtags) != 0 {
+		t.Errorf("TopHashtags = %v, want footer hashtag removed", result.TopHashtags)
+	}
+	if len(result.Cleaning.Boilerplate) != 2 || result.Cleaning.URLs != 1 || result.Cleaning.Timestamps != 1 {
+		t.Errorf("Cleaning = %+v, want 2 boilerplate lines, 1 URL, 1 timestamp", result.Cleaning)
+	}
+
+	opts := DefaultOptions()
+	opts.Clean = false
+	raw := AnalyzeVideosWithOptions(videos, opts)
+	if len(raw.TopHashtags) != 1 || raw.Cleaning.Removed() != 0 {
+		t.Errorf("cleaning disabled: TopHashtags = %v, Cleaning = %+v", raw.TopHashtags, raw.Cleaning)
+	}
+}
+
+func TestAnalyzeVideosWithOptions_HookPacks(t *testing.T) {
+	videos := []model.Video{
+		{Title: "The plot twist in my app"},
+		{Title: "Another plot twist"},
+	}
+
+	m, err := hooks.NewMatcher(hooks.Pack{
+		Name:  "custom",
+		Hooks: []hooks.Definition{{Type: "curiosity_gap", Name: "plot twist", Regex: `plot twist`}},
+	})
+	if err != nil {
+		t.Fatalf("NewMatcher() error = %v", err)
+	}
+	opts := DefaultOptions()
+	opts.Hooks = m
+	result := AnalyzeVideosWithOptions(videos, opts)
+
+	if len(result.TopHooks) != 1 || result.TopHooks[0].Pattern != "plot twist" || result.TopHooks[0].Frequency != 2 {
+		t.Errorf("TopHooks = %+v, want plot twist x2", result.TopHooks)
+	}
+	if result.TitleMetrics.HookDensity != 1 {
+		t.Errorf("HookDensity = %v, want 1", result.TitleMetrics.HookDensity)
+	}
+}
+
 func TestPatterns_Type(t *testing.T) {
 	// Verify Patterns struct has expected fields
 	p := Patterns{
@@ -295,3 +462,29 @@ func TestTitleMetrics_EmptyTitles(t *testing.T) {
 		t.Errorf("AvgLength = %d, want 11", result.TitleMetrics.AvgLength)
 	}
 }
+
+func TestTitleMetrics_TopLengthRange(t *testing.T) {
+	videos := []model.Video{
+		{Title: "Ten chars!", ViewCount: 9000},
+		{Title: "Twenty characters ok", ViewCount: 8000},
+		{Title: "Short", ViewCount: 10},
+		{Title: "A much longer title that did not do well at all", ViewCount: 20},
+		{Title: "Another mediocre title here", ViewCount: 30},
+		{Title: "", ViewCount: 99999},
+		{Title: "Yet another", ViewCount: 40},
+		{Title: "And one more", ViewCount: 50},
+		{Title: "The last one", ViewCount: 60},
+	}
+
+	result := AnalyzeVideos(videos)
+
+	// The top quarter of the eight titled videos is the two most viewed
+	if result.TitleMetrics.TopLengthMin != 10 || result.TitleMetrics.TopLengthMax != 20 {
+		t.Errorf("top length range = %d-%d, want 10-20", result.TitleMetrics.TopLengthMin, result.TitleMetrics.TopLengthMax)
+	}
+
+	lo, hi := topPerformerLengthRange(videos[:3])
+	if lo != 5 || hi != 20 {
+		t.Errorf("topPerformerLengthRange(3 videos) = %d-%d, want 5-20", lo, hi)
+	}
+}
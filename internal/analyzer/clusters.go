@@ -0,0 +1,347 @@
+package analyzer
+
+import (
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/mikelady/kingmaker/internal/hooks"
+	"github.com/mikelady/kingmaker/internal/keywords"
+	"github.com/mikelady/kingmaker/internal/model"
+	"github.com/mikelady/kingmaker/internal/stats"
+	"github.com/mikelady/kingmaker/internal/text"
+)
+
+// TopicCluster is a group of videos covering the same sub-niche.
+type TopicCluster struct {
+	ID          int                // 1-based cluster ID, ordered by size
+	Keywords    []keywords.Keyword // Label keywords, highest centroid weight first
+	Size        int                // Number of videos in the cluster
+	MedianViews int64              // Median views of the cluster's videos
+	TopHooks    []hooks.Hook       // Most frequent hooks in the cluster's titles
+	VideoIDs    []string           // IDs of the cluster's videos
+}
+
+const (
+	minClusterVideos  = 6    // Fewer videos than this are not clustered
+	maxClusterK       = 8    // Upper bound for automatic k selection
+	minSilhouette     = 0.05 // Below this, the result set has no real sub-topics
+	kmeansIterations  = 50
+	clusterLabelWords = 5
+	clusterTopHooks   = 3
+	titleTermWeight   = 2 // Title terms count double; titles are the most topical text
+)
+
+// sparseVector is a TF-IDF document vector keyed by term.
+type sparseVector map[string]float64
+
+// clusterTopics groups videos into sub-topics using spherical k-means over
+// TF-IDF vectors built from titles, descriptions and tags. The number of
+// clusters is chosen automatically by silhouette score. Returns nil when the
+// result set is too small or has no clear sub-topics. Stop words are removed
+// in each video's language, or in lang for every video when it is set.
+func clusterTopics(videos []model.Video, lang string, m *hooks.Matcher) []TopicCluster {
+	if len(videos) < minClusterVideos {
+		return nil
+	}
+
+	vectors := tfidfVectors(videos, lang)
+
+	var best []int
+	bestScore := minSilhouette
+	for k := 2; k <= maxClusterK && k <= len(videos)/2; k++ {
+		assignments := kmeans(vectors, k)
+		if score := silhouette(vectors, assignments, k); score > bestScore {
+			best, bestScore = assignments, score
+		}
+	}
+	if best == nil {
+		return nil
+	}
+
+	return buildClusters(videos, vectors, best, m)
+}
+
+// tfidfVectors builds an L2-normalized TF-IDF vector for each video.
+func tfidfVectors(videos []model.Video, lang string) []sparseVector {
+	termCounts := make([]map[string]float64, len(videos))
+	docFreq := make(map[string]int)
+
+	for i, v := range videos {
+		videoLang := videoLanguage(v, lang)
+		counts := make(map[string]float64)
+		for _, term := range clusterTerms(v.Title, videoLang) {
+			counts[term] += titleTermWeight
+		}
+		for _, term := range clusterTerms(v.Description+" "+strings.Join(v.Tags, " "), videoLang) {
+			counts[term]++
+		}
+		for term := range counts {
+			docFreq[term]++
+		}
+		termCounts[i] = counts
+	}
+
+	n := float64(len(videos))
+	vectors := make([]sparseVector, len(videos))
+	for i, counts := range termCounts {
+		vec := make(sparseVector, len(counts))
+		for term, tf := range counts {
+			idf := math.Log((1+n)/(1+float64(docFreq[term]))) + 1
+			vec[term] = tf * idf
+		}
+		vectors[i] = normalize(vec)
+	}
+	return vectors
+}
+
+// clusterTerms tokenizes text for clustering, dropping stop words of lang
+// and single-character tokens.
+func clusterTerms(s, lang string) []string {
+	tokens := text.RemoveStopWordsLang(text.Tokenize(s), lang)
+	terms := tokens[:0]
+	for _, t := range tokens {
+		if !text.IsNoiseToken(t) {
+			terms = append(terms, t)
+		}
+	}
+	return terms
+}
+
+// kmeans runs spherical k-means (cosine similarity) with deterministic
+// farthest-point initialization and returns each vector's cluster index.
+func kmeans(vectors []sparseVector, k int) []int {
+	centroids := initCentroids(vectors, k)
+	assignments := make([]int, len(vectors))
+
+	for iter := 0; iter < kmeansIterations; iter++ {
+		changed := false
+		for i, v := range vectors {
+			bestC, bestSim := 0, -1.0
+			for c, centroid := range centroids {
+				if sim := dot(v, centroid); sim > bestSim {
+					bestC, bestSim = c, sim
+				}
+			}
+			if assignments[i] != bestC {
+				assignments[i] = bestC
+				changed = true
+			}
+		}
+		if !changed && iter > 0 {
+			break
+		}
+
+		for c := range centroids {
+			sum := make(sparseVector)
+			for i, v := range vectors {
+				if assignments[i] != c {
+					continue
+				}
+				for term, w := range v {
+					sum[term] += w
+				}
+			}
+			if len(sum) > 0 {
+				centroids[c] = normalize(sum)
+			}
+		}
+	}
+	return assignments
+}
+
+// initCentroids picks the most central vector first, then repeatedly the
+// vector least similar to any chosen centroid.
+func initCentroids(vectors []sparseVector, k int) []sparseVector {
+	first, bestTotal := 0, -1.0
+	for i, v := range vectors {
+		total := 0.0
+		for _, other := range vectors {
+			total += dot(v, other)
+		}
+		if total > bestTotal {
+			first, bestTotal = i, total
+		}
+	}
+
+	chosen := []int{first}
+	for len(chosen) < k {
+		next, lowest := -1, math.Inf(1)
+		for i, v := range vectors {
+			closest := -1.0
+			for _, c := range chosen {
+				closest = math.Max(closest, dot(v, vectors[c]))
+			}
+			if closest < lowest {
+				next, lowest = i, closest
+			}
+		}
+		chosen = append(chosen, next)
+	}
+
+	centroids := make([]sparseVector, k)
+	for i, idx := range chosen {
+		centroids[i] = vectors[idx]
+	}
+	return centroids
+}
+
+// silhouette returns the mean silhouette coefficient using cosine distance.
+func silhouette(vectors []sparseVector, assignments []int, k int) float64 {
+	sizes := make([]int, k)
+	for _, a := range assignments {
+		sizes[a]++
+	}
+
+	total := 0.0
+	for i, v := range vectors {
+		own := assignments[i]
+		if sizes[own] <= 1 {
+			continue // Singleton clusters score 0 by convention
+		}
+
+		sums := make([]float64, k)
+		for j, other := range vectors {
+			if i != j {
+				sums[assignments[j]] += 1 - dot(v, other)
+			}
+		}
+
+		a := sums[own] / float64(sizes[own]-1)
+		b := math.Inf(1)
+		for c := 0; c < k; c++ {
+			if c != own && sizes[c] > 0 {
+				b = math.Min(b, sums[c]/float64(sizes[c]))
+			}
+		}
+		if math.IsInf(b, 1) {
+			continue
+		}
+		if m := math.Max(a, b); m > 0 {
+			total += (b - a) / m
+		}
+	}
+	return total / float64(len(vectors))
+}
+
+// buildClusters turns k-means assignments into labeled clusters.
+func buildClusters(videos []model.Video, vectors []sparseVector, assignments []int, m *hooks.Matcher) []TopicCluster {
+	members := make(map[int][]int)
+	for i, a := range assignments {
+		members[a] = append(members[a], i)
+	}
+
+	clusters := make([]TopicCluster, 0, len(members))
+	for _, idxs := range members {
+		clusterVideos := make([]model.Video, len(idxs))
+		titles := make([]string, 0, len(idxs))
+		ids := make([]string, len(idxs))
+		centroid := make(sparseVector)
+		docCount := make(map[string]int)
+
+		for j, idx := range idxs {
+			v := videos[idx]
+			clusterVideos[j] = v
+			ids[j] = v.ID
+			if v.Title != "" {
+				titles = append(titles, v.Title)
+			}
+			for term, w := range vectors[idx] {
+				centroid[term] += w / float64(len(idxs))
+				docCount[term]++
+			}
+		}
+
+		clusters = append(clusters, TopicCluster{
+			Keywords:    labelKeywords(centroid, docCount),
+			Size:        len(idxs),
+			MedianViews: stats.MedianViews(clusterVideos),
+			TopHooks:    topHooksByFrequency(m.ExtractHooks(titles), clusterTopHooks),
+			VideoIDs:    ids,
+		})
+	}
+
+	sort.Slice(clusters, func(i, j int) bool {
+		if clusters[i].Size != clusters[j].Size {
+			return clusters[i].Size > clusters[j].Size
+		}
+		if clusters[i].MedianViews != clusters[j].MedianViews {
+			return clusters[i].MedianViews > clusters[j].MedianViews
+		}
+		return clusters[i].VideoIDs[0] < clusters[j].VideoIDs[0]
+	})
+	for i := range clusters {
+		clusters[i].ID = i + 1
+	}
+	return clusters
+}
+
+// labelKeywords returns the highest-weighted centroid terms.
+func labelKeywords(centroid sparseVector, docCount map[string]int) []keywords.Keyword {
+	labels := make([]keywords.Keyword, 0, len(centroid))
+	for term, w := range centroid {
+		labels = append(labels, keywords.Keyword{Word: term, Frequency: docCount[term], Score: w})
+	}
+	sort.Slice(labels, func(i, j int) bool {
+		if labels[i].Score != labels[j].Score {
+			return labels[i].Score > labels[j].Score
+		}
+		return labels[i].Word < labels[j].Word
+	})
+	if len(labels) > clusterLabelWords {
+		labels = labels[:clusterLabelWords]
+	}
+	return labels
+}
+
+// topHooksByFrequency returns the n most frequent hooks regardless of type.
+func topHooksByFrequency(all []hooks.Hook, n int) []hooks.Hook {
+	sorted := make([]hooks.Hook, len(all))
+	copy(sorted, all)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		if sorted[i].Frequency != sorted[j].Frequency {
+			return sorted[i].Frequency > sorted[j].Frequency
+		}
+		return sorted[i].Pattern < sorted[j].Pattern
+	})
+	if len(sorted) > n {
+		sorted = sorted[:n]
+	}
+	return sorted
+}
+
+// FindCluster returns the cluster with the given ID.
+func (p Patterns) FindCluster(id int) (TopicCluster, bool) {
+	for _, c := range p.Clusters {
+		if c.ID == id {
+			return c, true
+		}
+	}
+	return TopicCluster{}, false
+}
+
+func dot(a, b sparseVector) float64 {
+	if len(a) > len(b) {
+		a, b = b, a
+	}
+	sum := 0.0
+	for term, w := range a {
+		sum += w * b[term]
+	}
+	return sum
+}
+
+func normalize(v sparseVector) sparseVector {
+	norm := 0.0
+	for _, w := range v {
+		norm += w * w
+	}
+	if norm == 0 {
+		return v
+	}
+	norm = math.Sqrt(norm)
+	for term := range v {
+		v[term] /= norm
+	}
+	return v
+}
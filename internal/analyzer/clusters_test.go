@@ -0,0 +1,98 @@
+package analyzer
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/mikelady/kingmaker/internal/model"
+)
+
+// twoTopicVideos returns videos from two clearly separated sub-niches.
+func twoTopicVideos() []model.Video {
+	return []model.Video{
+		{ID: "t1", Title: "Cursor tutorial for beginners", Description: "cursor setup tutorial", Tags: []string{"cursor", "tutorial"}, ViewCount: 100},
+		{ID: "t2", Title: "Cursor setup tutorial", Description: "step by step cursor tutorial", Tags: []string{"cursor"}, ViewCount: 200},
+		{ID: "t3", Title: "Full cursor tutorial", Description: "cursor tutorial walkthrough", Tags: []string{"tutorial"}, ViewCount: 300},
+		{ID: "t4", Title: "Cursor tutorial in 5 minutes", Description: "quick cursor setup", Tags: []string{"cursor"}, ViewCount: 400},
+		{ID: "m1", Title: "Funny programmer meme compilation", Description: "memes about bugs", Tags: []string{"meme", "funny"}, ViewCount: 5000},
+		{ID: "m2", Title: "Programmer memes that hit hard", Description: "funny meme humor", Tags: []string{"meme"}, ViewCount: 6000},
+		{ID: "m3", Title: "Meme review: funny bugs", Description: "funny programmer meme", Tags: []string{"funny"}, ViewCount: 7000},
+		{ID: "m4", Title: "Best funny meme of the week", Description: "programmer humor meme", Tags: []string{"meme"}, ViewCount: 8000},
+	}
+}
+
+func TestClusterTopics_SeparatesSubNiches(t *testing.T) {
+	clusters := clusterTopics(twoTopicVideos(), "", nil)
+
+	if len(clusters) != 2 {
+		t.Fatalf("expected 2 clusters, got %d: %+v", len(clusters), clusters)
+	}
+
+	groups := make(map[string]bool)
+	for _, c := range clusters {
+		if c.Size != 4 {
+			t.Errorf("cluster %d size = %d, want 4", c.ID, c.Size)
+		}
+		groups[c.VideoIDs[0][:1]] = true
+		for _, id := range c.VideoIDs {
+			if id[:1] != c.VideoIDs[0][:1] {
+				t.Errorf("cluster %d mixes topics: %v", c.ID, c.VideoIDs)
+				break
+			}
+		}
+	}
+	if !groups["t"] || !groups["m"] {
+		t.Errorf("expected one tutorial and one meme cluster, got %+v", clusters)
+	}
+}
+
+func TestClusterTopics_LabelsAndStats(t *testing.T) {
+	clusters := clusterTopics(twoTopicVideos(), "", nil)
+
+	for _, c := range clusters {
+		if len(c.Keywords) == 0 {
+			t.Fatalf("cluster %d has no label keywords", c.ID)
+		}
+		top := c.Keywords[0].Word
+		switch c.VideoIDs[0][:1] {
+		case "t":
+			if top != "cursor" && top != "tutorial" {
+				t.Errorf("tutorial cluster label = %q", top)
+			}
+			if c.MedianViews != 250 {
+				t.Errorf("tutorial MedianViews = %d, want 250", c.MedianViews)
+			}
+		case "m":
+			if top != "meme" && top != "funny" {
+				t.Errorf("meme cluster label = %q", top)
+			}
+		}
+	}
+}
+
+func TestClusterTopics_Deterministic(t *testing.T) {
+	first := clusterTopics(twoTopicVideos(), "", nil)
+	for i := 0; i < 5; i++ {
+		if got := clusterTopics(twoTopicVideos(), "", nil); !reflect.DeepEqual(got[0].VideoIDs, first[0].VideoIDs) {
+			t.Fatalf("clustering not deterministic: %v vs %v", got[0].VideoIDs, first[0].VideoIDs)
+		}
+	}
+}
+
+func TestClusterTopics_TooFewVideos(t *testing.T) {
+	videos := twoTopicVideos()[:3]
+	if clusters := clusterTopics(videos, "", nil); clusters != nil {
+		t.Errorf("expected no clusters for %d videos, got %+v", len(videos), clusters)
+	}
+}
+
+func TestPatterns_FindCluster(t *testing.T) {
+	p := Patterns{Clusters: []TopicCluster{{ID: 1, Size: 3}, {ID: 2, Size: 2}}}
+
+	if c, ok := p.FindCluster(2); !ok || c.Size != 2 {
+		t.Errorf("FindCluster(2) = %+v, %v", c, ok)
+	}
+	if _, ok := p.FindCluster(3); ok {
+		t.Error("FindCluster(3) should not be found")
+	}
+}
@@ -0,0 +1,296 @@
+package analyzer
+
+import (
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/mikelady/kingmaker/internal/keywords"
+	"github.com/mikelady/kingmaker/internal/model"
+	"github.com/mikelady/kingmaker/internal/stats"
+)
+
+// KeywordNode is a keyword in the co-occurrence graph.
+type KeywordNode struct {
+	Word      string
+	Videos    int // Videos mentioning the keyword
+	Community int // Community ID, 0 when not part of a multi-keyword community
+}
+
+// KeywordEdge links two keywords that appear in the same videos more often
+// than chance.
+type KeywordEdge struct {
+	Source      string
+	Target      string
+	Videos      int     // Videos mentioning both keywords
+	PMI         float64 // Pointwise mutual information of the pair
+	MedianViews int64   // Median views of the videos mentioning both
+}
+
+// KeywordCommunity is a group of keywords that tend to travel together.
+type KeywordCommunity struct {
+	ID          int      // 1-based, largest community first
+	Words       []string // Members, most frequent first
+	MedianViews int64    // Median views of videos mentioning any member
+}
+
+// KeywordPair is a co-occurring keyword pair ranked by performance.
+type KeywordPair struct {
+	Words       [2]string
+	Videos      int     // Videos mentioning both keywords
+	PMI         float64 // Pointwise mutual information of the pair
+	MedianViews int64   // Median views of the videos mentioning both
+	Lift        float64 // MedianViews relative to the overall median (1.0 = same)
+}
+
+// KeywordGraph is the keyword co-occurrence graph of a result set.
+type KeywordGraph struct {
+	Nodes       []KeywordNode
+	Edges       []KeywordEdge
+	Communities []KeywordCommunity
+	TopPairs    []KeywordPair // Pairs that perform, highest median views first
+}
+
+const (
+	maxGraphNodes          = 40 // Most frequent keywords kept as graph nodes
+	minGraphVideos         = 2  // Minimum videos for a keyword or pair to count
+	maxTopPairs            = 10
+	labelPropagationRounds = 20
+)
+
+// buildKeywordGraph builds a co-occurrence graph over the keywords of each
+// video's title, description and tags. Edges join keywords mentioned together
+// in at least two videos, weighted by PMI; communities are found by weighted
+// label propagation.
+func buildKeywordGraph(videos []model.Video, lang string, exclude *keywords.Exclusion) KeywordGraph {
+	docs := make([]map[string]bool, len(videos))
+	docFreq := make(map[string]int)
+	for i, v := range videos {
+		videoLang := videoLanguage(v, lang)
+		terms := make(map[string]bool)
+		for _, term := range clusterTerms(v.Title+"\n"+v.Description+"\n"+strings.Join(v.Tags, " "), videoLang) {
+			if !exclude.Excludes(term, videoLang) {
+				terms[term] = true
+			}
+		}
+		for term := range terms {
+			docFreq[term]++
+		}
+		docs[i] = terms
+	}
+
+	nodes := graphNodes(docFreq)
+	if len(nodes) < 2 {
+		return KeywordGraph{}
+	}
+	inGraph := make(map[string]bool, len(nodes))
+	for _, n := range nodes {
+		inGraph[n.Word] = true
+	}
+
+	// Collect the indexes of the videos mentioning each pair of graph keywords
+	type pairKey [2]string
+	pairVideos := make(map[pairKey][]int)
+	for i, terms := range docs {
+		words := make([]string, 0, len(terms))
+		for term := range terms {
+			if inGraph[term] {
+				words = append(words, term)
+			}
+		}
+		sort.Strings(words)
+		for a := 0; a < len(words); a++ {
+			for b := a + 1; b < len(words); b++ {
+				key := pairKey{words[a], words[b]}
+				pairVideos[key] = append(pairVideos[key], i)
+			}
+		}
+	}
+
+	n := float64(len(videos))
+	var edges []KeywordEdge
+	views := make([]int64, 0, len(videos))
+	for key, members := range pairVideos {
+		if len(members) < minGraphVideos {
+			continue
+		}
+		pmi := math.Log(float64(len(members)) * n / (float64(docFreq[key[0]]) * float64(docFreq[key[1]])))
+		if pmi <= 0 {
+			continue
+		}
+		views = views[:0]
+		for _, i := range members {
+			views = append(views, videos[i].ViewCount)
+		}
+		edges = append(edges, KeywordEdge{
+			Source:      key[0],
+			Target:      key[1],
+			Videos:      len(members),
+			PMI:         pmi,
+			MedianViews: stats.Median(views),
+		})
+	}
+	sort.Slice(edges, func(i, j int) bool {
+		if edges[i].Source != edges[j].Source {
+			return edges[i].Source < edges[j].Source
+		}
+		return edges[i].Target < edges[j].Target
+	})
+
+	graph := KeywordGraph{Nodes: nodes, Edges: edges}
+	graph.Communities = detectCommunities(&graph, docs, videos)
+	graph.TopPairs = topPairs(edges, stats.MedianViews(videos))
+	return graph
+}
+
+// graphNodes keeps the most frequent keywords mentioned in enough videos.
+func graphNodes(docFreq map[string]int) []KeywordNode {
+	var nodes []KeywordNode
+	for word, count := range docFreq {
+		if count >= minGraphVideos {
+			nodes = append(nodes, KeywordNode{Word: word, Videos: count})
+		}
+	}
+	sort.Slice(nodes, func(i, j int) bool {
+		if nodes[i].Videos != nodes[j].Videos {
+			return nodes[i].Videos > nodes[j].Videos
+		}
+		return nodes[i].Word < nodes[j].Word
+	})
+	if len(nodes) > maxGraphNodes {
+		nodes = nodes[:maxGraphNodes]
+	}
+	return nodes
+}
+
+// detectCommunities runs deterministic weighted label propagation over the
+// graph, sets each node's Community and returns the communities with at
+// least two members, largest first.
+func detectCommunities(g *KeywordGraph, docs []map[string]bool, videos []model.Video) []KeywordCommunity {
+	index := make(map[string]int, len(g.Nodes))
+	for i, n := range g.Nodes {
+		index[n.Word] = i
+	}
+	// Edges are sorted, so each neighbor list is built in a fixed order and
+	// label weights sum identically on every run
+	type neighbor struct {
+		node   int
+		weight float64
+	}
+	neighbors := make([][]neighbor, len(g.Nodes))
+	for _, e := range g.Edges {
+		a, b := index[e.Source], index[e.Target]
+		w := e.PMI * float64(e.Videos)
+		neighbors[a] = append(neighbors[a], neighbor{b, w})
+		neighbors[b] = append(neighbors[b], neighbor{a, w})
+	}
+
+	labels := make([]int, len(g.Nodes))
+	for i := range labels {
+		labels[i] = i
+	}
+	for round := 0; round < labelPropagationRounds; round++ {
+		changed := false
+		for i := range g.Nodes {
+			if len(neighbors[i]) == 0 {
+				continue
+			}
+			weights := make(map[int]float64)
+			for _, nb := range neighbors[i] {
+				weights[labels[nb.node]] += nb.weight
+			}
+			best, bestWeight := labels[i], weights[labels[i]]
+			for label, w := range weights {
+				if w > bestWeight || (w == bestWeight && label < best) {
+					best, bestWeight = label, w
+				}
+			}
+			if best != labels[i] {
+				labels[i] = best
+				changed = true
+			}
+		}
+		if !changed {
+			break
+		}
+	}
+
+	members := make(map[int][]int)
+	for i, label := range labels {
+		members[label] = append(members[label], i)
+	}
+	var groups [][]int
+	for _, m := range members {
+		if len(m) >= 2 {
+			groups = append(groups, m)
+		}
+	}
+	// Nodes are ordered by frequency, so a group's first index is its most
+	// frequent member
+	sort.Slice(groups, func(i, j int) bool {
+		if len(groups[i]) != len(groups[j]) {
+			return len(groups[i]) > len(groups[j])
+		}
+		return groups[i][0] < groups[j][0]
+	})
+
+	communities := make([]KeywordCommunity, 0, len(groups))
+	for id, group := range groups {
+		c := KeywordCommunity{ID: id + 1}
+		words := make(map[string]bool, len(group))
+		for _, i := range group {
+			g.Nodes[i].Community = c.ID
+			c.Words = append(c.Words, g.Nodes[i].Word)
+			words[g.Nodes[i].Word] = true
+		}
+		var mentioning []model.Video
+		for v, terms := range docs {
+			for w := range words {
+				if terms[w] {
+					mentioning = append(mentioning, videos[v])
+					break
+				}
+			}
+		}
+		c.MedianViews = stats.MedianViews(mentioning)
+		communities = append(communities, c)
+	}
+	return communities
+}
+
+// topPairs ranks edges by the median views of the videos mentioning both
+// keywords.
+func topPairs(edges []KeywordEdge, overall int64) []KeywordPair {
+	pairs := make([]KeywordPair, 0, len(edges))
+	for _, e := range edges {
+		lift := 0.0
+		if overall > 0 {
+			lift = float64(e.MedianViews) / float64(overall)
+		}
+		pairs = append(pairs, KeywordPair{
+			Words:       [2]string{e.Source, e.Target},
+			Videos:      e.Videos,
+			PMI:         e.PMI,
+			MedianViews: e.MedianViews,
+			Lift:        lift,
+		})
+	}
+	sort.SliceStable(pairs, func(i, j int) bool {
+		if pairs[i].MedianViews != pairs[j].MedianViews {
+			return pairs[i].MedianViews > pairs[j].MedianViews
+		}
+		if pairs[i].Videos != pairs[j].Videos {
+			return pairs[i].Videos > pairs[j].Videos
+		}
+		return pairs[i].PMI > pairs[j].PMI
+	})
+	if len(pairs) > maxTopPairs {
+		pairs = pairs[:maxTopPairs]
+	}
+	return pairs
+}
+
+// queryExclusion builds the keyword exclusion described by the options.
+func queryExclusion(opts Options) *keywords.Exclusion {
+	return keywords.NewExclusion(opts.StopWords, queryTerms(opts.ExcludeQuery))
+}
@@ -0,0 +1,108 @@
+package analyzer
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/mikelady/kingmaker/internal/keywords"
+	"github.com/mikelady/kingmaker/internal/model"
+)
+
+// pairedVideos has two keyword groups: react/nextjs/tailwind in popular
+// videos and python/django in less popular ones.
+func pairedVideos() []model.Video {
+	return []model.Video{
+		{ID: "r1", Title: "React Nextjs app", Tags: []string{"tailwind"}, ViewCount: 90000},
+		{ID: "r2", Title: "Nextjs with React", Description: "styled with tailwind", ViewCount: 80000},
+		{ID: "r3", Title: "Tailwind tricks for React", Description: "nextjs", ViewCount: 70000},
+		{ID: "p1", Title: "Python Django API", ViewCount: 2000},
+		{ID: "p2", Title: "Django for Python devs", ViewCount: 3000},
+		{ID: "p3", Title: "Python Django deploy", ViewCount: 1000},
+	}
+}
+
+func TestBuildKeywordGraph_Communities(t *testing.T) {
+	g := buildKeywordGraph(pairedVideos(), "", nil)
+
+	if len(g.Communities) != 2 {
+		t.Fatalf("Communities = %+v, want 2", g.Communities)
+	}
+	want := []string{"nextjs", "react", "tailwind"}
+	if !reflect.DeepEqual(g.Communities[0].Words, want) {
+		t.Errorf("first community = %v, want %v", g.Communities[0].Words, want)
+	}
+	if !reflect.DeepEqual(g.Communities[1].Words, []string{"django", "python"}) {
+		t.Errorf("second community = %v, want [django python]", g.Communities[1].Words)
+	}
+	if g.Communities[0].MedianViews != 80000 {
+		t.Errorf("MedianViews = %d, want 80000", g.Communities[0].MedianViews)
+	}
+
+	for _, n := range g.Nodes {
+		if n.Community == 0 {
+			t.Errorf("node %q not assigned to a community", n.Word)
+		}
+	}
+}
+
+func TestBuildKeywordGraph_EdgesUsePMI(t *testing.T) {
+	g := buildKeywordGraph(pairedVideos(), "", nil)
+
+	for _, e := range g.Edges {
+		if e.PMI <= 0 {
+			t.Errorf("edge %s-%s has non-positive PMI %v", e.Source, e.Target, e.PMI)
+		}
+		if e.Source >= e.Target {
+			t.Errorf("edge %s-%s not ordered", e.Source, e.Target)
+		}
+	}
+	for _, e := range g.Edges {
+		if (e.Source == "python" || e.Target == "python") && (e.Source == "react" || e.Target == "react") {
+			t.Error("keywords from different groups should not be linked")
+		}
+	}
+}
+
+func TestBuildKeywordGraph_TopPairs(t *testing.T) {
+	g := buildKeywordGraph(pairedVideos(), "", nil)
+
+	if len(g.TopPairs) == 0 {
+		t.Fatal("no TopPairs")
+	}
+	top := g.TopPairs[0]
+	if top.MedianViews != 80000 {
+		t.Errorf("top pair %v MedianViews = %d, want 80000", top.Words, top.MedianViews)
+	}
+	if top.Lift <= 1 {
+		t.Errorf("top pair Lift = %v, want > 1", top.Lift)
+	}
+	last := g.TopPairs[len(g.TopPairs)-1]
+	if last.Words[0] != "django" || last.Words[1] != "python" {
+		t.Errorf("last pair = %v, want [django python]", last.Words)
+	}
+}
+
+func TestBuildKeywordGraph_Exclusion(t *testing.T) {
+	g := buildKeywordGraph(pairedVideos(), "", keywords.NewExclusion([]string{"react"}, nil))
+	for _, n := range g.Nodes {
+		if n.Word == "react" {
+			t.Error("excluded keyword should not be a node")
+		}
+	}
+}
+
+func TestBuildKeywordGraph_TooSmall(t *testing.T) {
+	g := buildKeywordGraph([]model.Video{{Title: "one video"}}, "", nil)
+	if len(g.Nodes) != 0 || len(g.Edges) != 0 {
+		t.Errorf("expected empty graph, got %+v", g)
+	}
+}
+
+func TestBuildKeywordGraph_Deterministic(t *testing.T) {
+	first := buildKeywordGraph(pairedVideos(), "", nil)
+	for i := 0; i < 5; i++ {
+		if got := buildKeywordGraph(pairedVideos(), "", nil); !reflect.DeepEqual(got, first) {
+			t.Fatal("graph differs between runs")
+		}
+	}
+}
@@ -0,0 +1,359 @@
+package analyzer
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/mikelady/kingmaker/internal/hooks"
+	"github.com/mikelady/kingmaker/internal/model"
+	"github.com/mikelady/kingmaker/internal/text"
+)
+
+// LinkType categorizes a link found in a description.
+type LinkType string
+
+const (
+	LinkAffiliate LinkType = "affiliate"
+	LinkSocial    LinkType = "social"
+	LinkOwnSite   LinkType = "own_site"
+	LinkOther     LinkType = "other" // Shorteners, link hubs, code hosts, sponsors and other sites
+)
+
+// CTA is a call-to-action phrase and how many descriptions use it.
+type CTA struct {
+	Phrase string
+	Count  int
+}
+
+// HashtagPlacement counts where hashtags appear within descriptions.
+type HashtagPlacement struct {
+	Top    int // On the first line
+	Inline int // Between the first and last lines (or in one-line descriptions)
+	Bottom int // On the last line
+}
+
+// DescriptionMetrics contains aggregated description structure metrics.
+type DescriptionMetrics struct {
+	AvgLength         int              // Average description length in characters
+	AvgLines          float64          // Average number of non-empty lines
+	FirstLineHookRate float64          // Proportion whose first line contains a hook (0.0-1.0)
+	TopFirstLines     []string         // First lines of the best-performing descriptions (up to 3)
+	AvgLinks          float64          // Average number of links
+	LinkTypes         map[LinkType]int // Total links by type
+	CTARate           float64          // Proportion with at least one call to action
+	TopCTAs           []CTA            // Most common calls to action
+	SponsorRate       float64          // Proportion with a sponsor block or disclosure
+	AvgHashtags       float64          // Average number of hashtags
+	HashtagPlacement  HashtagPlacement // Where hashtags are placed
+	TimestampRate     float64          // Proportion with chapter timestamps
+	EmojiRate         float64          // Proportion containing emoji
+	AvgEmoji          float64          // Average number of emoji
+}
+
+// descriptionStructure is the structure extracted from a single description.
+type descriptionStructure struct {
+	firstLine  string
+	length     int
+	lines      int
+	links      map[LinkType]int
+	sites      []string // Hosts of links that may be the creator's own site
+	ctas       []string
+	sponsor    bool
+	hashtags   int
+	placement  HashtagPlacement
+	timestamps bool
+	emoji      int
+}
+
+var (
+	affiliateLinkRe = regexp.MustCompile(`(?i)amzn\.to|amazon\.[a-z.]+/.*[?&]tag=|geni\.us|shareasale|[?&](?:ref|aff|affiliate|via)=|/ref/|/aff/|impact\.com`)
+	genericLinkRe   = regexp.MustCompile(`(?i)(?:^|[/.])(?:bit\.ly|tinyurl\.com|goo\.gl|t\.co|ow\.ly|buff\.ly|rebrand\.ly|cutt\.ly|linktr\.ee|beacons\.ai|bio\.link|lnk\.bio|github\.com|gitlab\.com|bitbucket\.org)(?:[/:?#]|$)`)
+	linkSchemeRe    = regexp.MustCompile(`(?i)^https?://`)
+	socialLinkRe    = regexp.MustCompile(`(?i)(?:^|[/.])(?:instagram\.com|tiktok\.com|twitter\.com|x\.com|facebook\.com|fb\.com|linkedin\.com|discord\.gg|discord\.com|threads\.net|youtube\.com|youtu\.be|twitch\.tv|reddit\.com|snapchat\.com|patreon\.com)`)
+	sponsorRe       = regexp.MustCompile(`(?i)sponsored by|this video is sponsored|thanks to .{1,40} for sponsoring|paid promotion|partnered with|use (?:my )?code|promo code|discount code|#ad\b|#sponsored`)
+	timestampRe     = regexp.MustCompile(`(?m)^\s*\(?(?:\d{1,2}:)?\d{1,2}:\d{2}\)?\s`)
+
+	// ctaPatterns maps a canonical call to action to its regex.
+	ctaPatterns = []struct {
+		phrase string
+		re     *regexp.Regexp
+	}{
+		{"subscribe", regexp.MustCompile(`(?i)\bsubscribe\b`)},
+		{"link in bio", regexp.MustCompile(`(?i)\blinks? in (?:my )?bio\b`)},
+		{"like", regexp.MustCompile(`(?i)\b(?:(?:hit|smash|drop|leave) (?:a |that |the )?like|like and subscribe)\b`)},
+		{"comment", regexp.MustCompile(`(?i)\bcomment\b`)},
+		{"follow", regexp.MustCompile(`(?i)\bfollow (?:me|us)\b`)},
+		{"share", regexp.MustCompile(`(?i)\bshare (?:this|with)\b`)},
+		{"notifications", regexp.MustCompile(`(?i)\b(?:notifications?|bell)\b`)},
+		{"sign up", regexp.MustCompile(`(?i)\bsign up\b`)},
+		{"join", regexp.MustCompile(`(?i)\bjoin (?:my|our|the)\b`)},
+		{"download", regexp.MustCompile(`(?i)\bdownload\b`)},
+		{"check out", regexp.MustCompile(`(?i)\bcheck (?:it )?out\b`)},
+	}
+)
+
+const maxDescriptionCTAs = 5
+
+// minOwnSiteVideos is the number of a channel's videos that must link a
+// site for it to count as the channel's own.
+const minOwnSiteVideos = 2
+
+// analyzeDescriptions extracts structure from every non-empty description
+// and aggregates it into DescriptionMetrics.
+func analyzeDescriptions(videos []model.Video, m *hooks.Matcher) DescriptionMetrics {
+	var described []model.Video
+	var structures []descriptionStructure
+	for _, v := range videos {
+		if strings.TrimSpace(v.Description) == "" {
+			continue
+		}
+		described = append(described, v)
+		structures = append(structures, parseDescription(v.Description))
+	}
+	if len(structures) == 0 {
+		return DescriptionMetrics{}
+	}
+
+	metrics := DescriptionMetrics{LinkTypes: make(map[LinkType]int)}
+	resolveOwnSites(described, structures)
+	n := float64(len(structures))
+	ctaCounts := make(map[string]int)
+	var totalLength, totalLines, totalLinks, totalHashtags, totalEmoji int
+	var withHook, withCTA, withSponsor, withTimestamps, withEmoji int
+
+	for _, s := range structures {
+		totalLength += s.length
+		totalLines += s.lines
+		totalHashtags += s.hashtags
+		totalEmoji += s.emoji
+		for lt, count := range s.links {
+			metrics.LinkTypes[lt] += count
+			totalLinks += count
+		}
+		for _, cta := range s.ctas {
+			ctaCounts[cta]++
+		}
+		if len(s.ctas) > 0 {
+			withCTA++
+		}
+		if m.HasHook(s.firstLine) {
+			withHook++
+		}
+		if s.sponsor {
+			withSponsor++
+		}
+		if s.timestamps {
+			withTimestamps++
+		}
+		if s.emoji > 0 {
+			withEmoji++
+		}
+		metrics.HashtagPlacement.Top += s.placement.Top
+		metrics.HashtagPlacement.Inline += s.placement.Inline
+		metrics.HashtagPlacement.Bottom += s.placement.Bottom
+	}
+
+	metrics.AvgLength = (totalLength + len(structures)/2) / len(structures)
+	metrics.AvgLines = float64(totalLines) / n
+	metrics.FirstLineHookRate = float64(withHook) / n
+	metrics.TopFirstLines = topFirstLines(described, structures, 3)
+	metrics.AvgLinks = float64(totalLinks) / n
+	metrics.CTARate = float64(withCTA) / n
+	metrics.TopCTAs = topCTAs(ctaCounts, maxDescriptionCTAs)
+	metrics.SponsorRate = float64(withSponsor) / n
+	metrics.AvgHashtags = float64(totalHashtags) / n
+	metrics.TimestampRate = float64(withTimestamps) / n
+	metrics.EmojiRate = float64(withEmoji) / n
+	metrics.AvgEmoji = float64(totalEmoji) / n
+
+	return metrics
+}
+
+// parseDescription extracts the structure of a single description.
+func parseDescription(desc string) descriptionStructure {
+	var lines []string
+	for _, line := range strings.Split(desc, "\n") {
+		if strings.TrimSpace(line) != "" {
+			lines = append(lines, strings.TrimSpace(line))
+		}
+	}
+
+	s := descriptionStructure{
+		length:     text.GraphemeCount(strings.TrimSpace(desc)),
+		lines:      len(lines),
+		links:      make(map[LinkType]int),
+		sponsor:    sponsorRe.MatchString(desc),
+		timestamps: timestampRe.MatchString(desc),
+		emoji:      text.CountEmoji(desc),
+	}
+	if len(lines) > 0 {
+		s.firstLine = lines[0]
+	}
+
+	for _, url := range text.ExtractURLs(desc) {
+		lt := classifyLink(url)
+		if host := linkHost(url); lt == LinkOther && !genericLinkRe.MatchString(url) && host != "" {
+			s.sites = append(s.sites, host)
+			continue
+		}
+		s.links[lt]++
+	}
+
+	for _, cta := range ctaPatterns {
+		if cta.re.MatchString(desc) {
+			s.ctas = append(s.ctas, cta.phrase)
+		}
+	}
+
+	for i, line := range lines {
+		count := len(text.ExtractHashtags(line))
+		s.hashtags += count
+		switch {
+		case count == 0:
+		case len(lines) == 1:
+			s.placement.Inline += count
+		case i == 0:
+			s.placement.Top += count
+		case i == len(lines)-1:
+			s.placement.Bottom += count
+		default:
+			s.placement.Inline += count
+		}
+	}
+
+	return s
+}
+
+// classifyLink decides whether a link is affiliate, social or other. Which
+// other links are the creator's own site depends on the channel; see
+// resolveOwnSites.
+func classifyLink(url string) LinkType {
+	switch {
+	case affiliateLinkRe.MatchString(url):
+		return LinkAffiliate
+	case socialLinkRe.MatchString(url):
+		return LinkSocial
+	default:
+		return LinkOther
+	}
+}
+
+// linkHost returns the lowercase host of a link without "www.".
+func linkHost(url string) string {
+	host := linkSchemeRe.ReplaceAllString(url, "")
+	if i := strings.IndexAny(host, "/?#"); i >= 0 {
+		host = host[:i]
+	}
+	if i := strings.LastIndex(host, ":"); i >= 0 {
+		host = host[:i]
+	}
+	return strings.TrimPrefix(strings.ToLower(host), "www.")
+}
+
+// resolveOwnSites counts each description's candidate sites as own-site or
+// other links. A site is the channel's own when it is linked from at least
+// minOwnSiteVideos of the channel's videos or is named after the channel
+// (fireship.io for Fireship).
+func resolveOwnSites(videos []model.Video, structures []descriptionStructure) {
+	linking := make(map[string]map[string]int) // channel -> host -> videos
+	for i, s := range structures {
+		channel := videoChannel(videos[i])
+		if channel == "" {
+			continue
+		}
+		if linking[channel] == nil {
+			linking[channel] = make(map[string]int)
+		}
+		seen := make(map[string]bool)
+		for _, host := range s.sites {
+			if !seen[host] {
+				seen[host] = true
+				linking[channel][host]++
+			}
+		}
+	}
+
+	for i, s := range structures {
+		channel := videoChannel(videos[i])
+		for _, host := range s.sites {
+			if linking[channel][host] >= minOwnSiteVideos || hostNamesChannel(host, videos[i].Channel) {
+				s.links[LinkOwnSite]++
+			} else {
+				s.links[LinkOther]++
+			}
+		}
+	}
+}
+
+// videoChannel identifies a video's channel by ID, else by name.
+func videoChannel(v model.Video) string {
+	if v.ChannelID != "" {
+		return v.ChannelID
+	}
+	return v.Channel
+}
+
+// hostNamesChannel reports whether a host's registered name matches a
+// channel name, ignoring case, spaces and punctuation.
+func hostNamesChannel(host, channel string) bool {
+	normalize := func(s string) string {
+		return strings.Map(func(r rune) rune {
+			if unicode.IsLetter(r) || unicode.IsDigit(r) {
+				return unicode.ToLower(r)
+			}
+			return -1
+		}, s)
+	}
+	labels := strings.Split(host, ".")
+	if len(labels) < 2 {
+		return false
+	}
+	name := normalize(channel)
+	return name != "" && normalize(labels[len(labels)-2]) == name
+}
+
+// topFirstLines returns the first lines of the best-performing descriptions.
+func topFirstLines(videos []model.Video, structures []descriptionStructure, n int) []string {
+	idx := make([]int, len(videos))
+	for i := range idx {
+		idx[i] = i
+	}
+	sort.SliceStable(idx, func(i, j int) bool {
+		return videos[idx[i]].ViewCount > videos[idx[j]].ViewCount
+	})
+
+	lines := make([]string, 0, n)
+	seen := make(map[string]bool)
+	for _, i := range idx {
+		line := structures[i].firstLine
+		if line == "" || seen[line] {
+			continue
+		}
+		seen[line] = true
+		lines = append(lines, line)
+		if len(lines) >= n {
+			break
+		}
+	}
+	return lines
+}
+
+// topCTAs returns the most common calls to action.
+func topCTAs(counts map[string]int, n int) []CTA {
+	ctas := make([]CTA, 0, len(counts))
+	for phrase, count := range counts {
+		ctas = append(ctas, CTA{Phrase: phrase, Count: count})
+	}
+	sort.Slice(ctas, func(i, j int) bool {
+		if ctas[i].Count != ctas[j].Count {
+			return ctas[i].Count > ctas[j].Count
+		}
+		return ctas[i].Phrase < ctas[j].Phrase
+	})
+	if len(ctas) > n {
+		ctas = ctas[:n]
+	}
+	return ctas
+}
@@ -0,0 +1,149 @@
+package analyzer
+
+import (
+	"testing"
+
+	"github.com/mikelady/kingmaker/internal/model"
+)
+
+const sampleDescription = `How I built this app in 5 minutes 🚀
+Try the tool: https://amzn.to/abc123
+Follow me on Instagram: https://instagram.com/dev
+My site: https://mysite.dev
+0:00 Intro
+1:30 The build
+This video is sponsored by Acme. Use code DEV10.
+Don't forget to subscribe!
+#coding #ai`
+
+func TestParseDescription(t *testing.T) {
+	s := parseDescription(sampleDescription)
+
+	if s.firstLine != "How I built this app in 5 minutes 🚀" {
+		t.Errorf("firstLine = %q", s.firstLine)
+	}
+	if s.lines != 9 {
+		t.Errorf("lines = %d, want 9", s.lines)
+	}
+	if s.links[LinkAffiliate] != 1 || s.links[LinkSocial] != 1 || len(s.sites) != 1 || s.sites[0] != "mysite.dev" {
+		t.Errorf("links = %v, sites = %v, want one affiliate, one social and mysite.dev", s.links, s.sites)
+	}
+	if !s.sponsor {
+		t.Error("expected sponsor block")
+	}
+	if !s.timestamps {
+		t.Error("expected timestamps")
+	}
+	if s.emoji != 1 {
+		t.Errorf("emoji = %d, want 1", s.emoji)
+	}
+	if s.hashtags != 2 || s.placement.Bottom != 2 {
+		t.Errorf("hashtags = %d (placement %+v), want 2 at bottom", s.hashtags, s.placement)
+	}
+
+	foundSubscribe := false
+	for _, cta := range s.ctas {
+		if cta == "subscribe" {
+			foundSubscribe = true
+		}
+	}
+	if !foundSubscribe {
+		t.Errorf("ctas = %v, want subscribe", s.ctas)
+	}
+}
+
+func TestParseDescription_PlainText(t *testing.T) {
+	s := parseDescription("Tools like Cursor make coding 10:30 faster #ai")
+
+	if s.sponsor || s.timestamps || len(s.ctas) != 0 {
+		t.Errorf("plain description misdetected: %+v", s)
+	}
+	if s.placement.Inline != 1 {
+		t.Errorf("one-line hashtag placement = %+v, want inline", s.placement)
+	}
+}
+
+func TestClassifyLink(t *testing.T) {
+	tests := []struct {
+		url  string
+		want LinkType
+	}{
+		{"https://amzn.to/3xyz", LinkAffiliate},
+		{"https://www.amazon.com/dp/B0?tag=dev-20", LinkAffiliate},
+		{"https://tool.io/?ref=creator", LinkAffiliate},
+		{"https://www.tiktok.com/@dev", LinkSocial},
+		{"https://x.com/dev", LinkSocial},
+		{"https://discord.gg/abc", LinkSocial},
+		{"https://mysite.dev/course", LinkOther},
+		{"https://netflix.com", LinkOther},
+		{"https://bit.ly/3abc", LinkOther},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.url, func(t *testing.T) {
+			if got := classifyLink(tt.url); got != tt.want {
+				t.Errorf("classifyLink(%q) = %s, want %s", tt.url, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAnalyzeDescriptions_OwnSites(t *testing.T) {
+	videos := []model.Video{
+		{ChannelID: "UC1", Description: "Course: https://mysite.dev/course\nCode: https://github.com/dev/app"},
+		{ChannelID: "UC1", Description: "Course: www.mysite.dev\nSponsor: https://acme.com/dev\nhttps://linktr.ee/dev"},
+		{ChannelID: "UC2", Channel: "Fire Ship", Description: "Pro: https://fireship.io/pro\nhttps://bit.ly/x https://bit.ly/y"},
+	}
+
+	m := analyzeDescriptions(videos, nil)
+
+	if m.LinkTypes[LinkOwnSite] != 3 || m.LinkTypes[LinkOther] != 5 {
+		t.Errorf("LinkTypes = %v, want 3 own_site (mysite.dev twice, fireship.io) and 5 other", m.LinkTypes)
+	}
+}
+
+func TestLinkHost(t *testing.T) {
+	tests := map[string]string{
+		"https://www.MySite.dev/course?x=1": "mysite.dev",
+		"www.mysite.dev":                    "mysite.dev",
+		"http://localhost:8080/app":         "localhost",
+	}
+	for url, want := range tests {
+		if got := linkHost(url); got != want {
+			t.Errorf("linkHost(%q) = %q, want %q", url, got, want)
+		}
+	}
+}
+
+func TestAnalyzeDescriptions_Aggregates(t *testing.T) {
+	videos := []model.Video{
+		{Description: sampleDescription, ViewCount: 1000},
+		{Description: "Why this works\nsubscribe for more", ViewCount: 5000},
+		{Description: "", ViewCount: 1}, // Ignored
+	}
+
+	m := analyzeDescriptions(videos, nil)
+
+	if m.FirstLineHookRate != 1 {
+		t.Errorf("FirstLineHookRate = %.2f, want 1 (both first lines have hooks)", m.FirstLineHookRate)
+	}
+	if len(m.TopFirstLines) != 2 || m.TopFirstLines[0] != "Why this works" {
+		t.Errorf("TopFirstLines = %v, want best performer first", m.TopFirstLines)
+	}
+	if m.CTARate != 1 || len(m.TopCTAs) == 0 || m.TopCTAs[0].Phrase != "subscribe" || m.TopCTAs[0].Count != 2 {
+		t.Errorf("CTAs = %.2f %+v", m.CTARate, m.TopCTAs)
+	}
+	if m.SponsorRate != 0.5 || m.TimestampRate != 0.5 || m.EmojiRate != 0.5 {
+		t.Errorf("rates sponsor=%.2f timestamps=%.2f emoji=%.2f, want 0.5", m.SponsorRate, m.TimestampRate, m.EmojiRate)
+	}
+	if m.AvgLinks != 1.5 {
+		t.Errorf("AvgLinks = %.2f, want 1.5", m.AvgLinks)
+	}
+}
+
+func TestAnalyzeDescriptions_Empty(t *testing.T) {
+	m := analyzeDescriptions([]model.Video{{Title: "no description"}}, nil)
+	if m.AvgLength != 0 || m.LinkTypes != nil {
+		t.Errorf("expected zero metrics, got %+v", m)
+	}
+}
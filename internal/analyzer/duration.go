@@ -0,0 +1,104 @@
+package analyzer
+
+import (
+	"github.com/mikelady/kingmaker/internal/hooks"
+	"github.com/mikelady/kingmaker/internal/model"
+	"github.com/mikelady/kingmaker/internal/stats"
+)
+
+// DurationBin summarizes the videos whose duration falls in one range.
+type DurationBin struct {
+	Label          string       // Human-readable range (e.g., "15-30s")
+	MinSeconds     int          // Exclusive lower bound (zero-length videos are excluded as unknown)
+	MaxSeconds     int          // Inclusive upper bound (0 = unbounded)
+	Count          int          // Videos in this bin
+	MedianViews    int64        // Median views of those videos
+	EngagementRate float64      // Median like-to-view ratio as a percentage
+	TopHooks       []hooks.Hook // Most frequent hooks in those titles
+}
+
+// DurationAnalysis contains the duration sweet-spot analysis.
+type DurationAnalysis struct {
+	Bins        []DurationBin // Bins in ascending duration order
+	Recommended *DurationBin  // Best-performing bin, nil if there is too little data
+}
+
+const (
+	minDurationBinVideos = 2 // Minimum videos for a bin to be recommended
+	durationBinTopHooks  = 3
+)
+
+// shortFormBins cover Shorts and short clips; longFormBins are added when
+// long-form videos are part of the analysis.
+var (
+	shortFormBins = []DurationBin{
+		{Label: "0-15s", MinSeconds: 0, MaxSeconds: 15},
+		{Label: "15-30s", MinSeconds: 15, MaxSeconds: 30},
+		{Label: "30-45s", MinSeconds: 30, MaxSeconds: 45},
+		{Label: "45-60s", MinSeconds: 45, MaxSeconds: 60},
+		{Label: "1-3m", MinSeconds: 60, MaxSeconds: 180},
+	}
+	longFormBins = []DurationBin{
+		{Label: "3-10m", MinSeconds: 180, MaxSeconds: 600},
+		{Label: "10-20m", MinSeconds: 600, MaxSeconds: 1200},
+		{Label: "20m+", MinSeconds: 1200, MaxSeconds: 0},
+	}
+)
+
+// analyzeDurations bins videos by duration and computes per-bin performance.
+// Videos with an unknown (zero) duration are skipped. Long-form bins are only
+// included when includeLongForm is set; otherwise longer videos are ignored.
+func analyzeDurations(videos []model.Video, includeLongForm bool, m *hooks.Matcher) DurationAnalysis {
+	bins := append([]DurationBin{}, shortFormBins...)
+	if includeLongForm {
+		bins = append(bins, longFormBins...)
+	}
+
+	members := make([][]model.Video, len(bins))
+	for _, v := range videos {
+		if v.Duration <= 0 {
+			continue
+		}
+		for i, b := range bins {
+			if v.Duration > b.MinSeconds && (b.MaxSeconds == 0 || v.Duration <= b.MaxSeconds) {
+				members[i] = append(members[i], v)
+				break
+			}
+		}
+	}
+
+	var result DurationAnalysis
+	for i := range bins {
+		bin := &bins[i]
+		bin.Count = len(members[i])
+		if bin.Count == 0 {
+			continue
+		}
+
+		titles := make([]string, 0, bin.Count)
+		rates := make([]float64, 0, bin.Count)
+		for _, v := range members[i] {
+			if v.Title != "" {
+				titles = append(titles, v.Title)
+			}
+			rates = append(rates, v.EngagementRate())
+		}
+
+		bin.MedianViews = stats.MedianViews(members[i])
+		bin.EngagementRate = stats.Median(rates)
+		bin.TopHooks = topHooksByFrequency(m.ExtractHooks(titles), durationBinTopHooks)
+	}
+	result.Bins = bins
+
+	for i := range result.Bins {
+		bin := result.Bins[i]
+		if bin.Count < minDurationBinVideos {
+			continue
+		}
+		if result.Recommended == nil || bin.MedianViews > result.Recommended.MedianViews {
+			result.Recommended = &bin
+		}
+	}
+
+	return result
+}
@@ -0,0 +1,90 @@
+package analyzer
+
+import (
+	"testing"
+
+	"github.com/mikelady/kingmaker/internal/model"
+)
+
+func TestAnalyzeDurations_Bins(t *testing.T) {
+	videos := []model.Video{
+		{Title: "How to code", Duration: 10, ViewCount: 100, LikeCount: 10},
+		{Title: "How to ship", Duration: 15, ViewCount: 300, LikeCount: 3},
+		{Title: "Quick tip", Duration: 40, ViewCount: 5000},
+		{Title: "Deep dive", Duration: 900, ViewCount: 9000},
+		{Title: "Unknown length", Duration: 0, ViewCount: 1},
+	}
+
+	result := analyzeDurations(videos, false, nil)
+
+	if len(result.Bins) != len(shortFormBins) {
+		t.Fatalf("expected %d short-form bins, got %d", len(shortFormBins), len(result.Bins))
+	}
+
+	first := result.Bins[0]
+	if first.Label != "0-15s" || first.Count != 2 {
+		t.Errorf("first bin = %s with %d videos, want 0-15s with 2", first.Label, first.Count)
+	}
+	if first.MedianViews != 200 {
+		t.Errorf("MedianViews = %d, want 200", first.MedianViews)
+	}
+	// Engagement rates are 10% and 1%, median 5.5%
+	if first.EngagementRate < 5.49 || first.EngagementRate > 5.51 {
+		t.Errorf("EngagementRate = %.2f, want 5.5", first.EngagementRate)
+	}
+	if len(first.TopHooks) == 0 || first.TopHooks[0].Pattern != "how" {
+		t.Errorf("TopHooks = %+v, want 'how' first", first.TopHooks)
+	}
+	if result.Bins[2].Count != 1 {
+		t.Errorf("30-45s count = %d, want 1", result.Bins[2].Count)
+	}
+}
+
+func TestAnalyzeDurations_LongForm(t *testing.T) {
+	videos := []model.Video{
+		{Duration: 900, ViewCount: 100},
+		{Duration: 3600, ViewCount: 100},
+	}
+
+	short := analyzeDurations(videos, false, nil)
+	for _, b := range short.Bins {
+		if b.Count != 0 {
+			t.Errorf("long videos should be ignored without long-form bins, got %s=%d", b.Label, b.Count)
+		}
+	}
+
+	long := analyzeDurations(videos, true, nil)
+	counts := make(map[string]int)
+	for _, b := range long.Bins {
+		counts[b.Label] = b.Count
+	}
+	if counts["10-20m"] != 1 || counts["20m+"] != 1 {
+		t.Errorf("long-form counts = %v", counts)
+	}
+}
+
+func TestAnalyzeDurations_Recommended(t *testing.T) {
+	videos := []model.Video{
+		{Duration: 20, ViewCount: 1000},
+		{Duration: 25, ViewCount: 3000},
+		{Duration: 50, ViewCount: 200},
+		{Duration: 55, ViewCount: 400},
+		{Duration: 35, ViewCount: 999999}, // Single video, not enough evidence
+	}
+
+	result := analyzeDurations(videos, false, nil)
+
+	if result.Recommended == nil {
+		t.Fatal("expected a recommended duration")
+	}
+	if result.Recommended.Label != "15-30s" {
+		t.Errorf("Recommended = %s, want 15-30s", result.Recommended.Label)
+	}
+}
+
+func TestAnalyzeDurations_ShortBoundary(t *testing.T) {
+	result := analyzeDurations([]model.Video{{Duration: 60}}, false, nil)
+	if result.Bins[3].Label != "45-60s" || result.Bins[3].Count != 1 {
+		t.Errorf("60s video should fall in the 45-60s bin like model.Video.IsShort")
+	}
+}
@@ -0,0 +1,107 @@
+package analyzer
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/mikelady/kingmaker/internal/model"
+	"github.com/mikelady/kingmaker/internal/stats"
+	"github.com/mikelady/kingmaker/internal/text"
+)
+
+// Entity is a product, company or person named across the result set.
+type Entity struct {
+	Name        string  // Canonical gazetteer name, or the most common spelling
+	Frequency   int     // Mentions across titles, descriptions and tags
+	Videos      int     // Videos mentioning the entity
+	MedianViews int64   // Median views of the videos mentioning the entity
+	Lift        float64 // MedianViews relative to the overall median (1.0 = same)
+	Known       bool    // Listed in the gazetteer
+}
+
+// minHeuristicEntityVideos is the minimum number of videos an entity found
+// only by capitalization must appear in; one-off capitalized words are
+// usually not names.
+const minHeuristicEntityVideos = 2
+
+// extractTopEntities finds the entities mentioned in each video's title,
+// description and tags, and returns the top N by mentions.
+func extractTopEntities(videos []model.Video, g *text.Gazetteer, topN int) []Entity {
+	type entityStats struct {
+		canonical string // Gazetteer name, if any mention matched it
+		spellings map[string]int
+		frequency int
+		videos    []model.Video
+	}
+	byKey := make(map[string]*entityStats)
+
+	for _, v := range videos {
+		content := v.Title + "\n" + v.Description + "\n" + strings.Join(v.Tags, "\n")
+		seen := make(map[string]bool)
+		for _, m := range text.ExtractEntities(content, g) {
+			key := strings.ToLower(m.Name)
+			s, ok := byKey[key]
+			if !ok {
+				s = &entityStats{spellings: make(map[string]int)}
+				byKey[key] = s
+			}
+			if m.Known {
+				s.canonical = m.Name
+			}
+			s.spellings[m.Name]++
+			s.frequency++
+			if !seen[key] {
+				seen[key] = true
+				s.videos = append(s.videos, v)
+			}
+		}
+	}
+
+	overall := stats.MedianViews(videos)
+	entities := make([]Entity, 0, len(byKey))
+	for _, s := range byKey {
+		known := s.canonical != ""
+		if !known && len(s.videos) < minHeuristicEntityVideos {
+			continue
+		}
+		e := Entity{
+			Name:        s.canonical,
+			Frequency:   s.frequency,
+			Videos:      len(s.videos),
+			MedianViews: stats.MedianViews(s.videos),
+			Known:       known,
+		}
+		if !known {
+			e.Name = mostCommonSpelling(s.spellings)
+		}
+		if overall > 0 {
+			e.Lift = float64(e.MedianViews) / float64(overall)
+		}
+		entities = append(entities, e)
+	}
+
+	sort.Slice(entities, func(i, j int) bool {
+		if entities[i].Frequency != entities[j].Frequency {
+			return entities[i].Frequency > entities[j].Frequency
+		}
+		if entities[i].Videos != entities[j].Videos {
+			return entities[i].Videos > entities[j].Videos
+		}
+		return entities[i].Name < entities[j].Name
+	})
+	if len(entities) > topN {
+		entities = entities[:topN]
+	}
+	return entities
+}
+
+// mostCommonSpelling returns the most frequent spelling of a name.
+func mostCommonSpelling(spellings map[string]int) string {
+	best, bestCount := "", 0
+	for name, count := range spellings {
+		if count > bestCount || (count == bestCount && name < best) {
+			best, bestCount = name, count
+		}
+	}
+	return best
+}
@@ -0,0 +1,63 @@
+package analyzer
+
+import (
+	"testing"
+
+	"github.com/mikelady/kingmaker/internal/model"
+	"github.com/mikelady/kingmaker/internal/text"
+)
+
+func TestExtractTopEntities(t *testing.T) {
+	videos := []model.Video{
+		{Title: "ChatGPT vs Cursor for coding", Tags: []string{"chatgpt"}, ViewCount: 90000},
+		{Title: "I asked chatgpt to build an app", Description: "Interview with Sam Altman", ViewCount: 50000},
+		{Title: "Cursor tips", Description: "Thanks to Jordan Reyes for the idea", ViewCount: 1000},
+		{Title: "More tips", Description: "Thanks to Jordan Reyes for reviewing this build on Monday", ViewCount: 2000},
+	}
+
+	entities := extractTopEntities(videos, text.DefaultGazetteer(), 10)
+
+	byName := make(map[string]Entity)
+	for _, e := range entities {
+		byName[e.Name] = e
+	}
+
+	chatgpt, ok := byName["ChatGPT"]
+	if !ok {
+		t.Fatalf("ChatGPT not found in %+v", entities)
+	}
+	if entities[0].Name != "ChatGPT" || chatgpt.Frequency != 3 || chatgpt.Videos != 2 || !chatgpt.Known {
+		t.Errorf("ChatGPT = %+v, want first with 3 mentions in 2 videos", chatgpt)
+	}
+	if chatgpt.MedianViews != 70000 || chatgpt.Lift <= 1 {
+		t.Errorf("ChatGPT MedianViews = %d, Lift = %v", chatgpt.MedianViews, chatgpt.Lift)
+	}
+	if _, ok := byName["Sam Altman"]; !ok {
+		t.Error("known entity mentioned once should be reported")
+	}
+	if e, ok := byName["Jordan Reyes"]; !ok || e.Known {
+		t.Errorf("repeated capitalized name should be found heuristically, got %+v", e)
+	}
+	if _, ok := byName["Monday"]; ok {
+		t.Error("capitalized word in one video should not be an entity")
+	}
+}
+
+func TestExtractTopEntities_TopN(t *testing.T) {
+	videos := []model.Video{{Title: "chatgpt claude cursor replit"}}
+	if got := extractTopEntities(videos, text.DefaultGazetteer(), 2); len(got) != 2 {
+		t.Errorf("len = %d, want 2", len(got))
+	}
+}
+
+func TestAnalyzeVideos_TopEntities(t *testing.T) {
+	videos := []model.Video{
+		{Title: "Building with Replit", ViewCount: 100},
+		{Title: "Replit agent review", ViewCount: 200},
+	}
+
+	result := AnalyzeVideos(videos)
+	if len(result.TopEntities) == 0 || result.TopEntities[0].Name != "Replit" {
+		t.Errorf("TopEntities = %+v, want Replit first", result.TopEntities)
+	}
+}
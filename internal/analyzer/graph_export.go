@@ -0,0 +1,177 @@
+package analyzer
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// GraphFormat is a keyword graph export format.
+type GraphFormat string
+
+const (
+	GraphFormatGraphML GraphFormat = "graphml"
+	GraphFormatDOT     GraphFormat = "dot"
+	GraphFormatJSON    GraphFormat = "json"
+)
+
+// GraphFormatFromPath picks the export format from a file extension
+// (.graphml, .dot or .gv, .json).
+func GraphFormatFromPath(path string) (GraphFormat, error) {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".graphml":
+		return GraphFormatGraphML, nil
+	case ".dot", ".gv":
+		return GraphFormatDOT, nil
+	case ".json":
+		return GraphFormatJSON, nil
+	default:
+		return "", fmt.Errorf("unknown graph format for %q (use .graphml, .dot or .json)", path)
+	}
+}
+
+// Export writes the graph in the given format.
+func (g KeywordGraph) Export(w io.Writer, format GraphFormat) error {
+	switch format {
+	case GraphFormatGraphML:
+		return g.WriteGraphML(w)
+	case GraphFormatDOT:
+		return g.WriteDOT(w)
+	case GraphFormatJSON:
+		return g.WriteJSON(w)
+	default:
+		return fmt.Errorf("unknown graph format %q", format)
+	}
+}
+
+// WriteJSON writes the graph as indented JSON.
+func (g KeywordGraph) WriteJSON(w io.Writer) error {
+	data, err := json.MarshalIndent(g, "", "  ")
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintln(w, string(data))
+	return err
+}
+
+// WriteDOT writes the graph in Graphviz DOT format. Edge weights are PMI and
+// nodes are colored by community.
+func (g KeywordGraph) WriteDOT(w io.Writer) error {
+	var sb strings.Builder
+	sb.WriteString("graph keywords {\n")
+	sb.WriteString("  node [shape=ellipse, style=filled, colorscheme=set312];\n")
+	for _, n := range g.Nodes {
+		color := ""
+		if n.Community > 0 {
+			color = fmt.Sprintf(", fillcolor=%d", (n.Community-1)%12+1)
+		}
+		sb.WriteString(fmt.Sprintf("  %s [label=%s, videos=%d, community=%d%s];\n",
+			dotID(n.Word), dotID(n.Word), n.Videos, n.Community, color))
+	}
+	for _, e := range g.Edges {
+		sb.WriteString(fmt.Sprintf("  %s -- %s [weight=%s, videos=%d, median_views=%d];\n",
+			dotID(e.Source), dotID(e.Target), formatFloat(e.PMI), e.Videos, e.MedianViews))
+	}
+	sb.WriteString("}\n")
+
+	_, err := io.WriteString(w, sb.String())
+	return err
+}
+
+// dotID quotes a DOT identifier.
+func dotID(s string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(s) + `"`
+}
+
+// graphML is the GraphML document structure.
+type graphML struct {
+	XMLName xml.Name     `xml:"graphml"`
+	XMLNS   string       `xml:"xmlns,attr"`
+	Keys    []graphMLKey `xml:"key"`
+	Graph   graphMLGraph `xml:"graph"`
+}
+
+type graphMLKey struct {
+	ID       string `xml:"id,attr"`
+	For      string `xml:"for,attr"`
+	AttrName string `xml:"attr.name,attr"`
+	AttrType string `xml:"attr.type,attr"`
+}
+
+type graphMLGraph struct {
+	ID          string        `xml:"id,attr"`
+	EdgeDefault string        `xml:"edgedefault,attr"`
+	Nodes       []graphMLNode `xml:"node"`
+	Edges       []graphMLEdge `xml:"edge"`
+}
+
+type graphMLNode struct {
+	ID   string        `xml:"id,attr"`
+	Data []graphMLData `xml:"data"`
+}
+
+type graphMLEdge struct {
+	Source string        `xml:"source,attr"`
+	Target string        `xml:"target,attr"`
+	Data   []graphMLData `xml:"data"`
+}
+
+type graphMLData struct {
+	Key   string `xml:"key,attr"`
+	Value string `xml:",chardata"`
+}
+
+// WriteGraphML writes the graph as GraphML, readable by Gephi, yEd and
+// NetworkX.
+func (g KeywordGraph) WriteGraphML(w io.Writer) error {
+	doc := graphML{
+		XMLNS: "http://graphml.graphdrawing.org/xmlns",
+		Keys: []graphMLKey{
+			{ID: "videos", For: "node", AttrName: "videos", AttrType: "int"},
+			{ID: "community", For: "node", AttrName: "community", AttrType: "int"},
+			{ID: "weight", For: "edge", AttrName: "weight", AttrType: "double"},
+			{ID: "evideos", For: "edge", AttrName: "videos", AttrType: "int"},
+			{ID: "median_views", For: "edge", AttrName: "median_views", AttrType: "long"},
+		},
+		Graph: graphMLGraph{ID: "keywords", EdgeDefault: "undirected"},
+	}
+	for _, n := range g.Nodes {
+		doc.Graph.Nodes = append(doc.Graph.Nodes, graphMLNode{
+			ID: n.Word,
+			Data: []graphMLData{
+				{Key: "videos", Value: strconv.Itoa(n.Videos)},
+				{Key: "community", Value: strconv.Itoa(n.Community)},
+			},
+		})
+	}
+	for _, e := range g.Edges {
+		doc.Graph.Edges = append(doc.Graph.Edges, graphMLEdge{
+			Source: e.Source,
+			Target: e.Target,
+			Data: []graphMLData{
+				{Key: "weight", Value: formatFloat(e.PMI)},
+				{Key: "evideos", Value: strconv.Itoa(e.Videos)},
+				{Key: "median_views", Value: strconv.FormatInt(e.MedianViews, 10)},
+			},
+		})
+	}
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+	if err := enc.Encode(doc); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
+
+func formatFloat(f float64) string {
+	return strconv.FormatFloat(f, 'f', 4, 64)
+}
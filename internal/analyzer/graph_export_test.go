@@ -0,0 +1,90 @@
+package analyzer
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"strings"
+	"testing"
+)
+
+func sampleGraph() KeywordGraph {
+	return KeywordGraph{
+		Nodes: []KeywordNode{
+			{Word: "react", Videos: 3, Community: 1},
+			{Word: `say "hi"`, Videos: 2, Community: 1},
+		},
+		Edges: []KeywordEdge{
+			{Source: "react", Target: `say "hi"`, Videos: 2, PMI: 0.6931, MedianViews: 5000},
+		},
+	}
+}
+
+func TestGraphFormatFromPath(t *testing.T) {
+	tests := map[string]GraphFormat{
+		"out.graphml": GraphFormatGraphML,
+		"out.DOT":     GraphFormatDOT,
+		"out.gv":      GraphFormatDOT,
+		"out.json":    GraphFormatJSON,
+	}
+	for path, want := range tests {
+		got, err := GraphFormatFromPath(path)
+		if err != nil || got != want {
+			t.Errorf("GraphFormatFromPath(%q) = %q, %v; want %q", path, got, err, want)
+		}
+	}
+	if _, err := GraphFormatFromPath("out.png"); err == nil {
+		t.Error("expected error for unknown extension")
+	}
+}
+
+func TestKeywordGraph_WriteGraphML(t *testing.T) {
+	var buf bytes.Buffer
+	if err := sampleGraph().Export(&buf, GraphFormatGraphML); err != nil {
+		t.Fatalf("WriteGraphML() error = %v", err)
+	}
+
+	var doc graphML
+	if err := xml.Unmarshal(buf.Bytes(), &doc); err != nil {
+		t.Fatalf("output is not valid XML: %v\n%s", err, buf.String())
+	}
+	if len(doc.Graph.Nodes) != 2 || len(doc.Graph.Edges) != 1 {
+		t.Errorf("got %d nodes and %d edges, want 2 and 1", len(doc.Graph.Nodes), len(doc.Graph.Edges))
+	}
+	if doc.Graph.Edges[0].Target != `say "hi"` {
+		t.Errorf("edge target = %q, want escaped round trip", doc.Graph.Edges[0].Target)
+	}
+	if !strings.Contains(buf.String(), `<data key="weight">0.6931</data>`) {
+		t.Errorf("expected PMI weight in output:\n%s", buf.String())
+	}
+}
+
+func TestKeywordGraph_WriteDOT(t *testing.T) {
+	var buf bytes.Buffer
+	if err := sampleGraph().Export(&buf, GraphFormatDOT); err != nil {
+		t.Fatalf("WriteDOT() error = %v", err)
+	}
+
+	out := buf.String()
+	if !strings.HasPrefix(out, "graph keywords {") {
+		t.Errorf("unexpected DOT header:\n%s", out)
+	}
+	if !strings.Contains(out, `"react" -- "say \"hi\"" [weight=0.6931`) {
+		t.Errorf("expected escaped edge in output:\n%s", out)
+	}
+}
+
+func TestKeywordGraph_WriteJSON(t *testing.T) {
+	var buf bytes.Buffer
+	if err := sampleGraph().Export(&buf, GraphFormatJSON); err != nil {
+		t.Fatalf("WriteJSON() error = %v", err)
+	}
+
+	var g KeywordGraph
+	if err := json.Unmarshal(buf.Bytes(), &g); err != nil {
+		t.Fatalf("output is not valid JSON: %v", err)
+	}
+	if len(g.Nodes) != 2 || g.Edges[0].MedianViews != 5000 {
+		t.Errorf("JSON round trip = %+v", g)
+	}
+}
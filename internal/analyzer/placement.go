@@ -0,0 +1,131 @@
+package analyzer
+
+import (
+	"fmt"
+
+	"github.com/mikelady/kingmaker/internal/hooks"
+	"github.com/mikelady/kingmaker/internal/model"
+	"github.com/mikelady/kingmaker/internal/stats"
+)
+
+// MobileTitleChars is roughly how much of a title mobile feeds show before
+// truncating it.
+const MobileTitleChars = 40
+
+// HookDensityBucket summarizes the titles using a given number of hooks.
+type HookDensityBucket struct {
+	Label          string  // Hook count (e.g., "0", "1", "3+")
+	Hooks          int     // Hooks per title (minimum for the last bucket)
+	Count          int     // Titles in this bucket
+	MedianViews    int64   // Median views of those videos
+	EngagementRate float64 // Median like-to-view ratio as a percentage
+}
+
+// HookPlacement describes where hooks sit in titles, how many each title
+// uses, and how both relate to views.
+type HookPlacement struct {
+	TitlesWithHooks  int                 // Titles with at least one hook
+	EarlyRate        float64             // Share of those whose first hook starts within MobileTitleChars (0.0-1.0)
+	EarlyMedianViews int64               // Median views of titles with an early first hook
+	LateMedianViews  int64               // Median views of titles whose first hook starts later
+	EarlyLift        float64             // EarlyMedianViews relative to LateMedianViews (0 = no comparison)
+	MedianFirstChar  int                 // Median character position of the first hook
+	MedianFirstWord  int                 // Median word position of the first hook (0 = first word)
+	Density          []HookDensityBucket // Titles by number of hooks, in ascending order
+	BestDensity      *HookDensityBucket  // Best-performing bucket, nil if there is too little data
+}
+
+const (
+	minHookDensityVideos = 2 // Minimum titles for a density bucket to be recommended
+	maxHookDensity       = 3 // Hook counts from here on share the last bucket
+)
+
+// analyzeHookPlacement locates the hooks in each title and compares the
+// performance of titles by first hook position and by hook count.
+func analyzeHookPlacement(videos []model.Video, m *hooks.Matcher) HookPlacement {
+	var result HookPlacement
+
+	buckets := make([][]model.Video, maxHookDensity+1)
+	var early, late []model.Video
+	var firstChars, firstWords []int64
+	for _, v := range videos {
+		if v.Title == "" {
+			continue
+		}
+		matches := m.Match(v.Title)
+		n := min(distinctHooks(matches), maxHookDensity)
+		buckets[n] = append(buckets[n], v)
+		if len(matches) == 0 {
+			continue
+		}
+
+		// Matches are ordered by position, so the first is the earliest hook
+		first := matches[0]
+		firstChars = append(firstChars, int64(first.Char))
+		firstWords = append(firstWords, int64(first.Word))
+		if first.Char < MobileTitleChars {
+			early = append(early, v)
+		} else {
+			late = append(late, v)
+		}
+	}
+
+	result.TitlesWithHooks = len(firstChars)
+	if result.TitlesWithHooks > 0 {
+		result.EarlyRate = float64(len(early)) / float64(result.TitlesWithHooks)
+		result.MedianFirstChar = int(stats.Median(firstChars))
+		result.MedianFirstWord = int(stats.Median(firstWords))
+	}
+	result.EarlyMedianViews = stats.MedianViews(early)
+	result.LateMedianViews = stats.MedianViews(late)
+	if len(early) > 0 && result.LateMedianViews > 0 {
+		result.EarlyLift = float64(result.EarlyMedianViews) / float64(result.LateMedianViews)
+	}
+
+	for n, bucketVideos := range buckets {
+		bucket := HookDensityBucket{Label: densityLabel(n), Hooks: n, Count: len(bucketVideos)}
+		if len(bucketVideos) > 0 {
+			bucket.MedianViews = stats.MedianViews(bucketVideos)
+			rates := make([]float64, len(bucketVideos))
+			for i := range bucketVideos {
+				rates[i] = bucketVideos[i].EngagementRate()
+			}
+			bucket.EngagementRate = stats.Median(rates)
+		}
+		result.Density = append(result.Density, bucket)
+	}
+
+	for i := range result.Density {
+		bucket := result.Density[i]
+		if bucket.Count < minHookDensityVideos {
+			continue
+		}
+		if result.BestDensity == nil || bucket.MedianViews > result.BestDensity.MedianViews {
+			result.BestDensity = &bucket
+		}
+	}
+
+	return result
+}
+
+// densityLabel labels a hook count bucket.
+func densityLabel(n int) string {
+	if n >= maxHookDensity {
+		return fmt.Sprintf("%d+", maxHookDensity)
+	}
+	return fmt.Sprintf("%d", n)
+}
+
+// distinctHooks counts the hooks in a title, merging matches whose spans
+// overlap or nest ("how i" and "how", "most powerful" and "powerful") into
+// one. Matches must be ordered by Start, as Matcher.Match returns them.
+func distinctHooks(matches []hooks.Match) int {
+	count, end := 0, -1
+	for _, m := range matches {
+		if m.Start >= end {
+			count++
+		}
+		end = max(end, m.End)
+	}
+	return count
+}
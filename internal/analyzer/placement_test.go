@@ -0,0 +1,83 @@
+package analyzer
+
+import (
+	"testing"
+
+	"github.com/mikelady/kingmaker/internal/hooks"
+	"github.com/mikelady/kingmaker/internal/model"
+)
+
+func TestAnalyzeHookPlacement_Empty(t *testing.T) {
+	hp := analyzeHookPlacement(nil, nil)
+	if hp.TitlesWithHooks != 0 || hp.BestDensity != nil {
+		t.Errorf("analyzeHookPlacement(nil) = %+v, want empty", hp)
+	}
+	if len(hp.Density) != maxHookDensity+1 {
+		t.Errorf("Density has %d buckets, want %d", len(hp.Density), maxHookDensity+1)
+	}
+}
+
+func TestAnalyzeHookPlacement_Position(t *testing.T) {
+	videos := []model.Video{
+		{Title: "Secret Go Features", ViewCount: 9000},
+		{Title: "Free Course on Rust", ViewCount: 7000},
+		{Title: "Building a Compiler in Go, Part Twelve: The Secret Sauce", ViewCount: 1000},
+		{Title: "Refactoring Legacy Code", ViewCount: 500},
+	}
+
+	hp := analyzeHookPlacement(videos, nil)
+
+	if hp.TitlesWithHooks != 3 {
+		t.Fatalf("TitlesWithHooks = %d, want 3", hp.TitlesWithHooks)
+	}
+	if want := 2.0 / 3.0; hp.EarlyRate != want {
+		t.Errorf("EarlyRate = %v, want %v", hp.EarlyRate, want)
+	}
+	if hp.EarlyMedianViews != 8000 || hp.LateMedianViews != 1000 {
+		t.Errorf("early/late median views = %d/%d, want 8000/1000", hp.EarlyMedianViews, hp.LateMedianViews)
+	}
+	if hp.EarlyLift != 8 {
+		t.Errorf("EarlyLift = %v, want 8", hp.EarlyLift)
+	}
+	if hp.MedianFirstChar != 0 || hp.MedianFirstWord != 0 {
+		t.Errorf("median first hook at char %d word %d, want 0 and 0", hp.MedianFirstChar, hp.MedianFirstWord)
+	}
+}
+
+func TestAnalyzeHookPlacement_Density(t *testing.T) {
+	videos := []model.Video{
+		{Title: "Refactoring Legacy Code", ViewCount: 100, LikeCount: 1},
+		{Title: "Unit Testing in Go", ViewCount: 300, LikeCount: 3},
+		{Title: "The Secret Go Feature", ViewCount: 2000, LikeCount: 100},
+		{Title: "A Free Rust Course", ViewCount: 4000, LikeCount: 100},
+		{Title: "The Best Free Secret Tools", ViewCount: 1000, LikeCount: 10},
+	}
+
+	hp := analyzeHookPlacement(videos, nil)
+
+	counts := make(map[string]int)
+	for _, b := range hp.Density {
+		counts[b.Label] = b.Count
+	}
+	if counts["0"] != 2 || counts["1"] != 2 || counts["3+"] != 1 {
+		t.Errorf("density counts = %v, want 0:2 1:2 3+:1", counts)
+	}
+	if hp.BestDensity == nil || hp.BestDensity.Label != "1" || hp.BestDensity.MedianViews != 3000 {
+		t.Errorf("BestDensity = %+v, want 1 hook with median 3000 views", hp.BestDensity)
+	}
+	if hp.BestDensity != nil && hp.BestDensity.EngagementRate != 3.75 {
+		t.Errorf("BestDensity.EngagementRate = %v, want 3.75", hp.BestDensity.EngagementRate)
+	}
+}
+
+func TestDistinctHooks_MergesOverlaps(t *testing.T) {
+	m := hooks.DefaultMatcher()
+	title := "How I Built the Most Powerful Go App"
+	matches := m.Match(title)
+	if len(matches) <= 2 {
+		t.Fatalf("Match(%q) = %v, want overlapping matches", title, matches)
+	}
+	if got := distinctHooks(matches); got != 2 {
+		t.Errorf("distinctHooks(%v) = %d, want 2", matches, got)
+	}
+}
@@ -0,0 +1,190 @@
+package analyzer
+
+import (
+	"sort"
+	"time"
+
+	"github.com/mikelady/kingmaker/internal/model"
+	"github.com/mikelady/kingmaker/internal/stats"
+)
+
+// HeatmapCell summarizes the videos published in one day-of-week × hour slot.
+type HeatmapCell struct {
+	Count       int   // Number of videos published in this slot
+	MedianViews int64 // Median views of those videos
+}
+
+// PostingWindow is a recommended block of hours on one day of the week.
+type PostingWindow struct {
+	Day         time.Weekday // Day of week the window starts on, in the analysis timezone
+	StartHour   int          // First hour of the window (inclusive)
+	EndHour     int          // Last hour of the window (exclusive); below StartHour when it runs past midnight
+	Count       int          // Videos published in the window
+	MedianViews int64        // Median views of those videos
+	Lift        float64      // MedianViews relative to the overall median (1.0 = same)
+}
+
+// ChannelCadence estimates how often a channel uploads, based on the
+// channel's videos present in the result set.
+type ChannelCadence struct {
+	Channel            string
+	ChannelID          string
+	Videos             int     // Videos from this channel in the result set
+	MedianIntervalDays float64 // Median days between consecutive uploads
+	UploadsPerWeek     float64 // 7 / MedianIntervalDays
+}
+
+// PublishingAnalysis contains publishing time and cadence analysis.
+type PublishingAnalysis struct {
+	Timezone           string             // IANA name of the timezone used for bucketing
+	Heatmap            [7][24]HeatmapCell // Indexed by time.Weekday (Sunday = 0) and hour
+	OverallMedianViews int64              // Median views of all dated videos
+	Recommended        *PostingWindow     // Best posting window, nil if there is too little data
+	Cadence            []ChannelCadence   // Channels with at least two videos, most active first
+}
+
+const (
+	postingWindowHours     = 3 // Width of a recommended posting window
+	minPostingWindowVideos = 2 // Minimum videos for a window to be recommended
+)
+
+// analyzePublishing builds the day-of-week × hour-of-day heatmap in loc,
+// recommends a posting window and estimates per-channel upload cadence.
+func analyzePublishing(videos []model.Video, loc *time.Location) PublishingAnalysis {
+	if loc == nil {
+		loc = time.UTC
+	}
+	result := PublishingAnalysis{Timezone: loc.String()}
+
+	var dated []model.Video
+	var slots [7][24][]model.Video
+	for _, v := range videos {
+		if v.PublishedAt.IsZero() {
+			continue
+		}
+		dated = append(dated, v)
+		t := v.PublishedAt.In(loc)
+		slots[t.Weekday()][t.Hour()] = append(slots[t.Weekday()][t.Hour()], v)
+	}
+	if len(dated) == 0 {
+		return result
+	}
+
+	for day := range slots {
+		for hour, members := range slots[day] {
+			result.Heatmap[day][hour] = HeatmapCell{
+				Count:       len(members),
+				MedianViews: stats.MedianViews(members),
+			}
+		}
+	}
+
+	result.OverallMedianViews = stats.MedianViews(dated)
+	result.Recommended = recommendPostingWindow(slots, result.OverallMedianViews)
+	result.Cadence = estimateCadence(dated)
+
+	return result
+}
+
+// recommendPostingWindow returns the window with the highest median views
+// among windows holding enough videos to be meaningful. Windows may run
+// past midnight into the next day (Saturday night into Sunday morning).
+func recommendPostingWindow(slots [7][24][]model.Video, overall int64) *PostingWindow {
+	var best *PostingWindow
+
+	for day := range slots {
+		for start := 0; start < 24; start++ {
+			var members []model.Video
+			for hour := start; hour < start+postingWindowHours; hour++ {
+				members = append(members, slots[(day+hour/24)%7][hour%24]...)
+			}
+			if len(members) < minPostingWindowVideos {
+				continue
+			}
+
+			median := stats.MedianViews(members)
+			if best != nil && (median < best.MedianViews ||
+				(median == best.MedianViews && len(members) <= best.Count)) {
+				continue
+			}
+
+			lift := 0.0
+			if overall > 0 {
+				lift = float64(median) / float64(overall)
+			}
+			best = &PostingWindow{
+				Day:         time.Weekday(day),
+				StartHour:   start,
+				EndHour:     (start + postingWindowHours) % 24,
+				Count:       len(members),
+				MedianViews: median,
+				Lift:        lift,
+			}
+		}
+	}
+	return best
+}
+
+// estimateCadence computes the median upload interval for every channel
+// with at least two dated videos.
+func estimateCadence(videos []model.Video) []ChannelCadence {
+	type channelVideos struct {
+		name  string
+		id    string
+		times []time.Time
+	}
+	byChannel := make(map[string]*channelVideos)
+	var order []string
+
+	for _, v := range videos {
+		key := v.ChannelID
+		if key == "" {
+			key = v.Channel
+		}
+		if key == "" {
+			continue
+		}
+		cv, ok := byChannel[key]
+		if !ok {
+			cv = &channelVideos{name: v.Channel, id: v.ChannelID}
+			byChannel[key] = cv
+			order = append(order, key)
+		}
+		cv.times = append(cv.times, v.PublishedAt)
+	}
+
+	var cadence []ChannelCadence
+	for _, key := range order {
+		cv := byChannel[key]
+		if len(cv.times) < 2 {
+			continue
+		}
+
+		sort.Slice(cv.times, func(i, j int) bool { return cv.times[i].Before(cv.times[j]) })
+		intervals := make([]float64, 0, len(cv.times)-1)
+		for i := 1; i < len(cv.times); i++ {
+			intervals = append(intervals, cv.times[i].Sub(cv.times[i-1]).Hours()/24)
+		}
+		median := stats.Median(intervals)
+
+		perWeek := 0.0
+		if median > 0 {
+			perWeek = 7 / median
+		}
+		cadence = append(cadence, ChannelCadence{
+			Channel:            cv.name,
+			ChannelID:          cv.id,
+			Videos:             len(cv.times),
+			MedianIntervalDays: median,
+			UploadsPerWeek:     perWeek,
+		})
+	}
+
+	sort.SliceStable(cadence, func(i, j int) bool {
+		if cadence[i].Videos != cadence[j].Videos {
+			return cadence[i].Videos > cadence[j].Videos
+		}
+		return cadence[i].UploadsPerWeek > cadence[j].UploadsPerWeek
+	})
+	return cadence
+}
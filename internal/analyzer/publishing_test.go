@@ -0,0 +1,121 @@
+package analyzer
+
+import (
+	"testing"
+	"time"
+
+	"github.com/mikelady/kingmaker/internal/model"
+)
+
+func publishedAt(s string) time.Time {
+	t, _ := time.Parse(time.RFC3339, s)
+	return t
+}
+
+func TestAnalyzePublishing_Heatmap(t *testing.T) {
+	videos := []model.Video{
+		{PublishedAt: publishedAt("2025-03-04T17:30:00Z"), ViewCount: 1000}, // Tuesday 17h UTC
+		{PublishedAt: publishedAt("2025-03-11T17:10:00Z"), ViewCount: 3000}, // Tuesday 17h UTC
+		{PublishedAt: publishedAt("2025-03-08T09:00:00Z"), ViewCount: 50},   // Saturday 9h UTC
+		{ViewCount: 999999}, // No publish date, ignored
+	}
+
+	result := analyzePublishing(videos, time.UTC)
+
+	cell := result.Heatmap[time.Tuesday][17]
+	if cell.Count != 2 || cell.MedianViews != 2000 {
+		t.Errorf("Tuesday 17h = %+v, want count 2, median 2000", cell)
+	}
+	if result.Heatmap[time.Saturday][9].Count != 1 {
+		t.Errorf("Saturday 9h count = %d, want 1", result.Heatmap[time.Saturday][9].Count)
+	}
+	if result.OverallMedianViews != 1000 {
+		t.Errorf("OverallMedianViews = %d, want 1000", result.OverallMedianViews)
+	}
+	if result.Timezone != "UTC" {
+		t.Errorf("Timezone = %q, want UTC", result.Timezone)
+	}
+}
+
+func TestAnalyzePublishing_Timezone(t *testing.T) {
+	loc, err := time.LoadLocation("America/Mexico_City")
+	if err != nil {
+		t.Skipf("timezone data unavailable: %v", err)
+	}
+	videos := []model.Video{
+		{PublishedAt: publishedAt("2025-03-04T02:00:00Z"), ViewCount: 10}, // Monday 20h in Mexico City
+	}
+
+	result := analyzePublishing(videos, loc)
+
+	if result.Heatmap[time.Monday][20].Count != 1 {
+		t.Errorf("expected video bucketed at Monday 20h local time")
+	}
+}
+
+func TestAnalyzePublishing_RecommendedWindow(t *testing.T) {
+	videos := []model.Video{
+		{PublishedAt: publishedAt("2025-03-04T17:00:00Z"), ViewCount: 9000},
+		{PublishedAt: publishedAt("2025-03-04T18:00:00Z"), ViewCount: 11000},
+		{PublishedAt: publishedAt("2025-03-06T08:00:00Z"), ViewCount: 100},
+		{PublishedAt: publishedAt("2025-03-06T09:00:00Z"), ViewCount: 300},
+		{PublishedAt: publishedAt("2025-03-09T12:00:00Z"), ViewCount: 20000}, // Alone, not enough data
+	}
+
+	result := analyzePublishing(videos, time.UTC)
+
+	rec := result.Recommended
+	if rec == nil {
+		t.Fatal("expected a recommended window")
+	}
+	if rec.Day != time.Tuesday || rec.StartHour > 17 || rec.EndHour <= 18 {
+		t.Errorf("Recommended = %+v, want Tuesday window covering 17-18h", rec)
+	}
+	if rec.Count != 2 || rec.MedianViews != 10000 {
+		t.Errorf("Recommended count/median = %d/%d, want 2/10000", rec.Count, rec.MedianViews)
+	}
+	if rec.Lift <= 1 {
+		t.Errorf("Lift = %.2f, want > 1", rec.Lift)
+	}
+}
+
+func TestAnalyzePublishing_LateNightWindow(t *testing.T) {
+	videos := []model.Video{
+		{PublishedAt: publishedAt("2025-03-04T23:00:00Z"), ViewCount: 10000}, // Tuesday
+		{PublishedAt: publishedAt("2025-03-05T00:30:00Z"), ViewCount: 10000}, // Wednesday
+		{PublishedAt: publishedAt("2025-03-05T01:00:00Z"), ViewCount: 10000},
+		{PublishedAt: publishedAt("2025-03-08T23:00:00Z"), ViewCount: 100}, // Saturday
+		{PublishedAt: publishedAt("2025-03-09T00:00:00Z"), ViewCount: 300}, // Sunday
+	}
+
+	rec := analyzePublishing(videos, time.UTC).Recommended
+	if rec == nil {
+		t.Fatal("expected a recommended window")
+	}
+	if rec.Day != time.Tuesday || rec.StartHour != 23 || rec.EndHour != 2 || rec.Count != 3 {
+		t.Errorf("Recommended = %+v, want Tuesday 23-02h with all 3 late-night videos", rec)
+	}
+}
+
+func TestEstimateCadence(t *testing.T) {
+	videos := []model.Video{
+		{ChannelID: "UC1", Channel: "Daily Dev", PublishedAt: publishedAt("2025-03-01T10:00:00Z")},
+		{ChannelID: "UC1", Channel: "Daily Dev", PublishedAt: publishedAt("2025-03-03T10:00:00Z")},
+		{ChannelID: "UC1", Channel: "Daily Dev", PublishedAt: publishedAt("2025-03-02T10:00:00Z")},
+		{ChannelID: "UC2", Channel: "Weekly", PublishedAt: publishedAt("2025-03-01T10:00:00Z")},
+		{ChannelID: "UC2", Channel: "Weekly", PublishedAt: publishedAt("2025-03-08T10:00:00Z")},
+		{ChannelID: "UC3", Channel: "One-off", PublishedAt: publishedAt("2025-03-01T10:00:00Z")},
+	}
+
+	cadence := estimateCadence(videos)
+
+	if len(cadence) != 2 {
+		t.Fatalf("expected 2 channels with cadence, got %+v", cadence)
+	}
+	if cadence[0].Channel != "Daily Dev" || cadence[0].MedianIntervalDays != 1 || cadence[0].UploadsPerWeek != 7 {
+		t.Errorf("Daily Dev cadence = %+v", cadence[0])
+	}
+	if cadence[1].Channel != "Weekly" || cadence[1].UploadsPerWeek != 1 {
+		t.Errorf("Weekly cadence = %+v", cadence[1])
+	}
+}
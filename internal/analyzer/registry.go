@@ -0,0 +1,326 @@
+package analyzer
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/mikelady/kingmaker/internal/hooks"
+	"github.com/mikelady/kingmaker/internal/keywords"
+	"github.com/mikelady/kingmaker/internal/model"
+)
+
+// Section is the named result of a custom Analyzer.
+type Section struct {
+	Name  string   // Analyzer name
+	Title string   // Human-readable heading for plain-text output
+	Lines []string // Pre-formatted lines for plain-text output
+	Data  any      // Structured result for JSON output
+}
+
+// Analyzer is a pluggable analysis step over the fetched videos.
+// Custom analyzers are registered with Register and selected by name
+// through Options.Analyzers; their results appear in Patterns.Sections.
+type Analyzer interface {
+	Name() string
+	Analyze(videos []model.Video) Section
+}
+
+// Built-in analyzer names. Built-ins are selected by name like custom
+// analyzers, but run on the shared cleaned input and populate the typed
+// fields of Patterns rather than Patterns.Sections.
+const (
+	AnalyzerHooks        = "hooks"
+	AnalyzerKeywords     = "keywords"
+	AnalyzerKeyphrases   = "keyphrases"
+	AnalyzerHashtags     = "hashtags"
+	AnalyzerTitles       = "titles"
+	AnalyzerPlacement    = "placement"
+	AnalyzerDescriptions = "descriptions"
+	AnalyzerTemplates    = "templates"
+	AnalyzerClusters     = "clusters"
+	AnalyzerCooccurrence = "cooccurrence"
+	AnalyzerEntities     = "entities"
+	AnalyzerPublishing   = "publishing"
+	AnalyzerDurations    = "durations"
+)
+
+// analysisInput holds the per-run text collections shared by built-ins.
+type analysisInput struct {
+	videos        []model.Video
+	keywordVideos []model.Video // videos with cleaned descriptions, for keyword-based analyzers
+	titles        []string
+	descriptions  []string // cleaned descriptions
+	allTexts      []string
+	languages     []string // Language code of each allTexts entry
+}
+
+// builtinAnalyzer is a built-in analysis. Built-ins share the run's
+// cleaned texts and options, so unlike custom analyzers they are not
+// Analyzers over bare videos; run stores the result in its Patterns field.
+type builtinAnalyzer struct {
+	name string
+	run  func(in analysisInput, opts Options, p *Patterns)
+}
+
+// builtin creates a built-in analyzer whose result is stored in the Patterns
+// field returned by field.
+func builtin[T any](name string, field func(p *Patterns) *T, analyze func(in analysisInput, opts Options) T) builtinAnalyzer {
+	return builtinAnalyzer{name, func(in analysisInput, opts Options, p *Patterns) {
+		*field(p) = analyze(in, opts)
+	}}
+}
+
+// builtinAnalyzers lists the built-in analyzers in execution order.
+var builtinAnalyzers = []builtinAnalyzer{
+	builtin(AnalyzerHooks, func(p *Patterns) *[]hooks.Hook { return &p.TopHooks },
+		func(in analysisInput, opts Options) []hooks.Hook {
+			return opts.Hooks.ExtractVideoHooks(in.videos)
+		}),
+	builtin(AnalyzerKeywords, func(p *Patterns) *[]keywords.Keyword { return &p.TopKeywords },
+		func(in analysisInput, opts Options) []keywords.Keyword {
+			return keywords.ExtractKeywordsWithOptions(in.allTexts, keywords.Options{
+				TopN:       opts.TopKeywordsN,
+				Scoring:    opts.KeywordScoring,
+				Background: opts.Background,
+				Stem:       opts.StemKeywords,
+				Languages:  in.languages,
+
+				Exclude:        opts.StopWords,
+				ExcludeStemsOf: queryTerms(opts.ExcludeQuery),
+			})
+		}),
+	builtin(AnalyzerKeyphrases, func(p *Patterns) *[]keywords.Keyphrase { return &p.TopKeyphrases },
+		func(in analysisInput, opts Options) []keywords.Keyphrase {
+			return keywords.ExtractKeyphrasesWithOptions(in.allTexts, keywords.Options{
+				TopN:      opts.TopKeyphrasesN,
+				Languages: in.languages,
+
+				Exclude:        opts.StopWords,
+				ExcludeStemsOf: queryTerms(opts.ExcludeQuery),
+			})
+		}),
+	builtin(AnalyzerHashtags, func(p *Patterns) *[]Hashtag { return &p.TopHashtags },
+		func(in analysisInput, opts Options) []Hashtag {
+			return extractAndAggregateHashtags(in.descriptions, opts.TopHashtagsN)
+		}),
+	builtin(AnalyzerTitles, func(p *Patterns) *TitleMetrics { return &p.TitleMetrics },
+		func(in analysisInput, opts Options) TitleMetrics {
+			m := calculateTitleMetrics(in.titles, opts.Hooks)
+			m.StyleFeatures = calculateStyleFeatures(in.videos)
+			m.TopLengthMin, m.TopLengthMax = topPerformerLengthRange(in.videos)
+			return m
+		}),
+	builtin(AnalyzerPlacement, func(p *Patterns) *HookPlacement { return &p.HookPlacement },
+		func(in analysisInput, opts Options) HookPlacement {
+			return analyzeHookPlacement(in.videos, opts.Hooks)
+		}),
+	builtin(AnalyzerDescriptions, func(p *Patterns) *DescriptionMetrics { return &p.DescriptionMetrics },
+		func(in analysisInput, opts Options) DescriptionMetrics {
+			return analyzeDescriptions(in.videos, opts.Hooks)
+		}),
+	builtin(AnalyzerTemplates, func(p *Patterns) *[]TitleTemplate { return &p.TitleTemplates },
+		func(in analysisInput, opts Options) []TitleTemplate {
+			return induceTitleTemplates(in.videos, opts.Gazetteer)
+		}),
+	builtin(AnalyzerClusters, func(p *Patterns) *[]TopicCluster { return &p.Clusters },
+		func(in analysisInput, opts Options) []TopicCluster {
+			return clusterTopics(in.keywordVideos, opts.Language, opts.Hooks)
+		}),
+	builtin(AnalyzerCooccurrence, func(p *Patterns) *KeywordGraph { return &p.KeywordGraph },
+		func(in analysisInput, opts Options) KeywordGraph {
+			return buildKeywordGraph(in.keywordVideos, opts.Language, queryExclusion(opts))
+		}),
+	builtin(AnalyzerEntities, func(p *Patterns) *[]Entity { return &p.TopEntities },
+		func(in analysisInput, opts Options) []Entity {
+			return extractTopEntities(in.keywordVideos, opts.Gazetteer, opts.TopEntitiesN)
+		}),
+	builtin(AnalyzerPublishing, func(p *Patterns) *PublishingAnalysis { return &p.Publishing },
+		func(in analysisInput, opts Options) PublishingAnalysis {
+			return analyzePublishing(in.videos, opts.Location)
+		}),
+	builtin(AnalyzerDurations, func(p *Patterns) *DurationAnalysis { return &p.Durations },
+		func(in analysisInput, opts Options) DurationAnalysis {
+			return analyzeDurations(in.videos, opts.IncludeLongForm, opts.Hooks)
+		}),
+}
+
+// queryTerms returns the query as a one-element word list, or nil.
+func queryTerms(query string) []string {
+	if strings.TrimSpace(query) == "" {
+		return nil
+	}
+	return []string{query}
+}
+
+// BuiltinAnalyzers returns the names of the built-in analyzers.
+func BuiltinAnalyzers() []string {
+	names := make([]string, len(builtinAnalyzers))
+	for i, b := range builtinAnalyzers {
+		names[i] = b.name
+	}
+	return names
+}
+
+func isBuiltin(name string) bool {
+	for _, b := range builtinAnalyzers {
+		if b.name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// Registry holds custom analyzers selectable by name.
+type Registry struct {
+	mu        sync.RWMutex
+	analyzers map[string]Analyzer
+	order     []string
+}
+
+// NewRegistry creates an empty analyzer registry.
+func NewRegistry() *Registry {
+	return &Registry{analyzers: make(map[string]Analyzer)}
+}
+
+// DefaultRegistry is the registry used when Options.Registry is nil.
+var DefaultRegistry = NewRegistry()
+
+// Register adds a custom analyzer to the default registry.
+func Register(a Analyzer) error {
+	return DefaultRegistry.Register(a)
+}
+
+// Register adds a custom analyzer. Names must be non-empty, unique and must
+// not shadow a built-in analyzer.
+func (r *Registry) Register(a Analyzer) error {
+	if a == nil {
+		return fmt.Errorf("analyzer cannot be nil")
+	}
+	name := a.Name()
+	if name == "" {
+		return fmt.Errorf("analyzer name cannot be empty")
+	}
+	if isBuiltin(name) {
+		return fmt.Errorf("analyzer %q conflicts with a built-in analyzer", name)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, exists := r.analyzers[name]; exists {
+		return fmt.Errorf("analyzer %q is already registered", name)
+	}
+	r.analyzers[name] = a
+	r.order = append(r.order, name)
+	return nil
+}
+
+// Lookup returns the custom analyzer registered under name.
+func (r *Registry) Lookup(name string) (Analyzer, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	a, ok := r.analyzers[name]
+	return a, ok
+}
+
+// Names returns the registered custom analyzer names in registration order.
+func (r *Registry) Names() []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	names := make([]string, len(r.order))
+	copy(names, r.order)
+	return names
+}
+
+// AvailableAnalyzers returns all built-in and registered analyzer names.
+func AvailableAnalyzers(r *Registry) []string {
+	if r == nil {
+		r = DefaultRegistry
+	}
+	return append(BuiltinAnalyzers(), r.Names()...)
+}
+
+// ParseAnalyzers splits a comma-separated analyzer list (as given to the
+// -analyzers flag), validates each name against r and drops repeats.
+func ParseAnalyzers(list string, r *Registry) ([]string, error) {
+	if strings.TrimSpace(list) == "" {
+		return nil, nil
+	}
+	if r == nil {
+		r = DefaultRegistry
+	}
+
+	var names []string
+	var unknown []string
+	seen := make(map[string]bool)
+	for _, name := range strings.Split(list, ",") {
+		name = strings.TrimSpace(name)
+		if name == "" || seen[name] {
+			continue
+		}
+		seen[name] = true
+		if _, ok := r.Lookup(name); !ok && !isBuiltin(name) {
+			unknown = append(unknown, name)
+			continue
+		}
+		names = append(names, name)
+	}
+
+	if len(unknown) > 0 {
+		available := AvailableAnalyzers(r)
+		sort.Strings(available)
+		return nil, fmt.Errorf("unknown analyzer(s) %s (available: %s)",
+			strings.Join(unknown, ", "), strings.Join(available, ", "))
+	}
+	return names, nil
+}
+
+// Section returns the custom analyzer section with the given name.
+func (p Patterns) Section(name string) (Section, bool) {
+	for _, s := range p.Sections {
+		if s.Name == name {
+			return s, true
+		}
+	}
+	return Section{}, false
+}
+
+// runAnalyzers runs the selected analyzers in two passes: built-ins, in
+// execution order, fill the typed fields of p; then custom analyzers, in
+// registration order, each append one Section. An empty selection runs
+// every built-in and every registered analyzer; unknown names are ignored
+// (use ParseAnalyzers to validate user input).
+func runAnalyzers(in analysisInput, opts Options, p *Patterns) {
+	registry := opts.Registry
+	if registry == nil {
+		registry = DefaultRegistry
+	}
+
+	selected := make(map[string]bool, len(opts.Analyzers))
+	for _, name := range opts.Analyzers {
+		selected[name] = true
+	}
+	all := len(selected) == 0
+
+	for _, b := range builtinAnalyzers {
+		if all || selected[b.name] {
+			b.run(in, opts, p)
+		}
+	}
+
+	for _, name := range registry.Names() {
+		if !all && !selected[name] {
+			continue
+		}
+		a, ok := registry.Lookup(name)
+		if !ok {
+			continue
+		}
+		section := a.Analyze(in.videos)
+		if section.Name == "" {
+			section.Name = name
+		}
+		p.Sections = append(p.Sections, section)
+	}
+}
@@ -0,0 +1,166 @@
+package analyzer
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/mikelady/kingmaker/internal/model"
+)
+
+// countingAnalyzer is a custom analyzer reporting how many titles mention a word.
+type countingAnalyzer struct {
+	name string
+	word string
+}
+
+func (a countingAnalyzer) Name() string { return a.name }
+
+func (a countingAnalyzer) Analyze(videos []model.Video) Section {
+	count := 0
+	for _, v := range videos {
+		if strings.Contains(strings.ToLower(v.Title), a.word) {
+			count++
+		}
+	}
+	return Section{
+		Name:  a.name,
+		Title: "Mentions of " + a.word,
+		Lines: []string{fmt.Sprintf("%d titles", count)},
+		Data:  count,
+	}
+}
+
+func TestRegistry_Register(t *testing.T) {
+	r := NewRegistry()
+
+	if err := r.Register(countingAnalyzer{name: "mentions", word: "ai"}); err != nil {
+		t.Fatalf("Register failed: %v", err)
+	}
+	if err := r.Register(countingAnalyzer{name: "mentions", word: "go"}); err == nil {
+		t.Error("expected error for duplicate name")
+	}
+	if err := r.Register(countingAnalyzer{name: AnalyzerHooks}); err == nil {
+		t.Error("expected error for name shadowing a built-in")
+	}
+	if err := r.Register(countingAnalyzer{name: ""}); err == nil {
+		t.Error("expected error for empty name")
+	}
+
+	if _, ok := r.Lookup("mentions"); !ok {
+		t.Error("Lookup should find registered analyzer")
+	}
+	if names := r.Names(); len(names) != 1 || names[0] != "mentions" {
+		t.Errorf("Names = %v, want [mentions]", names)
+	}
+}
+
+func TestParseAnalyzers(t *testing.T) {
+	r := NewRegistry()
+	r.Register(countingAnalyzer{name: "mentions", word: "ai"})
+
+	names, err := ParseAnalyzers(" hooks, mentions ,", r)
+	if err != nil {
+		t.Fatalf("ParseAnalyzers failed: %v", err)
+	}
+	if len(names) != 2 || names[0] != "hooks" || names[1] != "mentions" {
+		t.Errorf("names = %v, want [hooks mentions]", names)
+	}
+
+	if _, err := ParseAnalyzers("hooks,bogus", r); err == nil || !strings.Contains(err.Error(), "bogus") {
+		t.Errorf("expected error naming unknown analyzer, got %v", err)
+	}
+
+	if names, err := ParseAnalyzers("", r); err != nil || names != nil {
+		t.Errorf("empty list = %v, %v; want nil, nil", names, err)
+	}
+}
+
+func TestAnalyzeVideosWithOptions_SelectsAnalyzers(t *testing.T) {
+	videos := []model.Video{
+		{Title: "How to build AI apps", Description: "AI tutorial #ai"},
+	}
+
+	opts := DefaultOptions()
+	opts.Analyzers = []string{AnalyzerHashtags}
+	opts.Registry = NewRegistry()
+
+	result := AnalyzeVideosWithOptions(videos, opts)
+
+	if len(result.TopHashtags) == 0 {
+		t.Error("expected hashtags from selected analyzer")
+	}
+	if len(result.TopHooks) != 0 || len(result.TopKeywords) != 0 {
+		t.Error("unselected analyzers should not run")
+	}
+	if result.VideoCount != 1 {
+		t.Errorf("VideoCount = %d, want 1", result.VideoCount)
+	}
+}
+
+func TestAnalyzeVideosWithOptions_CustomSections(t *testing.T) {
+	r := NewRegistry()
+	r.Register(countingAnalyzer{name: "mentions", word: "ai"})
+
+	videos := []model.Video{
+		{Title: "AI coding"},
+		{Title: "Go coding"},
+	}
+
+	opts := DefaultOptions()
+	opts.Registry = r
+
+	result := AnalyzeVideosWithOptions(videos, opts)
+
+	section, ok := result.Section("mentions")
+	if !ok {
+		t.Fatal("expected custom section when running all analyzers")
+	}
+	if section.Data != 1 {
+		t.Errorf("section Data = %v, want 1", section.Data)
+	}
+	if len(result.TopKeywords) == 0 {
+		t.Error("built-in analyzers should still run")
+	}
+
+	opts.Analyzers = []string{AnalyzerKeywords}
+	result = AnalyzeVideosWithOptions(videos, opts)
+	if _, ok := result.Section("mentions"); ok {
+		t.Error("custom analyzer should not run when not selected")
+	}
+}
+
+func TestRunAnalyzers_BuiltinAndCustomPaths(t *testing.T) {
+	r := NewRegistry()
+	r.Register(countingAnalyzer{name: "mentions", word: "ai"})
+
+	videos := []model.Video{{Title: "How I built an AI app"}, {Title: "How AI changed coding"}}
+	opts := DefaultOptions()
+	opts.Registry = r
+	opts.Analyzers = []string{"mentions", AnalyzerHooks, "mentions"}
+
+	result := AnalyzeVideosWithOptions(videos, opts)
+
+	if len(result.TopHooks) == 0 {
+		t.Error("selected built-in should fill its Patterns field")
+	}
+	if len(result.TopKeywords) != 0 {
+		t.Errorf("unselected built-in ran: TopKeywords = %v", result.TopKeywords)
+	}
+	if len(result.Sections) != 1 || result.Sections[0].Name != "mentions" {
+		t.Errorf("Sections = %+v, want one mentions section and no built-in sections", result.Sections)
+	}
+}
+
+func TestParseAnalyzers_DropsRepeats(t *testing.T) {
+	r := NewRegistry()
+	r.Register(countingAnalyzer{name: "mentions", word: "ai"})
+
+	names, err := ParseAnalyzers("mentions, hooks,mentions,hooks", r)
+	if err != nil {
+		t.Fatalf("ParseAnalyzers() error: %v", err)
+	}
+	if strings.Join(names, ",") != "mentions,hooks" {
+		t.Errorf("ParseAnalyzers() = %v, want [mentions hooks]", names)
+	}
+}
@@ -0,0 +1,42 @@
+package analyzer
+
+import (
+	"math"
+
+	"github.com/mikelady/kingmaker/internal/model"
+)
+
+// pearson returns the Pearson correlation coefficient of xs and ys, or 0 when
+// either series has no variance.
+func pearson(xs, ys []float64) float64 {
+	n := len(xs)
+	if n == 0 || n != len(ys) {
+		return 0
+	}
+
+	var sumX, sumY float64
+	for i := range xs {
+		sumX += xs[i]
+		sumY += ys[i]
+	}
+	meanX, meanY := sumX/float64(n), sumY/float64(n)
+
+	var cov, varX, varY float64
+	for i := range xs {
+		dx, dy := xs[i]-meanX, ys[i]-meanY
+		cov += dx * dy
+		varX += dx * dx
+		varY += dy * dy
+	}
+
+	if varX == 0 || varY == 0 {
+		return 0
+	}
+	return cov / math.Sqrt(varX*varY)
+}
+
+// logViews returns log(1+views), which tames the heavy tail of view counts
+// before computing correlations.
+func logViews(v model.Video) float64 {
+	return math.Log1p(float64(v.ViewCount))
+}
@@ -0,0 +1,17 @@
+package analyzer
+
+import (
+	"testing"
+)
+
+func TestPearson(t *testing.T) {
+	if got := pearson([]float64{1, 2, 3}, []float64{2, 4, 6}); got < 0.999 {
+		t.Errorf("pearson of linear series = %.3f, want 1", got)
+	}
+	if got := pearson([]float64{1, 2, 3}, []float64{6, 4, 2}); got > -0.999 {
+		t.Errorf("pearson of inverse series = %.3f, want -1", got)
+	}
+	if got := pearson([]float64{1, 1, 1}, []float64{1, 2, 3}); got != 0 {
+		t.Errorf("pearson with no variance = %.3f, want 0", got)
+	}
+}
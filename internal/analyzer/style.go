@@ -0,0 +1,195 @@
+package analyzer
+
+import (
+	"strings"
+	"unicode"
+
+	"github.com/mikelady/kingmaker/internal/model"
+	"github.com/mikelady/kingmaker/internal/stats"
+	"github.com/mikelady/kingmaker/internal/text"
+)
+
+// StyleFeature describes a title style feature and how it relates to views.
+type StyleFeature struct {
+	Name               string  // Feature name (e.g., "emoji", "all_caps_words")
+	Prevalence         float64 // Proportion of titles with the feature (0.0-1.0)
+	Mean               float64 // Mean feature value per title (count or ratio)
+	MedianViewsWith    int64   // Median views of titles with the feature
+	MedianViewsWithout int64   // Median views of titles without the feature
+	Correlation        float64 // Pearson correlation of feature value with log views (-1.0-1.0)
+}
+
+// Style feature names.
+const (
+	FeatureEmoji         = "emoji"
+	FeatureEmojiLeading  = "emoji_leading"
+	FeatureEmojiTrailing = "emoji_trailing"
+	FeatureAllCapsWords  = "all_caps_words"
+	FeatureBrackets      = "brackets"
+	FeatureExclamation   = "exclamation"
+	FeatureQuestionMark  = "question_mark"
+	FeatureFirstPerson   = "first_person"
+	FeatureLeadingNumber = "leading_number"
+)
+
+// styleFeatureOrder fixes the reporting order of style features.
+var styleFeatureOrder = []string{
+	FeatureEmoji,
+	FeatureEmojiLeading,
+	FeatureEmojiTrailing,
+	FeatureAllCapsWords,
+	FeatureBrackets,
+	FeatureExclamation,
+	FeatureQuestionMark,
+	FeatureFirstPerson,
+	FeatureLeadingNumber,
+}
+
+var firstPersonWords = map[string]bool{
+	"i": true, "i'm": true, "i've": true, "i'll": true, "i'd": true,
+	"me": true, "my": true, "mine": true, "myself": true,
+	"we": true, "we're": true, "we've": true, "us": true, "our": true, "ours": true,
+}
+
+// calculateStyleFeatures measures each style feature per title and correlates
+// it with views across the videos that have a title.
+func calculateStyleFeatures(videos []model.Video) []StyleFeature {
+	var titled []model.Video
+	for _, v := range videos {
+		if v.Title != "" {
+			titled = append(titled, v)
+		}
+	}
+	if len(titled) == 0 {
+		return nil
+	}
+
+	values := make(map[string][]float64, len(styleFeatureOrder))
+	views := make([]float64, len(titled))
+	for i, v := range titled {
+		views[i] = logViews(v)
+		for name, value := range titleStyle(v.Title) {
+			if values[name] == nil {
+				values[name] = make([]float64, len(titled))
+			}
+			values[name][i] = value
+		}
+	}
+
+	features := make([]StyleFeature, 0, len(styleFeatureOrder))
+	for _, name := range styleFeatureOrder {
+		series := values[name]
+		if series == nil {
+			series = make([]float64, len(titled))
+		}
+
+		var with, without []model.Video
+		var sum float64
+		for i, value := range series {
+			sum += value
+			if value > 0 {
+				with = append(with, titled[i])
+			} else {
+				without = append(without, titled[i])
+			}
+		}
+
+		features = append(features, StyleFeature{
+			Name:               name,
+			Prevalence:         float64(len(with)) / float64(len(titled)),
+			Mean:               sum / float64(len(titled)),
+			MedianViewsWith:    stats.MedianViews(with),
+			MedianViewsWithout: stats.MedianViews(without),
+			Correlation:        pearson(series, views),
+		})
+	}
+	return features
+}
+
+// titleStyle returns the value of every style feature for a single title.
+func titleStyle(title string) map[string]float64 {
+	features := make(map[string]float64, len(styleFeatureOrder))
+
+	emoji := text.EmojiIndexes(title)
+	features[FeatureEmoji] = float64(len(emoji))
+	if len(emoji) > 0 {
+		clusters := text.Graphemes(strings.TrimSpace(title))
+		if emoji[0] == leadingIndex(clusters) {
+			features[FeatureEmojiLeading] = 1
+		}
+		if emoji[len(emoji)-1] >= trailingIndex(clusters) {
+			features[FeatureEmojiTrailing] = 1
+		}
+	}
+
+	words := strings.Fields(title)
+	var capsWords, letterWords, firstPerson int
+	for _, w := range words {
+		letters, upper := 0, 0
+		for _, r := range w {
+			if unicode.IsLetter(r) {
+				letters++
+				if unicode.IsUpper(r) {
+					upper++
+				}
+			}
+		}
+		if letters > 0 {
+			letterWords++
+		}
+		if letters >= 2 && upper == letters {
+			capsWords++
+		}
+
+		bare := strings.ToLower(strings.TrimFunc(w, func(r rune) bool {
+			return !unicode.IsLetter(r) && r != '\''
+		}))
+		if firstPersonWords[strings.ReplaceAll(bare, "’", "'")] {
+			firstPerson++
+		}
+	}
+	if letterWords > 0 {
+		features[FeatureAllCapsWords] = float64(capsWords) / float64(letterWords)
+	}
+	features[FeatureFirstPerson] = float64(firstPerson)
+
+	if strings.ContainsAny(title, "[]()") {
+		features[FeatureBrackets] = 1
+	}
+	features[FeatureExclamation] = float64(strings.Count(title, "!"))
+	features[FeatureQuestionMark] = float64(strings.Count(title, "?"))
+
+	trimmed := strings.TrimSpace(title)
+	if trimmed != "" && (unicode.IsDigit([]rune(trimmed)[0]) || strings.HasPrefix(trimmed, "$")) {
+		features[FeatureLeadingNumber] = 1
+	}
+
+	return features
+}
+
+// leadingIndex returns the index of the first non-space grapheme.
+func leadingIndex(clusters []string) int {
+	for i, c := range clusters {
+		if strings.TrimSpace(c) != "" {
+			return i
+		}
+	}
+	return 0
+}
+
+// trailingIndex returns the index of the last word grapheme, skipping
+// trailing spaces and punctuation so "Wow 🔥!" still counts as trailing.
+func trailingIndex(clusters []string) int {
+	for i := len(clusters) - 1; i >= 0; i-- {
+		c := clusters[i]
+		if strings.TrimSpace(c) == "" {
+			continue
+		}
+		r := []rune(c)[0]
+		if unicode.IsPunct(r) {
+			continue
+		}
+		return i
+	}
+	return len(clusters) - 1
+}
@@ -0,0 +1,83 @@
+package analyzer
+
+import (
+	"testing"
+
+	"github.com/mikelady/kingmaker/internal/model"
+)
+
+func TestTitleStyle(t *testing.T) {
+	tests := []struct {
+		name    string
+		title   string
+		feature string
+		want    float64
+	}{
+		{"emoji count", "🔥 Coding 🚀 fast", FeatureEmoji, 2},
+		{"emoji leading", "🔥 Coding fast", FeatureEmojiLeading, 1},
+		{"emoji not leading", "Coding 🔥 fast", FeatureEmojiLeading, 0},
+		{"emoji trailing", "Coding fast 🔥!", FeatureEmojiTrailing, 1},
+		{"all caps ratio", "THIS is INSANE", FeatureAllCapsWords, 2.0 / 3.0},
+		{"single letter not caps", "I built it", FeatureAllCapsWords, 0},
+		{"brackets", "Coding (full tutorial)", FeatureBrackets, 1},
+		{"exclamation", "Wow!! Amazing!", FeatureExclamation, 3},
+		{"question mark", "Is this real?", FeatureQuestionMark, 1},
+		{"first person", "I made my own app", FeatureFirstPerson, 2},
+		{"curly apostrophe", "I’m quitting", FeatureFirstPerson, 1},
+		{"leading number", "5 tips for Go", FeatureLeadingNumber, 1},
+		{"leading money", "$1000 in a day", FeatureLeadingNumber, 1},
+		{"no leading number", "Tips for Go 5", FeatureLeadingNumber, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := titleStyle(tt.title)[tt.feature]
+			if got < tt.want-0.001 || got > tt.want+0.001 {
+				t.Errorf("titleStyle(%q)[%s] = %.3f, want %.3f", tt.title, tt.feature, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCalculateStyleFeatures_Correlation(t *testing.T) {
+	videos := []model.Video{
+		{Title: "WOW 🔥", ViewCount: 100000},
+		{Title: "Amazing 🔥", ViewCount: 80000},
+		{Title: "plain title", ViewCount: 100},
+		{Title: "another plain one", ViewCount: 200},
+	}
+
+	features := calculateStyleFeatures(videos)
+
+	if len(features) != len(styleFeatureOrder) {
+		t.Fatalf("expected %d features, got %d", len(styleFeatureOrder), len(features))
+	}
+
+	var emoji StyleFeature
+	for _, f := range features {
+		if f.Name == FeatureEmoji {
+			emoji = f
+		}
+	}
+	if emoji.Prevalence != 0.5 {
+		t.Errorf("emoji Prevalence = %.2f, want 0.5", emoji.Prevalence)
+	}
+	if emoji.Correlation <= 0.9 {
+		t.Errorf("emoji Correlation = %.2f, want strongly positive", emoji.Correlation)
+	}
+	if emoji.MedianViewsWith != 90000 || emoji.MedianViewsWithout != 150 {
+		t.Errorf("emoji medians = %d/%d, want 90000/150", emoji.MedianViewsWith, emoji.MedianViewsWithout)
+	}
+}
+
+func TestTitleMetrics_CountsGraphemesNotBytes(t *testing.T) {
+	videos := []model.Video{
+		{Title: "Café 🔥"}, // 6 characters, 11 bytes
+	}
+
+	result := AnalyzeVideos(videos)
+
+	if result.TitleMetrics.AvgLength != 6 {
+		t.Errorf("AvgLength = %d, want 6", result.TitleMetrics.AvgLength)
+	}
+}
@@ -0,0 +1,201 @@
+package analyzer
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/mikelady/kingmaker/internal/model"
+	"github.com/mikelady/kingmaker/internal/stats"
+	"github.com/mikelady/kingmaker/internal/text"
+)
+
+// TitleTemplate is a title skeleton shared by several videos, with the
+// variable parts (numbers, durations, years, names, quotes) replaced by slots.
+type TitleTemplate struct {
+	Template    string   // Normalized skeleton (e.g., "I built [THING] in [N] [UNIT]")
+	Count       int      // Number of titles matching this template
+	Examples    []string // Best-performing example titles (up to 3)
+	MedianViews int64    // Median views of matching videos
+}
+
+// Template slot placeholders.
+const (
+	slotNumber = "[N]"
+	slotUnit   = "[UNIT]"
+	slotYear   = "[YEAR]"
+	slotThing  = "[THING]"
+	slotQuote  = "[QUOTE]"
+)
+
+const (
+	minTemplateCount  = 2  // A template must recur at least this often
+	maxTitleTemplates = 10 // Maximum templates returned
+)
+
+// Slot detection regexes, applied in order.
+var (
+	quotedRe   = regexp.MustCompile(`"[^"]+"|“[^”]+”`)
+	durationRe = regexp.MustCompile(`(?i)\b\d+(?:\.\d+)?\s*(?:seconds?|secs?|minutes?|mins?|hours?|hrs?|days?|weeks?|months?|years?)\b`)
+	yearRe     = regexp.MustCompile(`\b(?:19|20)\d{2}\b`)
+	numberRe   = regexp.MustCompile(`\$?\d+(?:[.,]\d+)*[kKmM%]?`)
+	hashtagRe  = regexp.MustCompile(`#\S+`)
+	slotRe     = regexp.MustCompile(`\[(?:N|UNIT|YEAR|THING|QUOTE)\]`)
+)
+
+// induceTitleTemplates normalizes each title into a slotted skeleton and
+// groups videos sharing the same skeleton into recurring templates. Names
+// are recognized with the gazetteer g, which may be nil.
+func induceTitleTemplates(videos []model.Video, g *text.Gazetteer) []TitleTemplate {
+	groups := make(map[string][]model.Video)
+
+	for _, v := range videos {
+		if v.Title == "" {
+			continue
+		}
+		skeleton := titleSkeleton(v.Title, g)
+		if !slotRe.MatchString(skeleton) {
+			continue // Identical titles without slots are not templates
+		}
+		groups[skeleton] = append(groups[skeleton], v)
+	}
+
+	var result []TitleTemplate
+	for skeleton, members := range groups {
+		if len(members) < minTemplateCount {
+			continue
+		}
+		result = append(result, TitleTemplate{
+			Template:    skeleton,
+			Count:       len(members),
+			Examples:    topTitlesByViews(members, 3),
+			MedianViews: stats.MedianViews(members),
+		})
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Count != result[j].Count {
+			return result[i].Count > result[j].Count
+		}
+		if result[i].MedianViews != result[j].MedianViews {
+			return result[i].MedianViews > result[j].MedianViews
+		}
+		return result[i].Template < result[j].Template
+	})
+
+	if len(result) > maxTitleTemplates {
+		result = result[:maxTitleTemplates]
+	}
+	return result
+}
+
+// MatchTemplate returns the first of templates that a title follows. g
+// should be the gazetteer the templates were induced with.
+func MatchTemplate(title string, templates []TitleTemplate, g *text.Gazetteer) (TitleTemplate, bool) {
+	skeleton := titleSkeleton(title, g)
+	for _, t := range templates {
+		if t.Template == skeleton {
+			return t, true
+		}
+	}
+	return TitleTemplate{}, false
+}
+
+// titleSkeleton replaces the variable parts of a title with slots and
+// normalizes the rest (lowercase, no punctuation, collapsed whitespace).
+// Names found by text.ExtractEntities become [THING].
+func titleSkeleton(title string, g *text.Gazetteer) string {
+	s := hashtagRe.ReplaceAllString(title, " ")
+	mentions := text.ExtractEntities(s, g)
+	s = quotedRe.ReplaceAllString(s, " "+slotQuote+" ")
+	for _, m := range mentions {
+		s = replaceWords(s, m.Text, " "+slotThing+" ")
+	}
+	s = durationRe.ReplaceAllString(s, " "+slotNumber+" "+slotUnit+" ")
+	s = yearRe.ReplaceAllString(s, " "+slotYear+" ")
+	s = numberRe.ReplaceAllStringFunc(s, func(m string) string {
+		if strings.HasPrefix(m, "$") {
+			return " $" + slotNumber + " "
+		}
+		return " " + slotNumber + " "
+	})
+
+	words := strings.Fields(s)
+	out := make([]string, 0, len(words))
+	for _, w := range words {
+		if slotRe.MatchString(w) {
+			out = appendSlot(out, w)
+			continue
+		}
+
+		cleaned := strings.ToLower(strings.TrimFunc(w, func(r rune) bool {
+			return !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '$'
+		}))
+		if cleaned == "" {
+			continue
+		}
+		if cleaned == "i" {
+			cleaned = "I"
+		}
+		out = append(out, cleaned)
+	}
+
+	return strings.Join(out, " ")
+}
+
+// appendSlot appends a slot, collapsing consecutive [THING] slots so that
+// multi-word names produce a single placeholder.
+func appendSlot(out []string, slot string) []string {
+	if slot == slotThing && len(out) > 0 && out[len(out)-1] == slotThing {
+		return out
+	}
+	return append(out, slot)
+}
+
+// replaceWords replaces the whole-word occurrences of word in s with repl.
+func replaceWords(s, word, repl string) string {
+	var b strings.Builder
+	for {
+		i := strings.Index(s, word)
+		if i < 0 {
+			break
+		}
+		before, _ := utf8.DecodeLastRuneInString(s[:i])
+		after, _ := utf8.DecodeRuneInString(s[i+len(word):])
+		b.WriteString(s[:i])
+		if isWordRune(before) || isWordRune(after) {
+			b.WriteString(word)
+		} else {
+			b.WriteString(repl)
+		}
+		s = s[i+len(word):]
+	}
+	b.WriteString(s)
+	return b.String()
+}
+
+// isWordRune reports whether r is a letter or digit; utf8.RuneError, as
+// returned at the ends of a string, is not.
+func isWordRune(r rune) bool {
+	return r != utf8.RuneError && (unicode.IsLetter(r) || unicode.IsDigit(r))
+}
+
+// topTitlesByViews returns up to n titles ordered by view count (highest first).
+func topTitlesByViews(videos []model.Video, n int) []string {
+	sorted := make([]model.Video, len(videos))
+	copy(sorted, videos)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].ViewCount > sorted[j].ViewCount
+	})
+
+	titles := make([]string, 0, n)
+	for _, v := range sorted {
+		if len(titles) >= n {
+			break
+		}
+		titles = append(titles, v.Title)
+	}
+	return titles
+}
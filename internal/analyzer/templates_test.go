@@ -0,0 +1,120 @@
+package analyzer
+
+import (
+	"testing"
+
+	"github.com/mikelady/kingmaker/internal/model"
+	"github.com/mikelady/kingmaker/internal/text"
+)
+
+func TestTitleSkeleton(t *testing.T) {
+	tests := []struct {
+		name  string
+		title string
+		want  string
+	}{
+		{"duration and entity", "I built a Todo App in 5 minutes", "I built a [THING] in [N] [UNIT]"},
+		{"camel case entity", "I built an app with ChatGPT in 10 mins", "I built an app with [THING] in [N] [UNIT]"},
+		{"year", "The best AI tools of 2025", "the best [THING] tools of [YEAR]"},
+		{"quoted phrase", `Why "vibe coding" is taking over`, "why [QUOTE] is taking over"},
+		{"plain number", "3 mistakes beginners make", "[N] mistakes beginners make"},
+		{"title case ignores capitals", "How I Made $1000 With One Prompt", "how I made $[N] with one prompt"},
+		{"hashtags dropped", "Coding in 30 seconds #shorts", "coding in [N] [UNIT]"},
+		{"gazetteer name in lowercase", "vibe coding with chatgpt in 5 minutes", "vibe coding with [THING] in [N] [UNIT]"},
+		{"name at the start", "ChatGPT wrote my app in 2 hours", "[THING] wrote my app in [N] [UNIT]"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := titleSkeleton(tt.title, text.DefaultGazetteer())
+			if got != tt.want {
+				t.Errorf("titleSkeleton(%q) = %q, want %q", tt.title, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestReplaceWords(t *testing.T) {
+	tests := map[string]string{
+		"AI tools":      "[THING] tools",
+		"FAIR AI, AIR":  "FAIR [THING], AIR",
+		"tools for AI":  "tools for [THING]",
+		"no names here": "no names here",
+	}
+	for in, want := range tests {
+		if got := replaceWords(in, "AI", "[THING]"); got != want {
+			t.Errorf("replaceWords(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestInduceTitleTemplates_GroupsBySkeleton(t *testing.T) {
+	videos := []model.Video{
+		{Title: "I built a Todo App in 5 minutes", ViewCount: 100},
+		{Title: "I built a Chess Engine in 2 hours", ViewCount: 300},
+		{Title: "I built a Website in 30 seconds", ViewCount: 200},
+		{Title: "Random unrelated title", ViewCount: 50},
+	}
+
+	templates := induceTitleTemplates(videos, nil)
+
+	if len(templates) != 1 {
+		t.Fatalf("expected 1 template, got %d: %+v", len(templates), templates)
+	}
+
+	tt := templates[0]
+	if tt.Template != "I built a [THING] in [N] [UNIT]" {
+		t.Errorf("Template = %q", tt.Template)
+	}
+	if tt.Count != 3 {
+		t.Errorf("Count = %d, want 3", tt.Count)
+	}
+	if tt.MedianViews != 200 {
+		t.Errorf("MedianViews = %d, want 200", tt.MedianViews)
+	}
+	if len(tt.Examples) != 3 || tt.Examples[0] != "I built a Chess Engine in 2 hours" {
+		t.Errorf("Examples should be ordered by views, got %v", tt.Examples)
+	}
+}
+
+func TestInduceTitleTemplates_IgnoresSlotlessDuplicates(t *testing.T) {
+	videos := []model.Video{
+		{Title: "watch this"},
+		{Title: "Watch this!"},
+	}
+
+	if templates := induceTitleTemplates(videos, nil); len(templates) != 0 {
+		t.Errorf("expected no templates for slotless titles, got %+v", templates)
+	}
+}
+
+func TestMatchTemplate(t *testing.T) {
+	templates := []TitleTemplate{
+		{Template: "[N] mistakes beginners make", Count: 2},
+		{Template: "I built a [THING] in [N] [UNIT]", Count: 3},
+	}
+
+	got, ok := MatchTemplate("I built a Chess Engine in 2 hours", templates, nil)
+	if !ok || got.Template != "I built a [THING] in [N] [UNIT]" {
+		t.Errorf("MatchTemplate() = %+v, %v, want the I built template", got, ok)
+	}
+	if _, ok := MatchTemplate("Random unrelated title", templates, nil); ok {
+		t.Error("MatchTemplate() matched a title following no template")
+	}
+}
+
+func TestAnalyzeVideos_TitleTemplates(t *testing.T) {
+	videos := []model.Video{
+		{Title: "Top 5 AI tools in 2025"},
+		{Title: "Top 10 AI tools in 2024"},
+	}
+
+	result := AnalyzeVideos(videos)
+
+	if len(result.TitleTemplates) != 1 {
+		t.Fatalf("expected 1 template, got %+v", result.TitleTemplates)
+	}
+	if result.TitleTemplates[0].Template != "top [N] [THING] tools in [YEAR]" {
+		t.Errorf("Template = %q", result.TitleTemplates[0].Template)
+	}
+}
@@ -5,8 +5,16 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"strings"
+	"time"
 
 	"github.com/mikelady/kingmaker/internal/analyzer"
+	"github.com/mikelady/kingmaker/internal/hooks"
+	"github.com/mikelady/kingmaker/internal/prompt"
+	"github.com/mikelady/kingmaker/internal/score"
+	"github.com/mikelady/kingmaker/internal/target"
+	"github.com/mikelady/kingmaker/internal/text"
+	"github.com/mikelady/kingmaker/internal/variants"
 )
 
 // Options configures output formatting.
@@ -14,10 +22,18 @@ type Options struct {
 	JSON        bool // Output as JSON instead of plain text
 	ShowSummary bool // Show summary statistics
 	Verbose     bool // Show additional details
+	Explain     bool // Show why each prompt was generated
+
+	Target target.Target // Clipping tool prompts are labeled for (default OpusClip)
 }
 
-// DisplayPrompts writes prompts to the given writer.
-func DisplayPrompts(w io.Writer, prompts []string, opts Options) {
+// maxExplainedVideos is the number of supporting video IDs listed per prompt
+// in the explain view.
+const maxExplainedVideos = 5
+
+// DisplayPrompts writes prompts to the given writer. The explain view adds
+// each prompt's strategy, sources and supporting videos.
+func DisplayPrompts(w io.Writer, prompts []prompt.Prompt, opts Options) {
 	if len(prompts) == 0 {
 		if opts.JSON {
 			fmt.Fprintln(w, "[]")
@@ -35,18 +51,22 @@ func DisplayPrompts(w io.Writer, prompts []string, opts Options) {
 
 	// Plain text format
 	fmt.Fprintln(w, "═══════════════════════════════════════════════════════════")
-	fmt.Fprintln(w, "  OPUSCLIP PROMPTS")
+	tool := opts.Target.OrDefault()
+	fmt.Fprintf(w, "  %s PROMPTS\n", strings.ToUpper(tool.Label))
 	fmt.Fprintln(w, "═══════════════════════════════════════════════════════════")
 	fmt.Fprintln(w)
 
-	for i, prompt := range prompts {
-		fmt.Fprintf(w, "  %d. %s\n", i+1, prompt)
+	for i, p := range prompts {
+		fmt.Fprintf(w, "  %d. %s\n", i+1, p.Text)
+		if opts.Explain {
+			explainPrompt(w, p)
+		}
 		fmt.Fprintln(w)
 	}
 
 	if opts.ShowSummary {
 		fmt.Fprintln(w, "───────────────────────────────────────────────────────────")
-		fmt.Fprintf(w, "  Generated %d prompt(s)\n", len(prompts))
+		fmt.Fprintf(w, "  Generated %d prompt(s) for %s %s (max %d characters)\n", len(prompts), tool.Label, tool.ClipFeature, tool.MaxClipChars)
 		fmt.Fprintln(w, "═══════════════════════════════════════════════════════════")
 	}
 }
@@ -77,6 +97,56 @@ func DisplayPatterns(w io.Writer, patterns analyzer.Patterns, opts Options) {
 			fmt.Fprintf(w, "    • %s (%s) - %d occurrences\n", h.Pattern, h.Type.String(), h.Frequency)
 		}
 		fmt.Fprintln(w)
+
+		// Every hook type found, with its most frequent patterns
+		fmt.Fprintln(w, "  Hook Types:")
+		for _, typ := range hooks.HookTypes {
+			var found []string
+			for _, h := range patterns.TopHooks {
+				if h.Type == typ && len(found) < 3 {
+					found = append(found, fmt.Sprintf("%s (%d)", h.Pattern, h.Frequency))
+				}
+			}
+			if len(found) > 0 {
+				fmt.Fprintf(w, "    • %s: %s\n", typ, strings.Join(found, ", "))
+			}
+		}
+		fmt.Fprintln(w)
+	}
+
+	// Hook Placement
+	if hp := patterns.HookPlacement; hp.TitlesWithHooks > 0 {
+		fmt.Fprintln(w, "  Hook Placement:")
+		fmt.Fprintf(w, "    • %.0f%% of hooked titles open a hook in the first %d characters", hp.EarlyRate*100, analyzer.MobileTitleChars)
+		if hp.EarlyLift > 0 {
+			fmt.Fprintf(w, " (median %d vs %d views, %.1fx)", hp.EarlyMedianViews, hp.LateMedianViews, hp.EarlyLift)
+		}
+		fmt.Fprintln(w)
+		fmt.Fprintf(w, "    • First hook at character %d, word %d (median)\n", hp.MedianFirstChar, hp.MedianFirstWord+1)
+		fmt.Fprintln(w, "  Hooks Per Title:")
+		for _, b := range hp.Density {
+			if b.Count == 0 {
+				continue
+			}
+			marker := " "
+			if hp.BestDensity != nil && hp.BestDensity.Label == b.Label {
+				marker = "★"
+			}
+			fmt.Fprintf(w, "   %s %-3s %3d videos, median %d views, %.1f%% engagement\n", marker, b.Label, b.Count, b.MedianViews, b.EngagementRate)
+		}
+		fmt.Fprintln(w)
+	}
+
+	// Hooks That Perform
+	if best := hooks.BestPerforming(patterns.TopHooks, 5); len(best) > 0 {
+		fmt.Fprintln(w, "  Hooks That Perform:")
+		for _, h := range best {
+			fmt.Fprintf(w, "    • %s (%s) - %d videos, median %d views, %.1f%% engagement, %.1fx\n", h.Pattern, h.Type, h.Frequency, h.MedianViews, h.EngagementRate, h.Lift)
+			if opts.Verbose && len(h.Examples) > 0 {
+				fmt.Fprintf(w, "      e.g. %q\n", h.Examples[0])
+			}
+		}
+		fmt.Fprintln(w)
 	}
 
 	// Top Keywords
@@ -86,11 +156,39 @@ func DisplayPatterns(w io.Writer, patterns analyzer.Patterns, opts Options) {
 			if i >= 10 {
 				break
 			}
+			if len(kw.Variants) > 0 {
+				fmt.Fprintf(w, "    • %s (%d) [%s]\n", kw.Word, kw.Frequency, strings.Join(kw.Variants, ", "))
+				continue
+			}
 			fmt.Fprintf(w, "    • %s (%d)\n", kw.Word, kw.Frequency)
 		}
 		fmt.Fprintln(w)
 	}
 
+	// Top Keyphrases
+	if len(patterns.TopKeyphrases) > 0 {
+		fmt.Fprintln(w, "  Top Keyphrases:")
+		for i, kp := range patterns.TopKeyphrases {
+			if i >= 5 {
+				break
+			}
+			fmt.Fprintf(w, "    • %s (%d)\n", kp.Phrase, kp.Frequency)
+		}
+		fmt.Fprintln(w)
+	}
+
+	// Top Entities
+	if len(patterns.TopEntities) > 0 {
+		fmt.Fprintln(w, "  Top Entities:")
+		for i, e := range patterns.TopEntities {
+			if i >= 5 {
+				break
+			}
+			fmt.Fprintf(w, "    • %s (%d mentions in %d videos, median %d views)\n", e.Name, e.Frequency, e.Videos, e.MedianViews)
+		}
+		fmt.Fprintln(w)
+	}
+
 	// Top Hashtags
 	if len(patterns.TopHashtags) > 0 {
 		fmt.Fprintln(w, "  Top Hashtags:")
@@ -103,6 +201,89 @@ func DisplayPatterns(w io.Writer, patterns analyzer.Patterns, opts Options) {
 		fmt.Fprintln(w)
 	}
 
+	// Title Templates
+	if len(patterns.TitleTemplates) > 0 {
+		fmt.Fprintln(w, "  Title Templates:")
+		for i, tt := range patterns.TitleTemplates {
+			if i >= 5 {
+				break
+			}
+			fmt.Fprintf(w, "    • %s (%d titles, median %d views)\n", tt.Template, tt.Count, tt.MedianViews)
+		}
+		fmt.Fprintln(w)
+	}
+
+	// Topic Clusters
+	if len(patterns.Clusters) > 0 {
+		fmt.Fprintln(w, "  Topic Clusters:")
+		for _, c := range patterns.Clusters {
+			labels := make([]string, 0, len(c.Keywords))
+			for _, kw := range c.Keywords {
+				labels = append(labels, kw.Word)
+			}
+			fmt.Fprintf(w, "    [%d] %s - %d videos, median %d views\n", c.ID, strings.Join(labels, ", "), c.Size, c.MedianViews)
+		}
+		fmt.Fprintln(w)
+	}
+
+	// Keyword Pairs That Perform
+	if len(patterns.KeywordGraph.TopPairs) > 0 {
+		fmt.Fprintln(w, "  Keyword Pairs That Perform:")
+		for i, p := range patterns.KeywordGraph.TopPairs {
+			if i >= 5 {
+				break
+			}
+			fmt.Fprintf(w, "    • %s + %s (%d videos, median %d views, %.1fx)\n", p.Words[0], p.Words[1], p.Videos, p.MedianViews, p.Lift)
+		}
+		fmt.Fprintln(w)
+	}
+
+	// Keyword Communities
+	if len(patterns.KeywordGraph.Communities) > 0 {
+		fmt.Fprintln(w, "  Keyword Communities:")
+		for _, c := range patterns.KeywordGraph.Communities {
+			fmt.Fprintf(w, "    [%d] %s - median %d views\n", c.ID, strings.Join(c.Words, ", "), c.MedianViews)
+		}
+		fmt.Fprintln(w)
+	}
+
+	// Duration Sweet Spot
+	if len(patterns.Durations.Bins) > 0 {
+		fmt.Fprintln(w, "  Duration Breakdown:")
+		for _, b := range patterns.Durations.Bins {
+			if b.Count == 0 {
+				continue
+			}
+			marker := " "
+			if rec := patterns.Durations.Recommended; rec != nil && rec.Label == b.Label {
+				marker = "★"
+			}
+			fmt.Fprintf(w, "   %s %-7s %3d videos, median %d views, %.1f%% engagement\n", marker, b.Label, b.Count, b.MedianViews, b.EngagementRate)
+		}
+		if rec := patterns.Durations.Recommended; rec != nil {
+			fmt.Fprintf(w, "  Recommended duration: %s\n", rec.Label)
+		}
+		fmt.Fprintln(w)
+	}
+
+	// Publishing Times
+	if patterns.Publishing.OverallMedianViews > 0 || patterns.Publishing.Recommended != nil {
+		displayPublishing(w, patterns.Publishing)
+	}
+
+	// Custom analyzer sections
+	for _, section := range patterns.Sections {
+		title := section.Title
+		if title == "" {
+			title = section.Name
+		}
+		fmt.Fprintf(w, "  %s:\n", title)
+		for _, line := range section.Lines {
+			fmt.Fprintf(w, "    • %s\n", line)
+		}
+		fmt.Fprintln(w)
+	}
+
 	if patterns.VideoCount == 0 && len(patterns.TopKeywords) == 0 {
 		fmt.Fprintln(w, "  No patterns found (0 videos analyzed)")
 		fmt.Fprintln(w)
@@ -111,12 +292,98 @@ func DisplayPatterns(w io.Writer, patterns analyzer.Patterns, opts Options) {
 	fmt.Fprintln(w, "═══════════════════════════════════════════════════════════")
 }
 
+// heatmapShades maps relative performance to characters, lowest to highest.
+const heatmapShades = ".:-=+*#%@"
+
+// heatmapDays lists weekdays in display order (Monday first).
+var heatmapDays = []time.Weekday{
+	time.Monday, time.Tuesday, time.Wednesday, time.Thursday,
+	time.Friday, time.Saturday, time.Sunday,
+}
+
+// displayPublishing renders the publishing heatmap as ASCII art, followed by
+// the recommended posting window and channel cadence.
+func displayPublishing(w io.Writer, pub analyzer.PublishingAnalysis) {
+	var maxViews int64
+	for _, day := range pub.Heatmap {
+		for _, cell := range day {
+			if cell.MedianViews > maxViews {
+				maxViews = cell.MedianViews
+			}
+		}
+	}
+
+	fmt.Fprintf(w, "  Publishing Heatmap (median views, %s):\n", pub.Timezone)
+	fmt.Fprintln(w, "          0     6     12    18")
+	for _, day := range heatmapDays {
+		var row strings.Builder
+		for _, cell := range pub.Heatmap[day] {
+			row.WriteByte(heatmapShade(cell, maxViews))
+		}
+		fmt.Fprintf(w, "    %s   %s\n", day.String()[:3], row.String())
+	}
+	fmt.Fprintf(w, "          (blank = no videos, %q = lowest, %q = highest)\n", heatmapShades[0], heatmapShades[len(heatmapShades)-1])
+	fmt.Fprintln(w)
+
+	if rec := pub.Recommended; rec != nil {
+		fmt.Fprintf(w, "  Recommended posting window: %s %02d:00-%02d:00 (%d videos, median %d views, %.1fx overall)\n",
+			rec.Day, rec.StartHour, rec.EndHour, rec.Count, rec.MedianViews, rec.Lift)
+		fmt.Fprintln(w)
+	}
+
+	if len(pub.Cadence) > 0 {
+		fmt.Fprintln(w, "  Channel Cadence:")
+		for i, c := range pub.Cadence {
+			if i >= 5 {
+				break
+			}
+			fmt.Fprintf(w, "    • %s - %.1f uploads/week (%d videos)\n", c.Channel, c.UploadsPerWeek, c.Videos)
+		}
+		fmt.Fprintln(w)
+	}
+}
+
+// heatmapShade returns the character for a heatmap cell.
+func heatmapShade(cell analyzer.HeatmapCell, maxViews int64) byte {
+	if cell.Count == 0 {
+		return ' '
+	}
+	if maxViews <= 0 {
+		return heatmapShades[0]
+	}
+	idx := int(float64(cell.MedianViews) / float64(maxViews) * float64(len(heatmapShades)-1))
+	return heatmapShades[idx]
+}
+
+// explainPrompt writes why a prompt was generated.
+func explainPrompt(w io.Writer, p prompt.Prompt) {
+	fmt.Fprintf(w, "     strategy: %s, supported by %d videos\n", p.Strategy, p.Evidence)
+	if len(p.Keywords) > 0 {
+		fmt.Fprintf(w, "     keywords: %s\n", strings.Join(p.Keywords, ", "))
+	}
+	if len(p.Hooks) > 0 {
+		fmt.Fprintf(w, "     hooks:    %s\n", strings.Join(p.Hooks, ", "))
+	}
+	if len(p.Hashtags) > 0 {
+		fmt.Fprintf(w, "     hashtags: #%s\n", strings.Join(p.Hashtags, ", #"))
+	}
+	if len(p.VideoIDs) > 0 {
+		ids := p.VideoIDs
+		more := ""
+		if len(ids) > maxExplainedVideos {
+			more = fmt.Sprintf(" (+%d more)", len(ids)-maxExplainedVideos)
+			ids = ids[:maxExplainedVideos]
+		}
+		fmt.Fprintf(w, "     videos:   %s%s\n", strings.Join(ids, ", "), more)
+	}
+}
+
 // DisplayResults writes both patterns and prompts to the given writer.
-func DisplayResults(w io.Writer, patterns analyzer.Patterns, prompts []string, opts Options) {
+func DisplayResults(w io.Writer, patterns analyzer.Patterns, prompts []prompt.Prompt, opts Options) {
 	if opts.JSON {
 		result := struct {
 			Patterns analyzer.Patterns `json:"patterns"`
-			Prompts  []string          `json:"prompts"`
+			Prompts  []prompt.Prompt   `json:"prompts"`
 		}{
 			Patterns: patterns,
 			Prompts:  prompts,
@@ -131,6 +398,98 @@ func DisplayResults(w io.Writer, patterns analyzer.Patterns, prompts []string, o
 	DisplayPrompts(w, prompts, opts)
 }
 
+// DisplayScores writes the score breakdown and suggestions for each draft
+// title.
+func DisplayScores(w io.Writer, results []score.Result, opts Options) {
+	if opts.JSON {
+		data, _ := json.MarshalIndent(results, "", "  ")
+		fmt.Fprintln(w, string(data))
+		return
+	}
+
+	fmt.Fprintln(w, "═══════════════════════════════════════════════════════════")
+	fmt.Fprintln(w, "  TITLE SCORES")
+	fmt.Fprintln(w, "═══════════════════════════════════════════════════════════")
+	fmt.Fprintln(w)
+
+	for i, r := range results {
+		fmt.Fprintf(w, "  %d. %q\n", i+1, r.Title)
+		fmt.Fprintf(w, "     Score: %d/100\n", r.Score)
+		for _, c := range r.Criteria {
+			fmt.Fprintf(w, "    %s %-12s %s\n", criterionMark(c.Score), c.Name, c.Detail)
+		}
+		if len(r.Suggestions) > 0 {
+			fmt.Fprintln(w, "     Suggestions:")
+			for _, s := range r.Suggestions {
+				fmt.Fprintf(w, "    • %s\n", s)
+			}
+		}
+		fmt.Fprintln(w)
+	}
+}
+
+// DisplayVariants writes generated title variants, best first. Verbose
+// output adds the formula behind each variant and its score breakdown.
+func DisplayVariants(w io.Writer, results []variants.Variant, opts Options) {
+	if opts.JSON {
+		data, _ := json.MarshalIndent(results, "", "  ")
+		fmt.Fprintln(w, string(data))
+		return
+	}
+
+	if len(results) == 0 {
+		fmt.Fprintln(w, "No title variants generated.")
+		return
+	}
+
+	fmt.Fprintln(w, "═══════════════════════════════════════════════════════════")
+	fmt.Fprintln(w, "  TITLE VARIANTS")
+	fmt.Fprintln(w, "═══════════════════════════════════════════════════════════")
+	fmt.Fprintln(w)
+
+	for i, v := range results {
+		fmt.Fprintf(w, "  %d. %s (%d/100)\n", i+1, v.Title, v.Score.Score)
+		if opts.Verbose {
+			fmt.Fprintf(w, "     formula: %s\n", v.Formula)
+			for _, c := range v.Score.Criteria {
+				fmt.Fprintf(w, "    %s %-12s %s\n", criterionMark(c.Score), c.Name, c.Detail)
+			}
+		}
+	}
+	fmt.Fprintln(w)
+}
+
+// criterionMark marks a criterion score as passing, borderline or failing.
+func criterionMark(s float64) string {
+	switch {
+	case s >= 0.85:
+		return "✓"
+	case s >= 0.5:
+		return "~"
+	default:
+		return "✗"
+	}
+}
+
+// DisplayCleaning writes a summary of what description cleaning removed
+// (only in non-JSON mode).
+func DisplayCleaning(w io.Writer, report text.CleaningReport, opts Options) {
+	if opts.JSON || report.Removed() == 0 {
+		return
+	}
+
+	fmt.Fprintln(w, "  Description Cleaning:")
+	fmt.Fprintf(w, "    • %d links, %d mentions, %d emails, %d timestamps removed\n", report.URLs, report.Mentions, report.Emails, report.Timestamps)
+	for i, b := range report.Boilerplate {
+		if i >= 10 {
+			fmt.Fprintf(w, "    • ... and %d more boilerplate lines\n", len(report.Boilerplate)-i)
+			break
+		}
+		fmt.Fprintf(w, "    • boilerplate in %d videos of %s: %s\n", b.Videos, b.Channel, text.TruncateGraphemes(b.Line, 60))
+	}
+	fmt.Fprintln(w)
+}
+
 // DisplayError writes an error message to the given writer.
 func DisplayError(w io.Writer, err error, opts Options) {
 	if opts.JSON {
@@ -169,7 +528,8 @@ func DisplayMetadataPrompt(w io.Writer, prompt string, patterns analyzer.Pattern
 	}
 
 	fmt.Fprintln(w, "═══════════════════════════════════════════════════════════")
-	fmt.Fprintln(w, "  OPUSCLIP CREATE-DEFAULT PROMPT")
+	tool := opts.Target.OrDefault()
+	fmt.Fprintf(w, "  %s %s PROMPT\n", strings.ToUpper(tool.Label), strings.ToUpper(tool.MetadataFeature))
 	fmt.Fprintln(w, "═══════════════════════════════════════════════════════════")
 	fmt.Fprintln(w)
 	fmt.Fprintf(w, "  %s\n", prompt)
@@ -5,10 +5,16 @@ import (
 	"encoding/json"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/mikelady/kingmaker/internal/analyzer"
 	"github.com/mikelady/kingmaker/internal/hooks"
 	"github.com/mikelady/kingmaker/internal/keywords"
+	"github.com/mikelady/kingmaker/internal/prompt"
+	"github.com/mikelady/kingmaker/internal/score"
+	"github.com/mikelady/kingmaker/internal/target"
+	"github.com/mikelady/kingmaker/internal/text"
+	"github.com/mikelady/kingmaker/internal/variants"
 )
 
 func TestDisplayPrompts_Empty(t *testing.T) {
@@ -23,7 +29,7 @@ func TestDisplayPrompts_Empty(t *testing.T) {
 
 func TestDisplayPrompts_SinglePrompt(t *testing.T) {
 	var buf bytes.Buffer
-	prompts := []string{"Find clips about AI coding with excitement"}
+	prompts := textPrompts("Find clips about AI coding with excitement")
 
 	DisplayPrompts(&buf, prompts, Options{})
 
@@ -40,32 +46,32 @@ func TestDisplayPrompts_SinglePrompt(t *testing.T) {
 
 func TestDisplayPrompts_MultiplePrompts(t *testing.T) {
 	var buf bytes.Buffer
-	prompts := []string{
+	prompts := textPrompts(
 		"First prompt about coding",
 		"Second prompt about AI",
 		"Third prompt about tech",
-	}
+	)
 
 	DisplayPrompts(&buf, prompts, Options{})
 
 	output := buf.String()
 	for _, p := range prompts {
-		if !strings.Contains(output, p) {
-			t.Errorf("expected output to contain: %s", p)
+		if !strings.Contains(output, p.Text) {
+			t.Errorf("expected output to contain: %s", p.Text)
 		}
 	}
 }
 
 func TestDisplayPrompts_JSONFormat(t *testing.T) {
 	var buf bytes.Buffer
-	prompts := []string{"Test prompt one", "Test prompt two"}
+	prompts := textPrompts("Test prompt one", "Test prompt two")
 
 	DisplayPrompts(&buf, prompts, Options{JSON: true})
 
 	output := buf.String()
 
 	// Should be valid JSON
-	var result []string
+	var result []prompt.Prompt
 	if err := json.Unmarshal([]byte(output), &result); err != nil {
 		t.Errorf("expected valid JSON output: %v", err)
 	}
@@ -77,7 +83,7 @@ func TestDisplayPrompts_JSONFormat(t *testing.T) {
 
 func TestDisplayPrompts_WithSummary(t *testing.T) {
 	var buf bytes.Buffer
-	prompts := []string{"Prompt 1", "Prompt 2", "Prompt 3"}
+	prompts := textPrompts("Prompt 1", "Prompt 2", "Prompt 3")
 
 	DisplayPrompts(&buf, prompts, Options{ShowSummary: true})
 
@@ -155,7 +161,7 @@ func TestDisplayResults_Full(t *testing.T) {
 		},
 		VideoCount: 15,
 	}
-	prompts := []string{"Find exciting moments about cursor"}
+	prompts := textPrompts("Find exciting moments about cursor")
 
 	DisplayResults(&buf, patterns, prompts, Options{})
 
@@ -172,7 +178,7 @@ func TestDisplayResults_JSONFormat(t *testing.T) {
 	var buf bytes.Buffer
 
 	patterns := analyzer.Patterns{VideoCount: 5}
-	prompts := []string{"Test prompt"}
+	prompts := textPrompts("Test prompt")
 
 	DisplayResults(&buf, patterns, prompts, Options{JSON: true})
 
@@ -203,7 +209,7 @@ func TestOptions_Defaults(t *testing.T) {
 
 func TestDisplayPrompts_Delimiter(t *testing.T) {
 	var buf bytes.Buffer
-	prompts := []string{"First", "Second"}
+	prompts := textPrompts("First", "Second")
 
 	DisplayPrompts(&buf, prompts, Options{})
 
@@ -214,3 +220,462 @@ func TestDisplayPrompts_Delimiter(t *testing.T) {
 		t.Error("expected prompts on separate lines")
 	}
 }
+
+func TestDisplayPatterns_KeywordVariants(t *testing.T) {
+	var buf bytes.Buffer
+	patterns := analyzer.Patterns{
+		TopKeywords: []keywords.Keyword{{Word: "coding", Frequency: 6, Variants: []string{"coding", "code", "codes"}}},
+		VideoCount:  10,
+	}
+
+	DisplayPatterns(&buf, patterns, Options{})
+
+	if !strings.Contains(buf.String(), "coding (6) [coding, code, codes]") {
+		t.Errorf("expected keyword variants in output, got:\n%s", buf.String())
+	}
+}
+
+func TestDisplayPatterns_Keyphrases(t *testing.T) {
+	var buf bytes.Buffer
+	patterns := analyzer.Patterns{
+		TopKeyphrases: []keywords.Keyphrase{{Phrase: "vibe coding", Frequency: 6}},
+		VideoCount:    10,
+	}
+
+	DisplayPatterns(&buf, patterns, Options{})
+
+	output := buf.String()
+	if !strings.Contains(output, "Top Keyphrases") || !strings.Contains(output, "vibe coding (6)") {
+		t.Errorf("expected keyphrases in output, got:\n%s", output)
+	}
+}
+
+func TestDisplayPatterns_Entities(t *testing.T) {
+	var buf bytes.Buffer
+	patterns := analyzer.Patterns{
+		TopEntities: []analyzer.Entity{{Name: "ChatGPT", Frequency: 7, Videos: 4, MedianViews: 25000}},
+		VideoCount:  10,
+	}
+
+	DisplayPatterns(&buf, patterns, Options{})
+
+	output := buf.String()
+	if !strings.Contains(output, "Top Entities") || !strings.Contains(output, "ChatGPT (7 mentions in 4 videos, median 25000 views)") {
+		t.Errorf("expected entities in output, got:\n%s", output)
+	}
+}
+
+func TestDisplayPatterns_HookTypes(t *testing.T) {
+	var buf bytes.Buffer
+	patterns := analyzer.Patterns{
+		TopHooks: []hooks.Hook{
+			{Type: hooks.Question, Pattern: "how", Frequency: 9},
+			{Type: hooks.Question, Pattern: "why", Frequency: 8},
+			{Type: hooks.Question, Pattern: "what", Frequency: 7},
+			{Type: hooks.Question, Pattern: "can", Frequency: 6},
+			{Type: hooks.Question, Pattern: "is", Frequency: 5},
+			{Type: hooks.Comparison, Pattern: "vs", Frequency: 4},
+			{Type: hooks.TimeBound, Pattern: "year", Frequency: 2},
+		},
+		VideoCount: 10,
+	}
+
+	DisplayPatterns(&buf, patterns, Options{})
+
+	output := buf.String()
+	for _, want := range []string{"Hook Types:", "Question: how (9), why (8), what (7)", "Comparison: vs (4)", "TimeBound: year (2)"} {
+		if !strings.Contains(output, want) {
+			t.Errorf("expected %q in output, got:\n%s", want, output)
+		}
+	}
+}
+
+func TestDisplayPatterns_HooksThatPerform(t *testing.T) {
+	var buf bytes.Buffer
+	patterns := analyzer.Patterns{
+		TopHooks: []hooks.Hook{
+			{Type: hooks.Question, Pattern: "how", Frequency: 6, MedianViews: 1200, EngagementRate: 3.5, Lift: 1.2},
+			{Type: hooks.Comparison, Pattern: "vs", Frequency: 3, MedianViews: 9000, EngagementRate: 6, Lift: 4, Examples: []string{"Cursor vs Copilot"}},
+		},
+		VideoCount: 10,
+	}
+
+	DisplayPatterns(&buf, patterns, Options{Verbose: true})
+
+	output := buf.String()
+	section := output[strings.Index(output, "Hooks That Perform:"):]
+	if !strings.Contains(section, "vs (Comparison) - 3 videos, median 9000 views, 6.0% engagement, 4.0x") {
+		t.Errorf("expected scored hooks in output, got:\n%s", output)
+	}
+	if strings.Index(section, "vs (") > strings.Index(section, "how (") {
+		t.Errorf("expected hooks ordered by lift, got:\n%s", section)
+	}
+	if !strings.Contains(section, `e.g. "Cursor vs Copilot"`) {
+		t.Errorf("expected best example in verbose output, got:\n%s", section)
+	}
+}
+
+func TestDisplayPatterns_HookPlacement(t *testing.T) {
+	var buf bytes.Buffer
+	best := analyzer.HookDensityBucket{Label: "1", Hooks: 1, Count: 6, MedianViews: 5000, EngagementRate: 4}
+	patterns := analyzer.Patterns{
+		HookPlacement: analyzer.HookPlacement{
+			TitlesWithHooks:  8,
+			EarlyRate:        0.75,
+			EarlyMedianViews: 6000,
+			LateMedianViews:  2000,
+			EarlyLift:        3,
+			MedianFirstChar:  4,
+			MedianFirstWord:  1,
+			Density: []analyzer.HookDensityBucket{
+				{Label: "0", Count: 2, MedianViews: 800, EngagementRate: 1},
+				best,
+				{Label: "2", Hooks: 2, Count: 0},
+			},
+			BestDensity: &best,
+		},
+		VideoCount: 10,
+	}
+
+	DisplayPatterns(&buf, patterns, Options{})
+
+	output := buf.String()
+	for _, want := range []string{
+		"75% of hooked titles open a hook in the first 40 characters (median 6000 vs 2000 views, 3.0x)",
+		"First hook at character 4, word 2 (median)",
+		"★ 1     6 videos, median 5000 views, 4.0% engagement",
+	} {
+		if !strings.Contains(output, want) {
+			t.Errorf("expected %q in output, got:\n%s", want, output)
+		}
+	}
+	if strings.Contains(output, " 2     0 videos") {
+		t.Errorf("expected empty density buckets to be skipped, got:\n%s", output)
+	}
+}
+
+func TestDisplayPatterns_KeywordGraph(t *testing.T) {
+	var buf bytes.Buffer
+	patterns := analyzer.Patterns{
+		KeywordGraph: analyzer.KeywordGraph{
+			TopPairs: []analyzer.KeywordPair{
+				{Words: [2]string{"nextjs", "react"}, Videos: 3, MedianViews: 80000, Lift: 2.5},
+			},
+			Communities: []analyzer.KeywordCommunity{
+				{ID: 1, Words: []string{"react", "nextjs", "tailwind"}, MedianViews: 80000},
+			},
+		},
+		VideoCount: 6,
+	}
+
+	DisplayPatterns(&buf, patterns, Options{})
+
+	output := buf.String()
+	if !strings.Contains(output, "nextjs + react (3 videos, median 80000 views, 2.5x)") {
+		t.Errorf("expected keyword pair in output, got:\n%s", output)
+	}
+	if !strings.Contains(output, "[1] react, nextjs, tailwind - median 80000 views") {
+		t.Errorf("expected keyword community in output, got:\n%s", output)
+	}
+}
+
+func TestDisplayPatterns_TitleTemplates(t *testing.T) {
+	var buf bytes.Buffer
+	patterns := analyzer.Patterns{
+		TitleTemplates: []analyzer.TitleTemplate{
+			{Template: "I built [THING] in [N] [UNIT]", Count: 4, MedianViews: 12000},
+		},
+		VideoCount: 10,
+	}
+
+	DisplayPatterns(&buf, patterns, Options{})
+
+	output := buf.String()
+	if !strings.Contains(output, "I built [THING] in [N] [UNIT]") {
+		t.Error("expected title template in output")
+	}
+	if !strings.Contains(output, "12000") {
+		t.Error("expected template median views in output")
+	}
+}
+
+func TestDisplayPatterns_Clusters(t *testing.T) {
+	var buf bytes.Buffer
+	patterns := analyzer.Patterns{
+		Clusters: []analyzer.TopicCluster{
+			{ID: 1, Size: 6, MedianViews: 4200, Keywords: []keywords.Keyword{{Word: "cursor"}, {Word: "tutorial"}}},
+		},
+		VideoCount: 6,
+	}
+
+	DisplayPatterns(&buf, patterns, Options{})
+
+	output := buf.String()
+	if !strings.Contains(output, "[1] cursor, tutorial") {
+		t.Errorf("expected cluster label in output, got:\n%s", output)
+	}
+}
+
+func TestDisplayPatterns_PublishingHeatmap(t *testing.T) {
+	var buf bytes.Buffer
+	patterns := analyzer.Patterns{VideoCount: 3}
+	patterns.Publishing.Timezone = "UTC"
+	patterns.Publishing.OverallMedianViews = 100
+	patterns.Publishing.Heatmap[time.Tuesday][17] = analyzer.HeatmapCell{Count: 2, MedianViews: 500}
+	patterns.Publishing.Heatmap[time.Monday][0] = analyzer.HeatmapCell{Count: 1, MedianViews: 10}
+	patterns.Publishing.Recommended = &analyzer.PostingWindow{
+		Day: time.Tuesday, StartHour: 16, EndHour: 19, Count: 2, MedianViews: 500, Lift: 5,
+	}
+
+	DisplayPatterns(&buf, patterns, Options{})
+
+	output := buf.String()
+	if !strings.Contains(output, "Tue   "+strings.Repeat(" ", 17)+"@") {
+		t.Errorf("expected Tuesday 17h to be the hottest cell, got:\n%s", output)
+	}
+	if !strings.Contains(output, "Mon   .") {
+		t.Errorf("expected Monday 0h to be a low cell, got:\n%s", output)
+	}
+	if !strings.Contains(output, "Tuesday 16:00-19:00") {
+		t.Errorf("expected recommended window, got:\n%s", output)
+	}
+}
+
+func TestDisplayPatterns_PublishingJSONMatrix(t *testing.T) {
+	var buf bytes.Buffer
+	patterns := analyzer.Patterns{VideoCount: 1}
+	patterns.Publishing.Heatmap[time.Friday][9] = analyzer.HeatmapCell{Count: 1, MedianViews: 42}
+
+	DisplayPatterns(&buf, patterns, Options{JSON: true})
+
+	var result struct {
+		Publishing struct {
+			Heatmap [][]analyzer.HeatmapCell
+		}
+	}
+	if err := json.Unmarshal(buf.Bytes(), &result); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+	if len(result.Publishing.Heatmap) != 7 || len(result.Publishing.Heatmap[0]) != 24 {
+		t.Fatalf("expected 7x24 heatmap matrix")
+	}
+	if result.Publishing.Heatmap[time.Friday][9].MedianViews != 42 {
+		t.Error("expected Friday 9h cell in JSON matrix")
+	}
+}
+
+func TestDisplayPatterns_Durations(t *testing.T) {
+	var buf bytes.Buffer
+	rec := analyzer.DurationBin{Label: "15-30s", Count: 4, MedianViews: 9000}
+	patterns := analyzer.Patterns{
+		Durations: analyzer.DurationAnalysis{
+			Bins:        []analyzer.DurationBin{{Label: "0-15s", Count: 2, MedianViews: 100}, rec},
+			Recommended: &rec,
+		},
+		VideoCount: 6,
+	}
+
+	DisplayPatterns(&buf, patterns, Options{})
+
+	output := buf.String()
+	if !strings.Contains(output, "Recommended duration: 15-30s") {
+		t.Errorf("expected recommended duration, got:\n%s", output)
+	}
+	if !strings.Contains(output, "0-15s") {
+		t.Error("expected duration bins in output")
+	}
+}
+
+func TestDisplayPatterns_CustomSections(t *testing.T) {
+	patterns := analyzer.Patterns{
+		Sections: []analyzer.Section{
+			{Name: "mentions", Title: "Brand Mentions", Lines: []string{"cursor: 12 titles"}, Data: map[string]int{"cursor": 12}},
+		},
+		VideoCount: 12,
+	}
+
+	var text bytes.Buffer
+	DisplayPatterns(&text, patterns, Options{})
+	if !strings.Contains(text.String(), "Brand Mentions") || !strings.Contains(text.String(), "cursor: 12 titles") {
+		t.Errorf("expected custom section in text output, got:\n%s", text.String())
+	}
+
+	var jsonBuf bytes.Buffer
+	DisplayResults(&jsonBuf, patterns, nil, Options{JSON: true})
+	if !strings.Contains(jsonBuf.String(), `"cursor": 12`) {
+		t.Errorf("expected custom section data in JSON output, got:\n%s", jsonBuf.String())
+	}
+}
+
+func TestDisplayCleaning(t *testing.T) {
+	var buf bytes.Buffer
+	report := text.CleaningReport{
+		URLs:       3,
+		Mentions:   1,
+		Timestamps: 4,
+		Boilerplate: []text.BoilerplateLine{
+			{Line: "Follow me on Instagram", Channel: "UC1", Videos: 5},
+		},
+	}
+
+	DisplayCleaning(&buf, report, Options{})
+
+	output := buf.String()
+	if !strings.Contains(output, "3 links, 1 mentions, 0 emails, 4 timestamps removed") {
+		t.Errorf("expected removal counts, got:\n%s", output)
+	}
+	if !strings.Contains(output, "boilerplate in 5 videos of UC1: Follow me on Instagram") {
+		t.Errorf("expected boilerplate line, got:\n%s", output)
+	}
+
+	buf.Reset()
+	DisplayCleaning(&buf, report, Options{JSON: true})
+	DisplayCleaning(&buf, text.CleaningReport{}, Options{})
+	if buf.Len() != 0 {
+		t.Errorf("expected no output in JSON mode or for an empty report, got %q", buf.String())
+	}
+}
+
+func TestDisplayScores(t *testing.T) {
+	var buf bytes.Buffer
+	results := []score.Result{{
+		Title: "Go Tips",
+		Score: 62,
+		Criteria: []score.Criterion{
+			{Name: score.CriterionLength, Score: 1, Detail: "7 characters (top performers: 5-30)"},
+			{Name: score.CriterionHooks, Score: 0, Detail: "no hook"},
+		},
+		Suggestions: []string{"Add a hook: \"secret\" (power_word) titles get 2.5x the views"},
+	}}
+
+	DisplayScores(&buf, results, Options{})
+
+	output := buf.String()
+	for _, want := range []string{
+		"1. \"Go Tips\"",
+		"Score: 62/100",
+		"✓ length",
+		"✗ hooks",
+		"• Add a hook",
+	} {
+		if !strings.Contains(output, want) {
+			t.Errorf("expected %q in output, got:\n%s", want, output)
+		}
+	}
+
+	buf.Reset()
+	DisplayScores(&buf, results, Options{JSON: true})
+	var decoded []score.Result
+	if err := json.Unmarshal(buf.Bytes(), &decoded); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+	if len(decoded) != 1 || decoded[0].Score != 62 {
+		t.Errorf("decoded = %+v, want the single result", decoded)
+	}
+}
+
+func TestDisplayVariants(t *testing.T) {
+	var buf bytes.Buffer
+	results := []variants.Variant{{
+		Title:   "Secret Go Tips",
+		Formula: "[HOOK] [TOPIC] Tips",
+		Score: score.Result{
+			Title:    "Secret Go Tips",
+			Score:    74,
+			Criteria: []score.Criterion{{Name: score.CriterionHooks, Score: 1, Detail: "secret (PowerWord, 2.5x)"}},
+		},
+	}}
+
+	DisplayVariants(&buf, results, Options{})
+	output := buf.String()
+	if !strings.Contains(output, "1. Secret Go Tips (74/100)") {
+		t.Errorf("expected ranked variant, got:\n%s", output)
+	}
+	if strings.Contains(output, "formula:") {
+		t.Errorf("expected no formula outside verbose mode, got:\n%s", output)
+	}
+
+	buf.Reset()
+	DisplayVariants(&buf, results, Options{Verbose: true})
+	output = buf.String()
+	for _, want := range []string{"formula: [HOOK] [TOPIC] Tips", "✓ hooks"} {
+		if !strings.Contains(output, want) {
+			t.Errorf("expected %q in verbose output, got:\n%s", want, output)
+		}
+	}
+
+	buf.Reset()
+	DisplayVariants(&buf, nil, Options{})
+	if !strings.Contains(buf.String(), "No title variants generated.") {
+		t.Errorf("expected empty message, got %q", buf.String())
+	}
+}
+
+func TestDisplayPrompts_Explain(t *testing.T) {
+	var buf bytes.Buffer
+	prompts := []prompt.Prompt{{
+		Text:     "Extract warnings and mistakes to avoid",
+		Strategy: prompt.StrategyHooks,
+		Keywords: []string{"cursor"},
+		Hooks:    []string{"don't", "never"},
+		Hashtags: []string{"shorts"},
+		VideoIDs: []string{"a", "b", "c", "d", "e", "f", "g"},
+		Evidence: 7,
+	}}
+
+	DisplayPrompts(&buf, prompts, Options{})
+	if strings.Contains(buf.String(), "strategy:") {
+		t.Errorf("expected no explanation by default, got:\n%s", buf.String())
+	}
+
+	buf.Reset()
+	DisplayPrompts(&buf, prompts, Options{Explain: true})
+	output := buf.String()
+	for _, want := range []string{
+		"strategy: hooks, supported by 7 videos",
+		"keywords: cursor",
+		"hooks:    don't, never",
+		"hashtags: #shorts",
+		"videos:   a, b, c, d, e (+2 more)",
+	} {
+		if !strings.Contains(output, want) {
+			t.Errorf("expected %q in explain view, got:\n%s", want, output)
+		}
+	}
+}
+
+func textPrompts(texts ...string) []prompt.Prompt {
+	prompts := make([]prompt.Prompt, len(texts))
+	for i, text := range texts {
+		prompts[i] = prompt.Prompt{Text: text}
+	}
+	return prompts
+}
+
+func TestDisplayPrompts_LabelsTarget(t *testing.T) {
+	var buf bytes.Buffer
+	DisplayPrompts(&buf, textPrompts("Prompt 1"), Options{ShowSummary: true})
+	if !strings.Contains(buf.String(), "OPUSCLIP PROMPTS") {
+		t.Errorf("default output should be labeled for OpusClip, got:\n%s", buf.String())
+	}
+
+	vizard, err := target.Parse(target.Vizard)
+	if err != nil {
+		t.Fatal(err)
+	}
+	buf.Reset()
+	DisplayPrompts(&buf, textPrompts("Prompt 1"), Options{ShowSummary: true, Target: vizard})
+	output := buf.String()
+	if !strings.Contains(output, "VIZARD PROMPTS") || strings.Contains(output, "OPUSCLIP") {
+		t.Errorf("expected Vizard label, got:\n%s", output)
+	}
+	if !strings.Contains(output, "max 200 characters") {
+		t.Errorf("summary should state Vizard's limit, got:\n%s", output)
+	}
+
+	buf.Reset()
+	DisplayMetadataPrompt(&buf, "Write titles", analyzer.Patterns{}, Options{Target: vizard})
+	if !strings.Contains(buf.String(), "VIZARD CAPTION INSTRUCTIONS PROMPT") {
+		t.Errorf("expected Vizard metadata label, got:\n%s", buf.String())
+	}
+}
@@ -1,8 +1,12 @@
 package config
 
 import (
+	"encoding/json"
 	"errors"
+	"fmt"
 	"os"
+	"path/filepath"
+	"strings"
 )
 
 // Config holds application configuration.
@@ -10,20 +14,82 @@ type Config struct {
 	YouTubeAPIKey string
 	OpenAIAPIKey  string // Optional, required for metadata mode
 	MaxResults    int
-	HTTPTimeout   int // seconds
+	HTTPTimeout   int                 // seconds
+	BoringWords   map[string][]string // Per-niche words to exclude from keywords; "*" applies to every niche
+	HookPacks     []string            // Hook pack files layered over the bundled packs, in order
 }
 
-// Load reads configuration from environment variables.
+// fileConfig is the optional JSON configuration file named by KINGMAKER_CONFIG.
+type fileConfig struct {
+	BoringWords map[string][]string `json:"boring_words"`
+	HookPacks   []string            `json:"hook_packs"` // Relative paths are resolved against the config file's directory
+}
+
+// Load reads configuration from environment variables and, when
+// KINGMAKER_CONFIG is set, from the JSON file it names.
 func Load() (*Config, error) {
-	apiKey := os.Getenv("YOUTUBE_API_KEY")
-	if apiKey == "" {
+	if os.Getenv("YOUTUBE_API_KEY") == "" {
 		return nil, errors.New("YOUTUBE_API_KEY environment variable is required")
 	}
+	return LoadLocal()
+}
 
-	return &Config{
-		YouTubeAPIKey: apiKey,
+// LoadLocal reads configuration like Load but does not require the YouTube
+// API key, for commands that work from saved videos.
+func LoadLocal() (*Config, error) {
+	cfg := &Config{
+		YouTubeAPIKey: os.Getenv("YOUTUBE_API_KEY"),
 		OpenAIAPIKey:  os.Getenv("OPENAI_API_KEY"), // Optional
 		MaxResults:    50,
 		HTTPTimeout:   30,
-	}, nil
+	}
+
+	if path := os.Getenv("KINGMAKER_CONFIG"); path != "" {
+		if err := cfg.loadFile(path); err != nil {
+			return nil, err
+		}
+	}
+
+	return cfg, nil
+}
+
+// loadFile merges settings from a JSON configuration file.
+func (c *Config) loadFile(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("reading config file: %w", err)
+	}
+
+	var fc fileConfig
+	if err := json.Unmarshal(data, &fc); err != nil {
+		return fmt.Errorf("parsing config file %s: %w", path, err)
+	}
+
+	c.BoringWords = make(map[string][]string, len(fc.BoringWords))
+	for niche, words := range fc.BoringWords {
+		key := normalizeNiche(niche)
+		c.BoringWords[key] = append(c.BoringWords[key], words...)
+	}
+
+	for _, pack := range fc.HookPacks {
+		if !filepath.IsAbs(pack) {
+			pack = filepath.Join(filepath.Dir(path), pack)
+		}
+		c.HookPacks = append(c.HookPacks, pack)
+	}
+	return nil
+}
+
+// BoringWordsFor returns the boring words configured for a niche (matched
+// case-insensitively) together with those configured for every niche.
+func (c *Config) BoringWordsFor(niche string) []string {
+	words := append([]string{}, c.BoringWords["*"]...)
+	if key := normalizeNiche(niche); key != "*" {
+		words = append(words, c.BoringWords[key]...)
+	}
+	return words
+}
+
+func normalizeNiche(niche string) string {
+	return strings.Join(strings.Fields(strings.ToLower(niche)), " ")
 }
@@ -2,6 +2,8 @@ package config
 
 import (
 	"os"
+	"path/filepath"
+	"reflect"
 	"testing"
 )
 
@@ -28,6 +30,18 @@ func TestLoadConfig_MissingAPIKey(t *testing.T) {
 	}
 }
 
+func TestLoadLocal_WithoutAPIKey(t *testing.T) {
+	os.Unsetenv("YOUTUBE_API_KEY")
+
+	cfg, err := LoadLocal()
+	if err != nil {
+		t.Fatalf("LoadLocal() error = %v", err)
+	}
+	if cfg.YouTubeAPIKey != "" || cfg.HTTPTimeout != 30 {
+		t.Errorf("LoadLocal() = %+v, want defaults without an API key", cfg)
+	}
+}
+
 func TestConfig_Defaults(t *testing.T) {
 	os.Setenv("YOUTUBE_API_KEY", "test-key")
 	defer os.Unsetenv("YOUTUBE_API_KEY")
@@ -45,3 +59,72 @@ func TestConfig_Defaults(t *testing.T) {
 		t.Errorf("HTTPTimeout = %d, want %d", cfg.HTTPTimeout, 30)
 	}
 }
+
+func TestLoadConfig_BoringWordsFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "kingmaker.json")
+	data := `{"boring_words": {"*": ["video"], "AI  Coding": ["ai", "coding"]}}`
+	if err := os.WriteFile(path, []byte(data), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Setenv("YOUTUBE_API_KEY", "test-key")
+	t.Setenv("KINGMAKER_CONFIG", path)
+
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+
+	got := cfg.BoringWordsFor("ai coding")
+	want := []string{"video", "ai", "coding"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("BoringWordsFor(ai coding) = %v, want %v", got, want)
+	}
+
+	got = cfg.BoringWordsFor("cooking")
+	if !reflect.DeepEqual(got, []string{"video"}) {
+		t.Errorf("BoringWordsFor(cooking) = %v, want [video]", got)
+	}
+}
+
+func TestLoadConfig_HookPacks(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "kingmaker.json")
+	abs := filepath.Join(t.TempDir(), "shared.json")
+	data := `{"hook_packs": ["hooks/es.json", "` + filepath.ToSlash(abs) + `"]}`
+	if err := os.WriteFile(path, []byte(data), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Setenv("YOUTUBE_API_KEY", "test-key")
+	t.Setenv("KINGMAKER_CONFIG", path)
+
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+
+	want := []string{filepath.Join(dir, "hooks", "es.json"), abs}
+	if !reflect.DeepEqual(cfg.HookPacks, want) {
+		t.Errorf("HookPacks = %v, want %v", cfg.HookPacks, want)
+	}
+}
+
+func TestLoadConfig_InvalidFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "kingmaker.json")
+	if err := os.WriteFile(path, []byte("{not json"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Setenv("YOUTUBE_API_KEY", "test-key")
+	t.Setenv("KINGMAKER_CONFIG", path)
+
+	if _, err := Load(); err == nil {
+		t.Error("Load() expected error for invalid config file")
+	}
+
+	t.Setenv("KINGMAKER_CONFIG", filepath.Join(t.TempDir(), "missing.json"))
+	if _, err := Load(); err == nil {
+		t.Error("Load() expected error for missing config file")
+	}
+}
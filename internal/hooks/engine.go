@@ -0,0 +1,372 @@
+package hooks
+
+import (
+	"regexp"
+	"regexp/syntax"
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/mikelady/kingmaker/internal/text"
+)
+
+// Match is a hook found in a title.
+type Match struct {
+	Type    HookType
+	Pattern string // Reported pattern (the word, or the regex definition's name)
+	Start   int    // Byte offset of the match in the title
+	End     int    // Byte offset just past the match
+	Char    int    // Character (rune) position of the match in the title
+	Word    int    // Position of the word the match starts in or before (0 = first word)
+}
+
+// automaton is an Aho-Corasick automaton over byte strings. Transitions
+// form a complete DFA, so scanning never follows failure links.
+type automaton struct {
+	delta   []int32   // delta[state*256+b] is the next state
+	outputs [][]int32 // Pattern IDs ending at each state, including suffixes
+	lengths []int     // Byte length of each pattern
+}
+
+// newAutomaton builds an automaton recognizing patterns. Pattern IDs are
+// indexes into patterns.
+func newAutomaton(patterns []string) *automaton {
+	// Build the trie
+	children := []map[byte]int32{{}}
+	outputs := [][]int32{nil}
+	lengths := make([]int, len(patterns))
+	for id, p := range patterns {
+		lengths[id] = len(p)
+		state := int32(0)
+		for i := 0; i < len(p); i++ {
+			next, ok := children[state][p[i]]
+			if !ok {
+				next = int32(len(children))
+				children = append(children, map[byte]int32{})
+				outputs = append(outputs, nil)
+				children[state][p[i]] = next
+			}
+			state = next
+		}
+		outputs[state] = append(outputs[state], int32(id))
+	}
+
+	// Breadth-first: fill in DFA transitions from failure links and merge
+	// the outputs of each state's longest proper suffix state
+	n := len(children)
+	delta := make([]int32, n*256)
+	fail := make([]int32, n)
+	queue := make([]int32, 0, n)
+	for b := 0; b < 256; b++ {
+		if next, ok := children[0][byte(b)]; ok {
+			delta[b] = next
+			queue = append(queue, next)
+		}
+	}
+	for len(queue) > 0 {
+		state := queue[0]
+		queue = queue[1:]
+		outputs[state] = append(outputs[state], outputs[fail[state]]...)
+		for b := 0; b < 256; b++ {
+			next, ok := children[state][byte(b)]
+			if !ok {
+				delta[int(state)*256+b] = delta[int(fail[state])*256+b]
+				continue
+			}
+			delta[int(state)*256+b] = next
+			fail[next] = delta[int(fail[state])*256+b]
+			queue = append(queue, next)
+		}
+	}
+
+	return &automaton{delta: delta, outputs: outputs, lengths: lengths}
+}
+
+// scan calls found with the pattern ID and byte span of every occurrence of
+// a pattern in s, in order of end offset.
+func (a *automaton) scan(s string, found func(id, start, end int)) {
+	state := int32(0)
+	for i := 0; i < len(s); i++ {
+		state = a.delta[int(state)*256+int(s[i])]
+		for _, id := range a.outputs[state] {
+			found(int(id), i+1-a.lengths[id], i+1)
+		}
+	}
+}
+
+// compile builds the matcher's phrase automaton from its word rules.
+func (m *Matcher) compile() {
+	m.phraseRules = m.phraseRules[:0]
+	m.regexRules = m.regexRules[:0]
+	var phrases []string
+	for i, r := range m.rules {
+		if r.re != nil {
+			m.regexRules = append(m.regexRules, i)
+			continue
+		}
+		phrases = append(phrases, r.word)
+		m.phraseRules = append(m.phraseRules, i)
+	}
+	m.phrases = newAutomaton(phrases)
+}
+
+// compileLowerRegex compiles a pattern that is only matched against
+// lowercased titles. Case-insensitive literals are rewritten as lowercase
+// literals, which matches the same titles but lets the regexp package skip
+// ahead to the literal prefix instead of trying every position.
+func compileLowerRegex(expr string) (*regexp.Regexp, error) {
+	re, err := syntax.Parse(expr, syntax.Perl)
+	if err != nil {
+		return nil, err
+	}
+	lowerLiterals(re)
+	return regexp.Compile(re.String())
+}
+
+// lowerLiterals clears case folding on literals whose lowercase form is the
+// only one a lowercased title can contain.
+func lowerLiterals(re *syntax.Regexp) {
+	if re.Op == syntax.OpLiteral && re.Flags&syntax.FoldCase != 0 {
+		for i, r := range re.Rune {
+			re.Rune[i] = unicode.ToLower(r)
+		}
+		re.Flags &^= syntax.FoldCase
+	}
+	for _, sub := range re.Sub {
+		lowerLiterals(sub)
+	}
+}
+
+// Match returns every hook in a title with its span, ordered by position.
+// Words and phrases match whole tokens only, so "free" does not match
+// "freedom"; question words only match at the start of the title or of a
+// clause after "-", "|" or ":".
+func (m *Matcher) Match(title string) []Match {
+	if m == nil {
+		m = defaultMatcher
+	}
+	var matches []Match
+	m.match(title, func() string { return titleLanguage(title) }, func(rule, start, end int) bool {
+		r := m.rules[rule]
+		matches = append(matches, Match{Type: r.typ, Pattern: r.name, Start: start, End: end})
+		return true
+	})
+	sort.SliceStable(matches, func(i, j int) bool {
+		if matches[i].Start != matches[j].Start {
+			return matches[i].Start < matches[j].Start
+		}
+		return matches[i].End > matches[j].End
+	})
+	setPositions(title, matches)
+	return matches
+}
+
+// setPositions fills in the character and word positions of matches ordered
+// by Start.
+func setPositions(title string, matches []Match) {
+	char, word := 0, -1
+	inWord := false
+	next := 0
+	for i, r := range title {
+		for next < len(matches) && matches[next].Start == i {
+			matches[next].Char = char
+			matches[next].Word = word
+			if !inWord {
+				matches[next].Word = word + 1
+			}
+			next++
+		}
+		if next == len(matches) {
+			return
+		}
+		if isWordRune(r) && !inWord {
+			word++
+		}
+		inWord = isWordRune(r)
+		char++
+	}
+}
+
+// minTitleStopWords is the number of stop words a Latin-script title needs
+// before its detected language narrows the packs applied to it. One shared
+// word is not enough: "on" is both English and French.
+const minTitleStopWords = 2
+
+// titleLanguage detects the language of a title for choosing the packs that
+// apply to it, or "" when the title gives too little evidence. Non-Latin
+// scripts identify their language; Latin-script titles need stop words.
+func titleLanguage(title string) string {
+	lang := text.DetectLanguage(title)
+	if lang == "" || strings.IndexFunc(title, func(r rune) bool {
+		return unicode.IsLetter(r) && !unicode.Is(unicode.Latin, r)
+	}) >= 0 {
+		return lang
+	}
+	stopWords := text.StopWords(lang)
+	hits := 0
+	for _, t := range text.Tokenize(title) {
+		if stopWords[t] {
+			hits++
+		}
+	}
+	if hits < minTitleStopWords {
+		return ""
+	}
+	return lang
+}
+
+// match calls found with the rule index and title span of each match until
+// found returns false. Rules of packs in another language than the title's
+// are skipped; lang, which resolves the title's language, is only called
+// once a language-specific rule matches.
+func (m *Matcher) match(title string, lang func() string, found func(rule, start, end int) bool) {
+	resolved, titleLang := false, ""
+	applies := func(r rule) bool {
+		if r.lang == "" {
+			return true
+		}
+		if !resolved {
+			resolved, titleLang = true, lang()
+		}
+		return r.appliesTo(titleLang)
+	}
+
+	lower, offsets := lowerWithOffsets(title)
+	original := func(i int) int {
+		if offsets == nil {
+			return i
+		}
+		return offsets[i]
+	}
+
+	done := false
+	m.phrases.scan(lower, func(id, start, end int) {
+		if done {
+			return
+		}
+		rule := m.phraseRules[id]
+		if !atTokenBoundary(lower, start, end) {
+			return
+		}
+		if m.rules[rule].typ == Question && !atQuestionPosition(lower, start, end) {
+			return
+		}
+		if !applies(m.rules[rule]) {
+			return
+		}
+		done = !found(rule, original(start), original(end))
+	})
+
+	for _, rule := range m.regexRules {
+		if done {
+			return
+		}
+		for _, span := range m.rules[rule].re.FindAllStringIndex(lower, -1) {
+			if !applies(m.rules[rule]) {
+				break
+			}
+			start := skipNonWord(lower, span[0], span[1])
+			if !found(rule, original(start), original(span[1])) {
+				return
+			}
+		}
+	}
+}
+
+// lowerWithOffsets lowercases s rune by rune. When lowercasing changes the
+// byte length of any rune, it also returns the byte offset in s of every
+// byte offset in the result (plus one past the end); otherwise offsets is
+// nil and offsets are unchanged.
+func lowerWithOffsets(s string) (string, []int) {
+	ascii := true
+	for i := 0; i < len(s); i++ {
+		if s[i] >= utf8.RuneSelf {
+			ascii = false
+			break
+		}
+	}
+	if ascii {
+		return strings.ToLower(s), nil
+	}
+
+	var sb strings.Builder
+	sb.Grow(len(s))
+	offsets := make([]int, 0, len(s)+1)
+	resized := false
+	for i := 0; i < len(s); {
+		r, n := utf8.DecodeRuneInString(s[i:])
+		if r == utf8.RuneError && n == 1 {
+			sb.WriteByte(s[i])
+			offsets = append(offsets, i)
+			i++
+			continue
+		}
+		lr := unicode.ToLower(r)
+		size := utf8.RuneLen(lr)
+		if size != n {
+			resized = true
+		}
+		sb.WriteRune(lr)
+		for k := 0; k < size; k++ {
+			offsets = append(offsets, i)
+		}
+		i += n
+	}
+	offsets = append(offsets, len(s))
+	if !resized {
+		return sb.String(), nil
+	}
+	return sb.String(), offsets
+}
+
+// skipNonWord returns the offset of the first word rune in s[start:end], so
+// a regex anchored on a clause separator ("- never") is reported from its
+// first word. It returns start when the span has no word runes.
+func skipNonWord(s string, start, end int) int {
+	for i, r := range s[start:end] {
+		if isWordRune(r) {
+			return start + i
+		}
+	}
+	return start
+}
+
+// atTokenBoundary reports whether s[start:end] is not part of a longer word.
+func atTokenBoundary(s string, start, end int) bool {
+	if start > 0 {
+		if r, _ := utf8.DecodeLastRuneInString(s[:start]); isWordRune(r) {
+			return false
+		}
+	}
+	if end < len(s) {
+		if r, _ := utf8.DecodeRuneInString(s[end:]); isWordRune(r) {
+			return false
+		}
+	}
+	return true
+}
+
+func isWordRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsNumber(r) || unicode.IsMark(r)
+}
+
+// atQuestionPosition reports whether a question word at s[start:end] opens
+// the title or a clause after "- ", "| " or ": ", and is followed by a space
+// or an apostrophe ("what's").
+func atQuestionPosition(s string, start, end int) bool {
+	if end >= len(s) || (s[end] != ' ' && s[end] != '\'') {
+		return false
+	}
+	if start == 0 {
+		return true
+	}
+	if start < 2 || s[start-1] != ' ' {
+		return false
+	}
+	switch s[start-2] {
+	case '-', '|', ':':
+		return s[end] == ' '
+	}
+	return false
+}
@@ -0,0 +1,318 @@
+package hooks
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+)
+
+func TestAutomaton_OverlappingPatterns(t *testing.T) {
+	a := newAutomaton([]string{"he", "she", "his", "hers"})
+
+	type hit struct{ id, start, end int }
+	var got []hit
+	a.scan("ushers", func(id, start, end int) {
+		got = append(got, hit{id, start, end})
+	})
+	sort.Slice(got, func(i, j int) bool {
+		if got[i].end != got[j].end {
+			return got[i].end < got[j].end
+		}
+		return got[i].id < got[j].id
+	})
+
+	want := []hit{{0, 2, 4}, {1, 1, 4}, {3, 2, 6}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("scan(\"ushers\") = %v, want %v", got, want)
+	}
+}
+
+func TestMatcher_WordBoundaries(t *testing.T) {
+	tests := []struct {
+		title string
+		want  bool
+	}{
+		{"Free Course on Go", true},
+		{"Freedom of Speech", false},
+		{"A forewarning about Rust", false},
+		{"Warning: don't run this", true},
+		{"100% FREE!", true},
+		{"This Game-Changer Tool", true},
+		{"Secretly Building Apps", false},
+	}
+
+	m := DefaultMatcher()
+	for _, tt := range tests {
+		found := false
+		for _, match := range m.Match(tt.title) {
+			if match.Type == PowerWord {
+				found = true
+			}
+		}
+		if found != tt.want {
+			t.Errorf("power word in %q = %v, want %v", tt.title, found, tt.want)
+		}
+	}
+}
+
+func TestMatcher_QuestionPosition(t *testing.T) {
+	tests := []struct {
+		title string
+		want  bool
+	}{
+		{"How to Learn Go", true},
+		{"What's New in Go 1.22", true},
+		{"Go Tutorial - Why Interfaces Matter", true},
+		{"Go Tutorial | How Channels Work", true},
+		{"Learning How to Code", false},
+		{"Showcase of Apps", false},
+	}
+
+	for _, tt := range tests {
+		found := false
+		for _, match := range DefaultMatcher().Match(tt.title) {
+			if match.Type == Question {
+				found = true
+			}
+		}
+		if found != tt.want {
+			t.Errorf("question in %q = %v, want %v", tt.title, found, tt.want)
+		}
+	}
+}
+
+func TestMatcher_MatchSpans(t *testing.T) {
+	tests := []struct {
+		title   string
+		pattern string
+	}{
+		{"The SECRET Trick", "secret"},
+		{"Ünïcode: the secret revealed", "secret"},
+		{"İstanbul Devs: Free Workshop", "free"},
+		{"Top 5 Tips", "top-n"},
+	}
+
+	for _, tt := range tests {
+		var match *Match
+		for _, m := range DefaultMatcher().Match(tt.title) {
+			if m.Pattern == tt.pattern {
+				m := m
+				match = &m
+				break
+			}
+		}
+		if match == nil {
+			t.Errorf("Match(%q) has no %q", tt.title, tt.pattern)
+			continue
+		}
+		if match.Start < 0 || match.End > len(tt.title) || match.Start >= match.End {
+			t.Errorf("Match(%q) span [%d,%d) out of range", tt.title, match.Start, match.End)
+			continue
+		}
+		got := tt.title[match.Start:match.End]
+		if tt.pattern != "top-n" && !strings.EqualFold(got, tt.pattern) {
+			t.Errorf("Match(%q) span = %q, want %q", tt.title, got, tt.pattern)
+		}
+	}
+}
+
+func TestMatcher_MatchPositions(t *testing.T) {
+	tests := []struct {
+		title   string
+		pattern string
+		char    int
+		word    int
+	}{
+		{"Free Course", "free", 0, 0},
+		{"The Secret Trick", "secret", 4, 1},
+		{"Élan Vital: the Secret of Go", "secret", 16, 3},
+		{"Go Tips - Never Use Panic", "never", 10, 2},
+	}
+
+	for _, tt := range tests {
+		found := false
+		for _, m := range DefaultMatcher().Match(tt.title) {
+			if m.Pattern != tt.pattern {
+				continue
+			}
+			found = true
+			if m.Char != tt.char || m.Word != tt.word {
+				t.Errorf("Match(%q) %q at char %d word %d, want char %d word %d", tt.title, tt.pattern, m.Char, m.Word, tt.char, tt.word)
+			}
+		}
+		if !found {
+			t.Errorf("Match(%q) has no %q", tt.title, tt.pattern)
+		}
+	}
+}
+
+func TestMatcher_MatchOrderedByPosition(t *testing.T) {
+	matches := DefaultMatcher().Match("Why This Secret Is Free")
+	for i := 1; i < len(matches); i++ {
+		if matches[i].Start < matches[i-1].Start {
+			t.Fatalf("matches not ordered by position: %+v", matches)
+		}
+	}
+	if len(matches) < 3 {
+		t.Errorf("Match() = %+v, want question and two power words", matches)
+	}
+}
+
+func TestMatcher_NewHookTypes(t *testing.T) {
+	tests := []struct {
+		title   string
+		typ     HookType
+		pattern string
+	}{
+		{"Cursor vs Copilot: Which Is Faster?", Comparison, "vs"},
+		{"Claude Is Better Than GPT-4 at Refactoring", Comparison, "better than"},
+		{"I Tried Vibe Coding for 30 Days", Challenge, "i tried"},
+		{"I Tried Vibe Coding for 30 Days", Challenge, "for n days"},
+		{"How I Built a SaaS in a Weekend", Story, "how i"},
+		{"My Journey from Bootcamp to Senior Dev", Story, "my story"},
+		{"Don't Use useEffect Like This", Warning, "don't"},
+		{"React Tips - Never Mutate State", Warning, "never"},
+		{"The Fastest Way to Learn Go", Superlative, "fastest"},
+		{"The Best AI Tools", Superlative, "best"},
+		{"Build an API in 60 Seconds", TimeBound, "in n minutes"},
+		{"Best AI Tools 2025", TimeBound, "year"},
+	}
+
+	for _, tt := range tests {
+		found := false
+		for _, m := range DefaultMatcher().Match(tt.title) {
+			if m.Type == tt.typ && m.Pattern == tt.pattern {
+				found = true
+			}
+		}
+		if !found {
+			t.Errorf("Match(%q) has no %s %q hook", tt.title, tt.typ, tt.pattern)
+		}
+	}
+}
+
+func TestMatcher_NewHookTypesNearMisses(t *testing.T) {
+	tests := []struct {
+		title string
+		typ   HookType
+	}{
+		{"Versioning APIs the Right Way", Comparison},
+		{"What They Don't Teach You", Warning},
+		{"Learning How I/O Works", Story},
+		{"How I/O Scheduling Works", Story},
+		{"Bestselling Books for Devs", Superlative},
+		{"Port 8080 Explained", TimeBound},
+	}
+
+	for _, tt := range tests {
+		for _, m := range DefaultMatcher().Match(tt.title) {
+			if m.Type == tt.typ {
+				t.Errorf("Match(%q) unexpectedly has %s hook %q", tt.title, tt.typ, m.Pattern)
+			}
+		}
+	}
+}
+
+func TestExtractHooks_CountsTitleOncePerPattern(t *testing.T) {
+	hooks := ExtractHooks([]string{"Free, free, FREE", "Free Stuff"})
+	for _, h := range hooks {
+		if h.Pattern == "free" && h.Frequency != 2 {
+			t.Errorf("free frequency = %d, want 2", h.Frequency)
+		}
+	}
+}
+
+func TestCompileLowerRegex(t *testing.T) {
+	tests := []struct {
+		expr  string
+		input string
+		want  bool
+	}{
+		{`(?i)You Won'?t Believe`, "you wont believe this", true},
+		{`(?i)\btop\s*(\d+)\b`, "top 10 tools", true},
+		{`(?i)the truth about`, "the truthabout", false},
+		{`plot twist`, "a plot twist", true},
+	}
+
+	for _, tt := range tests {
+		re, err := compileLowerRegex(tt.expr)
+		if err != nil {
+			t.Fatalf("compileLowerRegex(%q) error: %v", tt.expr, err)
+		}
+		if got := re.MatchString(tt.input); got != tt.want {
+			t.Errorf("compileLowerRegex(%q).MatchString(%q) = %v, want %v", tt.expr, tt.input, got, tt.want)
+		}
+	}
+
+	if _, err := compileLowerRegex("(unclosed"); err == nil {
+		t.Error("compileLowerRegex(\"(unclosed\") should fail")
+	}
+}
+
+// benchmarkTitles returns n deterministic synthetic titles mixing hooks,
+// near misses and plain titles.
+func benchmarkTitles(n int) []string {
+	templates := []string{
+		"How to Build a %s App in %d Minutes",
+		"The Secret %s Trick Nobody Talks About (%d)",
+		"Freedom to Ship: %s in Production, Part %d",
+		"Top %[2]d %[1]s Tools You Won't Believe Exist",
+		"Building a %s Pipeline from Scratch #%d",
+		"Warning: Stop Using %s Like This (v%d)",
+		"Why %s Is Revolutionary - Episode %d",
+		"A Forewarning for %s Developers, Lesson %d",
+	}
+	topics := []string{"Go", "Rust", "React", "Kubernetes", "LangChain", "Python", "Postgres", "Svelte"}
+
+	titles := make([]string, n)
+	for i := range titles {
+		titles[i] = fmt.Sprintf(templates[i%len(templates)], topics[(i/len(templates))%len(topics)], i%97)
+	}
+	return titles
+}
+
+func BenchmarkExtractHooks_100k(b *testing.B) {
+	titles := benchmarkTitles(100000)
+	m := DefaultMatcher()
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		m.ExtractHooks(titles)
+	}
+}
+
+func BenchmarkHasHook_100k(b *testing.B) {
+	titles := benchmarkTitles(100000)
+	m := DefaultMatcher()
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		for _, title := range titles {
+			m.HasHook(title)
+		}
+	}
+}
+
+func BenchmarkMatch_100k(b *testing.B) {
+	titles := benchmarkTitles(100000)
+	m := DefaultMatcher()
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		for _, title := range titles {
+			m.Match(title)
+		}
+	}
+}
+
+func TestMatcher_EnglishAITitles(t *testing.T) {
+	// "AI" must not make a title French and switch off the English packs
+	m := DefaultMatcher()
+	for _, title := range []string{"AI vs AI: the best AI", "Secret AI tools"} {
+		if !m.HasHook(title) {
+			t.Errorf("HasHook(%q) = false, want true", title)
+		}
+	}
+}
@@ -1,12 +1,9 @@
 // Package hooks provides extraction of engagement hooks from video titles.
-// Hooks are patterns that attract viewer attention (questions, numbers, power words).
+// Hooks are patterns that attract viewer attention (questions, numbers, power
+// words, comparisons, challenges, stories, warnings, superlatives, deadlines).
 package hooks
 
-import (
-	"regexp"
-	"sort"
-	"strings"
-)
+import "sort"
 
 // HookType represents the category of an engagement hook.
 type HookType int
@@ -16,8 +13,20 @@ const (
 	Numerical
 	PowerWord
 	CuriosityGap
+	Comparison  // "X vs Y", "better than"
+	Challenge   // "I tried...", "for 30 days"
+	Story       // "How I...", "my journey"
+	Warning     // "Don't...", "Never...", "avoid"
+	Superlative // "best", "worst", "fastest"
+	TimeBound   // "in 60 seconds", "2025", "today"
 )
 
+// HookTypes lists every hook type in report order.
+var HookTypes = []HookType{
+	Question, Numerical, PowerWord, CuriosityGap,
+	Comparison, Challenge, Story, Warning, Superlative, TimeBound,
+}
+
 // String returns the string representation of a HookType.
 func (h HookType) String() string {
 	switch h {
@@ -29,6 +38,18 @@ func (h HookType) String() string {
 		return "PowerWord"
 	case CuriosityGap:
 		return "CuriosityGap"
+	case Comparison:
+		return "Comparison"
+	case Challenge:
+		return "Challenge"
+	case Story:
+		return "Story"
+	case Warning:
+		return "Warning"
+	case Superlative:
+		return "Superlative"
+	case TimeBound:
+		return "TimeBound"
 	default:
 		return "Unknown"
 	}
@@ -39,180 +60,94 @@ type Hook struct {
 	Type      HookType
 	Pattern   string   // The matched pattern (e.g., "how", "5", "secret")
 	Frequency int      // How many times this pattern appeared
-	Examples  []string // Example titles containing this hook (up to 3)
+	Weight    float64  // Ranking weight from the hook pack (default 1)
+	Examples  []string // Example titles containing this hook (up to 3, best performing first when scored)
+
+	// Performance of the videos using the hook, set by ExtractVideoHooks
+	VideoIDs       []string // IDs of the videos whose titles contain the hook
+	MedianViews    int64    // Median views of those videos
+	MeanViews      float64  // Mean views of those videos
+	EngagementRate float64  // Median like-to-view ratio as a percentage
+	Lift           float64  // MedianViews relative to videos without the hook (1.0 = same, 0 = no baseline)
 }
 
-// Regex patterns for different hook types
-var (
-	questionWords = []string{"what", "how", "why", "who", "when", "where", "which", "can", "do", "does", "is", "are", "will", "should"}
-	numericalRe   = regexp.MustCompile(`(?i)\b(\d+)\s*(ways?|tips?|tricks?|secrets?|reasons?|things?|steps?|mistakes?|hacks?|ideas?|methods?|strategies?|rules?|facts?|signs?|lessons?)\b`)
-	topNumericalRe = regexp.MustCompile(`(?i)\btop\s*(\d+)\b`)
-
-	curiosityPatterns = []string{
-		`(?i)you won'?t believe`,
-		`(?i)this is why`,
-		`(?i)here'?s what`,
-		`(?i)the reason`,
-		`(?i)nobody tells you`,
-		`(?i)what happened`,
-		`(?i)what they don'?t`,
-		`(?i)the truth about`,
-		`(?i)you need to know`,
-		`(?i)stop doing this`,
+// weightedFrequency is the frequency scaled by the hook's pack weight.
+func (h Hook) weightedFrequency() float64 {
+	if h.Weight <= 0 {
+		return float64(h.Frequency)
 	}
-)
-
-var powerWords = []string{
-	"secret", "secrets",
-	"shocking", "shocked",
-	"amazing", "amazed",
-	"ultimate",
-	"insane", "crazy",
-	"unbelievable", "incredible",
-	"powerful", "proven",
-	"instant", "instantly",
-	"free", "guaranteed",
-	"exclusive", "limited",
-	"urgent", "warning",
-	"banned", "hidden",
-	"revealed", "exposed",
-	"game-changer", "life-changing",
-	"mind-blowing", "jaw-dropping",
-	"breakthrough", "revolutionary",
+	return float64(h.Frequency) * h.Weight
 }
 
 // GetPowerWords returns the list of power words used for hook detection.
 func GetPowerWords() []string {
-	result := make([]string, len(powerWords))
-	copy(result, powerWords)
-	return result
+	return defaultMatcher.PowerWords()
 }
 
-// ExtractHooks analyzes titles and returns detected engagement hooks.
-// Results are sorted by frequency (highest first) within each type.
+// ExtractHooks analyzes titles and returns detected engagement hooks using
+// the bundled hook packs. Results are sorted by weighted frequency (highest
+// first) within each type.
 func ExtractHooks(titles []string) []Hook {
+	return defaultMatcher.ExtractHooks(titles)
+}
+
+// ExtractHooks analyzes titles and returns detected engagement hooks.
+// Results are sorted by weighted frequency (highest first) within each type.
+func (m *Matcher) ExtractHooks(titles []string) []Hook {
 	if len(titles) == 0 {
 		return []Hook{}
 	}
+	if m == nil {
+		m = defaultMatcher
+	}
 
-	// Track patterns and their occurrences
-	questionCounts := make(map[string][]string)
-	numericalCounts := make(map[string][]string)
-	powerWordCounts := make(map[string][]string)
-	curiosityCounts := make(map[string][]string)
-
-	for _, title := range titles {
-		lower := strings.ToLower(title)
-
-		// Check for question words at the start of title
-		for _, qw := range questionWords {
-			if matchesQuestionPattern(lower, qw) {
-				questionCounts[qw] = appendExample(questionCounts[qw], title)
-			}
-		}
-
-		// Check for numerical patterns
-		if matches := numericalRe.FindStringSubmatch(lower); len(matches) > 0 {
-			key := "numerical"
-			numericalCounts[key] = appendExample(numericalCounts[key], title)
-		}
-		if matches := topNumericalRe.FindStringSubmatch(lower); len(matches) > 0 {
-			key := "top-n"
-			numericalCounts[key] = appendExample(numericalCounts[key], title)
-		}
-
-		// Check for power words
-		for _, pw := range powerWords {
-			if strings.Contains(lower, pw) {
-				numericalCounts[pw] = nil // just for detection, actual tracking below
-				powerWordCounts[pw] = appendExample(powerWordCounts[pw], title)
-			}
-		}
-
-		// Check for curiosity gap patterns
-		for i, pattern := range curiosityPatterns {
-			re := regexp.MustCompile(pattern)
-			if re.MatchString(lower) {
-				key := curiosityPatternKey(i)
-				curiosityCounts[key] = appendExample(curiosityCounts[key], title)
+	// Track the titles matching each rule, counting each title once per rule
+	examples := make([][]string, len(m.rules))
+	lastTitle := make([]int, len(m.rules))
+	for i := range lastTitle {
+		lastTitle[i] = -1
+	}
+	for t, title := range titles {
+		m.match(title, func() string { return titleLanguage(title) }, func(rule, start, end int) bool {
+			if lastTitle[rule] != t {
+				lastTitle[rule] = t
+				examples[rule] = append(examples[rule], title)
 			}
-		}
+			return true
+		})
 	}
 
 	// Build result slice
 	var hooks []Hook
-
-	// Add question hooks
-	for pattern, examples := range questionCounts {
-		hooks = append(hooks, Hook{
-			Type:      Question,
-			Pattern:   pattern,
-			Frequency: len(examples),
-			Examples:  limitExamples(examples, 3),
-		})
-	}
-
-	// Add numerical hooks
-	for pattern, examples := range numericalCounts {
-		if pattern == "numerical" || pattern == "top-n" {
-			hooks = append(hooks, Hook{
-				Type:      Numerical,
-				Pattern:   pattern,
-				Frequency: len(examples),
-				Examples:  limitExamples(examples, 3),
-			})
+	for i, r := range m.rules {
+		if len(examples[i]) == 0 {
+			continue
 		}
-	}
-
-	// Add power word hooks
-	for pattern, examples := range powerWordCounts {
 		hooks = append(hooks, Hook{
-			Type:      PowerWord,
-			Pattern:   pattern,
-			Frequency: len(examples),
-			Examples:  limitExamples(examples, 3),
+			Type:      r.typ,
+			Pattern:   r.name,
+			Frequency: len(examples[i]),
+			Weight:    r.weight,
+			Examples:  limitExamples(examples[i], 3),
 		})
 	}
 
-	// Add curiosity gap hooks
-	for pattern, examples := range curiosityCounts {
-		hooks = append(hooks, Hook{
-			Type:      CuriosityGap,
-			Pattern:   pattern,
-			Frequency: len(examples),
-			Examples:  limitExamples(examples, 3),
-		})
-	}
+	sortHooks(hooks)
+	return hooks
+}
 
-	// Sort by frequency descending within each type
+// sortHooks sorts hooks by type, then by weighted frequency (highest first).
+func sortHooks(hooks []Hook) {
 	sort.Slice(hooks, func(i, j int) bool {
 		if hooks[i].Type != hooks[j].Type {
 			return hooks[i].Type < hooks[j].Type
 		}
-		return hooks[i].Frequency > hooks[j].Frequency
-	})
-
-	return hooks
-}
-
-// matchesQuestionPattern checks if text starts with or contains a question word pattern.
-func matchesQuestionPattern(text, word string) bool {
-	// Check if starts with the question word
-	if strings.HasPrefix(text, word+" ") || strings.HasPrefix(text, word+"'") {
-		return true
-	}
-	// Also match question words after common prefixes
-	prefixes := []string{"- ", "| ", ": "}
-	for _, prefix := range prefixes {
-		if strings.Contains(text, prefix+word+" ") {
-			return true
+		wi, wj := hooks[i].weightedFrequency(), hooks[j].weightedFrequency()
+		if wi != wj {
+			return wi > wj
 		}
-	}
-	return false
-}
-
-func appendExample(examples []string, title string) []string {
-	return append(examples, title)
+		return hooks[i].Pattern < hooks[j].Pattern
+	})
 }
 
 func limitExamples(examples []string, max int) []string {
@@ -222,56 +157,21 @@ func limitExamples(examples []string, max int) []string {
 	return examples[:max]
 }
 
-func curiosityPatternKey(index int) string {
-	keys := []string{
-		"won't believe",
-		"this is why",
-		"here's what",
-		"the reason",
-		"nobody tells",
-		"what happened",
-		"what they don't",
-		"the truth about",
-		"need to know",
-		"stop doing",
-	}
-	if index < len(keys) {
-		return keys[index]
-	}
-	return "curiosity"
-}
-
-// HasHook checks if a title contains any hook pattern.
+// HasHook checks if a title contains any hook pattern of the bundled packs.
 // This provides a consistent definition of "has hook" that matches ExtractHooks.
 func HasHook(title string) bool {
-	lower := strings.ToLower(title)
-
-	// Check for question words at the start
-	for _, qw := range questionWords {
-		if matchesQuestionPattern(lower, qw) {
-			return true
-		}
-	}
-
-	// Check for numerical patterns
-	if numericalRe.MatchString(lower) || topNumericalRe.MatchString(lower) {
-		return true
-	}
-
-	// Check for power words
-	for _, pw := range powerWords {
-		if strings.Contains(lower, pw) {
-			return true
-		}
-	}
+	return defaultMatcher.HasHook(title)
+}
 
-	// Check for curiosity gap patterns
-	for _, pattern := range curiosityPatterns {
-		re := regexp.MustCompile(pattern)
-		if re.MatchString(lower) {
-			return true
-		}
+// HasHook checks if a title contains any of the matcher's hook patterns.
+func (m *Matcher) HasHook(title string) bool {
+	if m == nil {
+		m = defaultMatcher
 	}
-
-	return false
+	found := false
+	m.match(title, func() string { return titleLanguage(title) }, func(rule, start, end int) bool {
+		found = true
+		return false
+	})
+	return found
 }
@@ -14,6 +14,12 @@ func TestHookType_String(t *testing.T) {
 		{Numerical, "Numerical"},
 		{PowerWord, "PowerWord"},
 		{CuriosityGap, "CuriosityGap"},
+		{Comparison, "Comparison"},
+		{Challenge, "Challenge"},
+		{Story, "Story"},
+		{Warning, "Warning"},
+		{Superlative, "Superlative"},
+		{TimeBound, "TimeBound"},
 	}
 
 	for _, tt := range tests {
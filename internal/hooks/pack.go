@@ -0,0 +1,269 @@
+package hooks
+
+import (
+	"embed"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/mikelady/kingmaker/internal/text"
+)
+
+//go:embed packs/*.json
+var bundledPacks embed.FS
+
+// Definition is a single hook rule in a pack. A definition either lists
+// words, each reported as its own pattern, or gives a regex reported under
+// Name.
+type Definition struct {
+	Type     string   `json:"type"`               // Hook type name, e.g. "question", "power_word" or "time_bound"
+	Name     string   `json:"name"`               // Pattern name for regex definitions (e.g., "top-n")
+	Regex    string   `json:"regex,omitempty"`    // Regular expression matched against the lowercased title
+	Words    []string `json:"words,omitempty"`    // Words or phrases matched as whole tokens (question words only at the start)
+	Weight   float64  `json:"weight,omitempty"`   // Ranking weight (default 1)
+	Disabled bool     `json:"disabled,omitempty"` // Removes a rule defined by an earlier pack
+}
+
+// Pack is a named set of hook definitions for one language.
+type Pack struct {
+	Name     string       `json:"name"`
+	Language string       `json:"language"` // Language code of the pack's words and patterns; its rules only match titles in that language ("" = any)
+	Hooks    []Definition `json:"hooks"`
+}
+
+// hookTypePackNames are the pack type names of the hook types, indexed by
+// HookType.
+var hookTypePackNames = []string{
+	Question:     "question",
+	Numerical:    "numerical",
+	PowerWord:    "power_word",
+	CuriosityGap: "curiosity_gap",
+	Comparison:   "comparison",
+	Challenge:    "challenge",
+	Story:        "story",
+	Warning:      "warning",
+	Superlative:  "superlative",
+	TimeBound:    "time_bound",
+}
+
+// hookTypeNames maps pack type names to hook types.
+var hookTypeNames = func() map[string]HookType {
+	names := make(map[string]HookType, len(hookTypePackNames))
+	for t, name := range hookTypePackNames {
+		names[name] = HookType(t)
+	}
+	return names
+}()
+
+// ParseHookType parses a pack type name such as "power_word".
+func ParseHookType(name string) (HookType, error) {
+	t, ok := hookTypeNames[strings.ToLower(strings.TrimSpace(name))]
+	if !ok {
+		names := make([]string, 0, len(hookTypeNames))
+		for n := range hookTypeNames {
+			names = append(names, n)
+		}
+		sort.Strings(names)
+		return 0, fmt.Errorf("unknown hook type %q (use %s)", name, strings.Join(names, ", "))
+	}
+	return t, nil
+}
+
+// Name returns the pack type name of h (e.g., "power_word"), or "" for
+// unknown types.
+func (h HookType) Name() string {
+	if h < 0 || int(h) >= len(hookTypePackNames) {
+		return ""
+	}
+	return hookTypePackNames[h]
+}
+
+// DefaultPacks returns the bundled hook packs. English is the default pack.
+func DefaultPacks() []Pack {
+	entries, err := bundledPacks.ReadDir("packs")
+	if err != nil {
+		panic("hooks: missing bundled packs: " + err.Error())
+	}
+
+	packs := make([]Pack, 0, len(entries))
+	for _, e := range entries {
+		data, err := bundledPacks.ReadFile(path.Join("packs", e.Name()))
+		if err != nil {
+			panic("hooks: reading bundled pack: " + err.Error())
+		}
+		pack, err := ParsePack(data)
+		if err != nil {
+			panic(fmt.Sprintf("hooks: invalid bundled pack %s: %v", e.Name(), err))
+		}
+		packs = append(packs, pack)
+	}
+	return packs
+}
+
+// ParsePack decodes a JSON hook pack.
+func ParsePack(data []byte) (Pack, error) {
+	var p Pack
+	if err := json.Unmarshal(data, &p); err != nil {
+		return Pack{}, err
+	}
+	return p, nil
+}
+
+// LoadPack reads a JSON hook pack from a file.
+func LoadPack(file string) (Pack, error) {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return Pack{}, fmt.Errorf("reading hook pack: %w", err)
+	}
+	p, err := ParsePack(data)
+	if err != nil {
+		return Pack{}, fmt.Errorf("parsing hook pack %s: %w", file, err)
+	}
+	return p, nil
+}
+
+// rule is a compiled hook definition matching a single pattern.
+type rule struct {
+	typ    HookType
+	name   string         // Reported pattern
+	word   string         // Word to match, for word rules
+	re     *regexp.Regexp // Pattern to match, for regex rules
+	lang   string         // Language of the rule's pack ("" = any)
+	weight float64
+}
+
+// key identifies a rule across packs, so later packs of the same language
+// can override it.
+func (r rule) key() string {
+	return r.lang + "/" + r.typ.String() + "/" + r.name
+}
+
+// appliesTo reports whether the rule runs on a title in lang. Rules of packs
+// without a language, and titles whose language is unknown, match any.
+func (r rule) appliesTo(lang string) bool {
+	return r.lang == "" || lang == "" || r.lang == lang
+}
+
+// Matcher detects hooks in titles using the rules of one or more packs.
+// Word and phrase rules are compiled into a single Aho-Corasick automaton
+// and regex rules are compiled once, so matching a title is one pass over
+// it plus one per regex.
+type Matcher struct {
+	rules       []rule
+	phrases     *automaton // Automaton over the words of phraseRules
+	phraseRules []int      // Rule index of each automaton pattern
+	regexRules  []int      // Indexes of regex rules
+}
+
+// NewMatcher compiles packs into a matcher. Packs are layered in order: a
+// rule with the same language, type and pattern name as an earlier one
+// replaces it, and a disabled definition removes it.
+func NewMatcher(packs ...Pack) (*Matcher, error) {
+	var rules []rule
+	index := make(map[string]int)
+	for _, p := range packs {
+		lang := text.NormalizeLanguage(p.Language)
+		for i, d := range p.Hooks {
+			compiled, err := compileDefinition(d)
+			if err != nil {
+				return nil, fmt.Errorf("pack %q hook %d: %w", p.Name, i+1, err)
+			}
+			for _, r := range compiled {
+				r.lang = lang
+				pos, exists := index[r.key()]
+				switch {
+				case d.Disabled && exists:
+					rules[pos].weight = 0
+				case d.Disabled:
+				case exists:
+					rules[pos] = r
+				default:
+					index[r.key()] = len(rules)
+					rules = append(rules, r)
+				}
+			}
+		}
+	}
+
+	m := &Matcher{}
+	for _, r := range rules {
+		if r.weight > 0 {
+			m.rules = append(m.rules, r)
+		}
+	}
+	m.compile()
+	return m, nil
+}
+
+// compileDefinition expands a definition into one rule per word, or a
+// single regex rule.
+func compileDefinition(d Definition) ([]rule, error) {
+	typ, err := ParseHookType(d.Type)
+	if err != nil {
+		return nil, err
+	}
+	weight := d.Weight
+	if weight <= 0 {
+		weight = 1
+	}
+
+	switch {
+	case d.Regex != "" && len(d.Words) > 0:
+		return nil, fmt.Errorf("%q sets both regex and words", d.Name)
+	case d.Regex != "":
+		if d.Name == "" {
+			return nil, fmt.Errorf("regex %q needs a name", d.Regex)
+		}
+		re, err := compileLowerRegex(d.Regex)
+		if err != nil {
+			return nil, fmt.Errorf("invalid regex for %q: %w", d.Name, err)
+		}
+		return []rule{{typ: typ, name: d.Name, re: re, weight: weight}}, nil
+	case len(d.Words) > 0:
+		rules := make([]rule, 0, len(d.Words))
+		for _, w := range d.Words {
+			w = strings.ToLower(strings.TrimSpace(w))
+			if w == "" {
+				continue
+			}
+			rules = append(rules, rule{typ: typ, name: w, word: w, weight: weight})
+		}
+		return rules, nil
+	default:
+		return nil, fmt.Errorf("%q needs a regex or words", d.Name)
+	}
+}
+
+// defaultMatcher is compiled from the bundled packs.
+var defaultMatcher = mustDefaultMatcher()
+
+func mustDefaultMatcher() *Matcher {
+	m, err := NewMatcher(DefaultPacks()...)
+	if err != nil {
+		panic("hooks: invalid bundled packs: " + err.Error())
+	}
+	return m
+}
+
+// DefaultMatcher returns the matcher for the bundled packs.
+func DefaultMatcher() *Matcher {
+	return defaultMatcher
+}
+
+// PowerWords returns the power words the matcher detects.
+func (m *Matcher) PowerWords() []string {
+	if m == nil {
+		m = defaultMatcher
+	}
+	var words []string
+	for _, r := range m.rules {
+		if r.typ == PowerWord && r.word != "" {
+			words = append(words, r.word)
+		}
+	}
+	return words
+}
@@ -0,0 +1,182 @@
+package hooks
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/mikelady/kingmaker/internal/model"
+)
+
+func TestDefaultPacks_English(t *testing.T) {
+	packs := DefaultPacks()
+	if len(packs) != 1 || packs[0].Language != "en" {
+		t.Fatalf("DefaultPacks() = %+v, want one English pack", packs)
+	}
+
+	types := make(map[HookType]bool)
+	for _, d := range packs[0].Hooks {
+		typ, err := ParseHookType(d.Type)
+		if err != nil {
+			t.Fatalf("bundled pack: %v", err)
+		}
+		types[typ] = true
+	}
+	for _, typ := range HookTypes {
+		if !types[typ] {
+			t.Errorf("bundled pack has no %s hooks", typ)
+		}
+	}
+}
+
+func TestHookType_Name(t *testing.T) {
+	for _, typ := range HookTypes {
+		got, err := ParseHookType(typ.Name())
+		if err != nil || got != typ {
+			t.Errorf("ParseHookType(%q) = %v, %v; want %v", typ.Name(), got, err, typ)
+		}
+	}
+	if got := HookType(-1).Name(); got != "" {
+		t.Errorf("unknown type name = %q, want empty", got)
+	}
+}
+
+func TestNewMatcher_LayersPacks(t *testing.T) {
+	custom := Pack{
+		Name:     "copywriters",
+		Language: "en",
+		Hooks: []Definition{
+			{Type: "power_word", Words: []string{"free"}, Disabled: true},
+			{Type: "power_word", Words: []string{"secret"}, Weight: 3},
+			{Type: "curiosity_gap", Name: "plot twist", Regex: `(?i)plot twist`},
+		},
+	}
+	m, err := NewMatcher(append(DefaultPacks(), custom)...)
+	if err != nil {
+		t.Fatalf("NewMatcher() error = %v", err)
+	}
+
+	if m.HasHook("Free pizza friday") {
+		t.Error("disabled power word should not match")
+	}
+	if !m.HasHook("The plot twist nobody expected") {
+		t.Error("custom curiosity pattern should match")
+	}
+
+	hooks := m.ExtractHooks([]string{
+		"The secret menu",
+		"Insane results",
+		"Insane speed",
+	})
+	var powerWords []string
+	for _, h := range hooks {
+		if h.Type == PowerWord {
+			powerWords = append(powerWords, h.Pattern)
+		}
+	}
+	if strings.Join(powerWords, ",") != "secret,insane" {
+		t.Errorf("power words = %v, want weighted secret before insane", powerWords)
+	}
+}
+
+func TestNewMatcher_PackLanguage(t *testing.T) {
+	spanish := Pack{
+		Name:     "es",
+		Language: "es",
+		Hooks:    []Definition{{Type: "power_word", Words: []string{"real"}}},
+	}
+	m, err := NewMatcher(append(DefaultPacks(), spanish)...)
+	if err != nil {
+		t.Fatalf("NewMatcher() error = %v", err)
+	}
+
+	tests := []struct {
+		title string
+		want  string // Power word expected, "" for none
+	}{
+		{"The real reason it is so fast", ""},                  // English title: Spanish word skipped
+		{"La razón real de todo esto es simple", "real"},       // Spanish title
+		{"Cómo jugar free fire en el móvil de tu hermano", ""}, // Spanish title: English word skipped
+		{"Real talk", "real"},                                  // Too short to tell: every pack applies
+	}
+	for _, tt := range tests {
+		got := ""
+		for _, match := range m.Match(tt.title) {
+			if match.Type == PowerWord {
+				got = match.Pattern
+			}
+		}
+		if got != tt.want {
+			t.Errorf("Match(%q) power word = %q, want %q", tt.title, got, tt.want)
+		}
+	}
+
+	// A video's audio language decides for titles too short to detect
+	hooks := m.ExtractVideoHooks([]model.Video{
+		{ID: "a", Title: "Real talk", DefaultAudioLanguage: "en-US"},
+		{ID: "b", Title: "Real talk", DefaultAudioLanguage: "es-419"},
+	})
+	var real []string
+	for _, h := range hooks {
+		if h.Pattern == "real" {
+			real = h.VideoIDs
+		}
+	}
+	if strings.Join(real, ",") != "b" {
+		t.Errorf("real matched videos %v, want only the Spanish one", real)
+	}
+}
+
+func TestNewMatcher_Errors(t *testing.T) {
+	tests := []struct {
+		name string
+		def  Definition
+	}{
+		{"unknown type", Definition{Type: "meme", Words: []string{"lol"}}},
+		{"invalid regex", Definition{Type: "question", Name: "bad", Regex: "("}},
+		{"regex without name", Definition{Type: "question", Regex: "why"}},
+		{"no pattern", Definition{Type: "question", Name: "empty"}},
+		{"regex and words", Definition{Type: "question", Name: "both", Regex: "why", Words: []string{"why"}}},
+	}
+	for _, tt := range tests {
+		if _, err := NewMatcher(Pack{Name: "test", Hooks: []Definition{tt.def}}); err == nil {
+			t.Errorf("%s: expected error", tt.name)
+		}
+	}
+}
+
+func TestLoadPack(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "es.json")
+	data := `{"name": "spanish", "language": "es", "hooks": [{"type": "question", "words": ["cómo", "por qué"]}]}`
+	if err := os.WriteFile(path, []byte(data), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	pack, err := LoadPack(path)
+	if err != nil {
+		t.Fatalf("LoadPack() error = %v", err)
+	}
+	m, err := NewMatcher(pack)
+	if err != nil {
+		t.Fatalf("NewMatcher() error = %v", err)
+	}
+	hooks := m.ExtractHooks([]string{"Cómo hacer pan en casa"})
+	if len(hooks) != 1 || hooks[0].Pattern != "cómo" || hooks[0].Type != Question {
+		t.Errorf("ExtractHooks() = %+v, want cómo question hook", hooks)
+	}
+
+	if _, err := LoadPack(filepath.Join(t.TempDir(), "missing.json")); err == nil {
+		t.Error("expected error for missing pack")
+	}
+}
+
+func TestMatcher_NilUsesDefault(t *testing.T) {
+	var m *Matcher
+	if !m.HasHook("How to code") {
+		t.Error("nil matcher should use the bundled packs")
+	}
+	if len(m.PowerWords()) == 0 {
+		t.Error("nil matcher should list bundled power words")
+	}
+}
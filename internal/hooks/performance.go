@@ -0,0 +1,136 @@
+package hooks
+
+import (
+	"sort"
+
+	"github.com/mikelady/kingmaker/internal/model"
+	"github.com/mikelady/kingmaker/internal/stats"
+	"github.com/mikelady/kingmaker/internal/text"
+)
+
+// ExtractVideoHooks analyzes video titles using the bundled hook packs and
+// scores each hook by the performance of the videos using it.
+func ExtractVideoHooks(videos []model.Video) []Hook {
+	return defaultMatcher.ExtractVideoHooks(videos)
+}
+
+// ExtractVideoHooks analyzes video titles and returns detected hooks with the
+// IDs, view and engagement statistics of the videos using them. Lift compares
+// each hook's median views with videos whose titles lack it, and examples are
+// the best-performing titles. Results are sorted like ExtractHooks.
+func (m *Matcher) ExtractVideoHooks(videos []model.Video) []Hook {
+	if m == nil {
+		m = defaultMatcher
+	}
+
+	// Track the videos matching each rule, counting each video once per rule
+	matched := make([][]int, len(m.rules))
+	var titled []int
+	for i, v := range videos {
+		if v.Title == "" {
+			continue
+		}
+		titled = append(titled, i)
+		m.match(v.Title, func() string { return videoLanguage(v) }, func(rule, start, end int) bool {
+			if n := len(matched[rule]); n == 0 || matched[rule][n-1] != i {
+				matched[rule] = append(matched[rule], i)
+			}
+			return true
+		})
+	}
+
+	hooks := []Hook{}
+	for r, indexes := range matched {
+		if len(indexes) == 0 {
+			continue
+		}
+		hooks = append(hooks, videoHook(m.rules[r], videos, titled, indexes))
+	}
+
+	sortHooks(hooks)
+	return hooks
+}
+
+// videoLanguage is the language of a video's title: YouTube's
+// defaultAudioLanguage when set, else the language detected from the title.
+func videoLanguage(v model.Video) string {
+	if lang := text.NormalizeLanguage(v.DefaultAudioLanguage); lang != "" {
+		return lang
+	}
+	return titleLanguage(v.Title)
+}
+
+// minPerformingVideos is the minimum number of videos a hook must appear in
+// for its lift to be trusted.
+const minPerformingVideos = 2
+
+// BestPerforming returns up to n scored hooks with the highest lift, skipping
+// hooks used by too few videos or without a baseline to compare against.
+func BestPerforming(all []Hook, n int) []Hook {
+	var best []Hook
+	for _, h := range all {
+		if h.Lift > 0 && h.Frequency >= minPerformingVideos {
+			best = append(best, h)
+		}
+	}
+	sort.SliceStable(best, func(i, j int) bool {
+		if best[i].Lift != best[j].Lift {
+			return best[i].Lift > best[j].Lift
+		}
+		return best[i].Frequency > best[j].Frequency
+	})
+	if len(best) > n {
+		best = best[:n]
+	}
+	return best
+}
+
+// videoHook builds the hook for a rule from the indexes of the videos whose
+// titles match it, out of the indexes of all titled videos.
+func videoHook(r rule, videos []model.Video, titled, indexes []int) Hook {
+	with := make([]model.Video, 0, len(indexes))
+	inHook := make(map[int]bool, len(indexes))
+	for _, i := range indexes {
+		with = append(with, videos[i])
+		inHook[i] = true
+	}
+	without := make([]model.Video, 0, len(titled)-len(indexes))
+	for _, i := range titled {
+		if !inHook[i] {
+			without = append(without, videos[i])
+		}
+	}
+
+	h := Hook{
+		Type:           r.typ,
+		Pattern:        r.name,
+		Frequency:      len(with),
+		Weight:         r.weight,
+		VideoIDs:       make([]string, len(with)),
+		MedianViews:    stats.MedianViews(with),
+		MeanViews:      stats.MeanViews(with),
+		EngagementRate: stats.MedianEngagement(with),
+	}
+	for i, v := range with {
+		h.VideoIDs[i] = v.ID
+	}
+	if baseline := stats.MedianViews(without); baseline > 0 {
+		h.Lift = float64(h.MedianViews) / float64(baseline)
+	}
+
+	// Examples are the most viewed distinct titles
+	sort.SliceStable(with, func(i, j int) bool {
+		return with[i].ViewCount > with[j].ViewCount
+	})
+	seen := make(map[string]bool)
+	for _, v := range with {
+		if len(h.Examples) == 3 {
+			break
+		}
+		if !seen[v.Title] {
+			seen[v.Title] = true
+			h.Examples = append(h.Examples, v.Title)
+		}
+	}
+	return h
+}
@@ -0,0 +1,111 @@
+package hooks
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/mikelady/kingmaker/internal/model"
+)
+
+func TestExtractVideoHooks_Empty(t *testing.T) {
+	if got := ExtractVideoHooks(nil); len(got) != 0 {
+		t.Errorf("ExtractVideoHooks(nil) = %+v, want none", got)
+	}
+}
+
+func TestExtractVideoHooks_Performance(t *testing.T) {
+	videos := []model.Video{
+		{ID: "a", Title: "The Secret to Clean Code", ViewCount: 1000, LikeCount: 100},
+		{ID: "b", Title: "Secret Go Features", ViewCount: 9000, LikeCount: 450},
+		{ID: "c", Title: "Another Secret Trick", ViewCount: 5000, LikeCount: 100},
+		{ID: "d", Title: "Refactoring Legacy Code", ViewCount: 2000, LikeCount: 20},
+		{ID: "e", Title: "Unit Testing in Go", ViewCount: 1000, LikeCount: 10},
+		{ID: "f", Title: "", ViewCount: 50},
+	}
+
+	var secret *Hook
+	for _, h := range ExtractVideoHooks(videos) {
+		if h.Pattern == "secret" {
+			h := h
+			secret = &h
+		}
+	}
+	if secret == nil {
+		t.Fatal("expected a hook for \"secret\"")
+	}
+
+	if secret.Frequency != 3 {
+		t.Errorf("Frequency = %d, want 3", secret.Frequency)
+	}
+	if !reflect.DeepEqual(secret.VideoIDs, []string{"a", "b", "c"}) {
+		t.Errorf("VideoIDs = %v, want [a b c]", secret.VideoIDs)
+	}
+	if secret.MedianViews != 5000 {
+		t.Errorf("MedianViews = %d, want 5000", secret.MedianViews)
+	}
+	if secret.MeanViews != 5000 {
+		t.Errorf("MeanViews = %v, want 5000", secret.MeanViews)
+	}
+	if secret.EngagementRate != 5 {
+		t.Errorf("EngagementRate = %v, want 5 (median of 10%%, 5%%, 2%%)", secret.EngagementRate)
+	}
+	// Titles without the hook have a median of 1500 views; the untitled
+	// video is ignored
+	if want := 5000.0 / 1500.0; secret.Lift != want {
+		t.Errorf("Lift = %v, want %v", secret.Lift, want)
+	}
+	wantExamples := []string{"Secret Go Features", "Another Secret Trick", "The Secret to Clean Code"}
+	if !reflect.DeepEqual(secret.Examples, wantExamples) {
+		t.Errorf("Examples = %v, want most viewed first %v", secret.Examples, wantExamples)
+	}
+}
+
+func TestExtractVideoHooks_NoBaseline(t *testing.T) {
+	videos := []model.Video{
+		{ID: "a", Title: "Free Course", ViewCount: 100},
+		{ID: "b", Title: "Free Tools", ViewCount: 300},
+	}
+
+	for _, h := range ExtractVideoHooks(videos) {
+		if h.Pattern == "free" && h.Lift != 0 {
+			t.Errorf("Lift = %v, want 0 when every title uses the hook", h.Lift)
+		}
+	}
+}
+
+func TestExtractVideoHooks_DistinctExamples(t *testing.T) {
+	videos := []model.Video{
+		{ID: "a", Title: "Free Course", ViewCount: 300},
+		{ID: "b", Title: "Free Course", ViewCount: 200},
+		{ID: "c", Title: "Free Tools", ViewCount: 100},
+	}
+
+	for _, h := range ExtractVideoHooks(videos) {
+		if h.Pattern == "free" {
+			if h.Frequency != 3 {
+				t.Errorf("Frequency = %d, want 3", h.Frequency)
+			}
+			if !reflect.DeepEqual(h.Examples, []string{"Free Course", "Free Tools"}) {
+				t.Errorf("Examples = %v, want distinct titles", h.Examples)
+			}
+		}
+	}
+}
+
+func TestBestPerforming(t *testing.T) {
+	all := []Hook{
+		{Pattern: "how", Frequency: 10, Lift: 1.2},
+		{Pattern: "secret", Frequency: 3, Lift: 2.5},
+		{Pattern: "once", Frequency: 1, Lift: 9},
+		{Pattern: "everywhere", Frequency: 8},
+		{Pattern: "vs", Frequency: 4, Lift: 1.8},
+	}
+
+	var got []string
+	for _, h := range BestPerforming(all, 2) {
+		got = append(got, h.Pattern)
+	}
+	if want := []string{"secret", "vs"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("BestPerforming() = %v, want %v", got, want)
+	}
+}
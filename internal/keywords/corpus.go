@@ -0,0 +1,148 @@
+package keywords
+
+import (
+	_ "embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+)
+
+// Corpus holds background term statistics used to score keywords by how
+// distinctive they are, rather than by raw frequency.
+type Corpus struct {
+	Documents  int            `json:"documents"`           // Number of documents in the corpus
+	TotalTerms int            `json:"total_terms"`         // Number of terms across all documents
+	DocFreq    map[string]int `json:"doc_freq"`            // Documents containing each term
+	TermFreq   map[string]int `json:"term_freq"`           // Occurrences of each term
+	VideoIDs   []string       `json:"video_ids,omitempty"` // Videos already added, so reruns don't count them again
+}
+
+// bundledCorpus is a hand-written list of generic YouTube title and
+// description terms ("video", "subscribe", "best", ...), not statistics
+// measured from real videos. Document frequencies are rough estimates out
+// of a nominal 10,000 documents, and total_terms is the sum of the term
+// frequencies so that log-odds scoring stays consistent. Pass -corpus to
+// score against a corpus built from real videos instead.
+//
+//go:embed background.json
+var bundledCorpus []byte
+
+// NewCorpus creates an empty corpus.
+func NewCorpus() *Corpus {
+	return &Corpus{
+		DocFreq:  make(map[string]int),
+		TermFreq: make(map[string]int),
+	}
+}
+
+// DefaultCorpus returns the bundled background corpus of generic YouTube
+// terms.
+func DefaultCorpus() *Corpus {
+	c := NewCorpus()
+	if err := json.Unmarshal(bundledCorpus, c); err != nil {
+		panic(fmt.Sprintf("keywords: invalid bundled corpus: %v", err))
+	}
+	return c
+}
+
+// LoadCorpus reads a corpus from a JSON file. A missing file yields an
+// empty corpus so that a user corpus can be built up from scratch.
+func LoadCorpus(path string) (*Corpus, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return NewCorpus(), nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reading corpus: %w", err)
+	}
+
+	c := NewCorpus()
+	if err := json.Unmarshal(data, c); err != nil {
+		return nil, fmt.Errorf("parsing corpus %s: %w", path, err)
+	}
+	if c.DocFreq == nil {
+		c.DocFreq = make(map[string]int)
+	}
+	if c.TermFreq == nil {
+		c.TermFreq = make(map[string]int)
+	}
+	return c, nil
+}
+
+// Save writes the corpus to a JSON file.
+func (c *Corpus) Save(path string) error {
+	data, err := json.MarshalIndent(c, "", "  ")
+	if err != nil {
+		return fmt.Errorf("encoding corpus: %w", err)
+	}
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		return fmt.Errorf("writing corpus: %w", err)
+	}
+	return nil
+}
+
+// Add counts each text as one document, using the same tokenization and
+// stop-word filtering as keyword extraction, in each text's detected language.
+// ids, when given, are the video IDs parallel to texts: texts of videos the
+// corpus already holds are skipped, and the new IDs are recorded. Texts
+// without an ID are always counted.
+func (c *Corpus) Add(texts []string, ids []string) {
+	added := make(map[string]bool, len(c.VideoIDs))
+	for _, id := range c.VideoIDs {
+		added[id] = true
+	}
+
+	for i, t := range texts {
+		if i < len(ids) && ids[i] != "" {
+			if added[ids[i]] {
+				continue
+			}
+			added[ids[i]] = true
+			c.VideoIDs = append(c.VideoIDs, ids[i])
+		}
+
+		terms := extractTerms(t, detectLanguage(t))
+		if len(terms) == 0 {
+			continue
+		}
+
+		c.Documents++
+		seen := make(map[string]bool, len(terms))
+		for _, term := range terms {
+			c.TermFreq[term]++
+			c.TotalTerms++
+			if !seen[term] {
+				seen[term] = true
+				c.DocFreq[term]++
+			}
+		}
+	}
+}
+
+// docFreq returns the number of documents containing any of the given
+// surface forms, bounded by the corpus size.
+func (c *Corpus) docFreq(forms []string) int {
+	df := 0
+	for _, f := range forms {
+		df += c.DocFreq[f]
+	}
+	if df > c.Documents {
+		df = c.Documents
+	}
+	return df
+}
+
+// termFreq returns the total occurrences of the given surface forms.
+func (c *Corpus) termFreq(forms []string) int {
+	tf := 0
+	for _, f := range forms {
+		tf += c.TermFreq[f]
+	}
+	return tf
+}
+
+// Empty reports whether the corpus has no documents.
+func (c *Corpus) Empty() bool {
+	return c == nil || c.Documents == 0
+}
@@ -0,0 +1,113 @@
+package keywords
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestDefaultCorpus(t *testing.T) {
+	c := DefaultCorpus()
+	if c.Empty() {
+		t.Fatal("DefaultCorpus() is empty")
+	}
+	if c.DocFreq["video"] == 0 {
+		t.Error("bundled corpus should contain 'video'")
+	}
+	if c.DocFreq["sourdough"] != 0 {
+		t.Error("bundled corpus should not contain niche term 'sourdough'")
+	}
+	total := 0
+	for _, n := range c.TermFreq {
+		total += n
+	}
+	if c.TotalTerms != total {
+		t.Errorf("TotalTerms = %d, want the sum of term frequencies %d", c.TotalTerms, total)
+	}
+	for term, df := range c.DocFreq {
+		if df > c.Documents || df > c.TermFreq[term] {
+			t.Errorf("DocFreq[%s] = %d exceeds the document count or its term frequency", term, df)
+		}
+	}
+}
+
+func TestCorpus_Add(t *testing.T) {
+	c := NewCorpus()
+	c.Add([]string{"golang golang tips", "the", "golang basics"}, nil)
+
+	if c.Documents != 2 {
+		t.Errorf("Documents = %d, want 2 (stop-word-only text skipped)", c.Documents)
+	}
+	if c.DocFreq["golang"] != 2 {
+		t.Errorf("DocFreq[golang] = %d, want 2", c.DocFreq["golang"])
+	}
+	if c.TermFreq["golang"] != 3 {
+		t.Errorf("TermFreq[golang] = %d, want 3", c.TermFreq["golang"])
+	}
+	if c.TotalTerms != 5 {
+		t.Errorf("TotalTerms = %d, want 5", c.TotalTerms)
+	}
+}
+
+func TestCorpus_AddSkipsKnownVideos(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "corpus.json")
+	c := NewCorpus()
+	c.Add([]string{"golang tips", "golang basics"}, []string{"a", "b"})
+	if err := c.Save(path); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+
+	// A rerun fetches a and b again plus a new video c
+	rerun, err := LoadCorpus(path)
+	if err != nil {
+		t.Fatalf("LoadCorpus() error = %v", err)
+	}
+	rerun.Add([]string{"golang tips", "golang basics", "golang generics"}, []string{"a", "b", "c"})
+
+	if rerun.Documents != 3 {
+		t.Errorf("Documents = %d, want 3 (known videos skipped)", rerun.Documents)
+	}
+	if rerun.DocFreq["golang"] != 3 {
+		t.Errorf("DocFreq[golang] = %d, want 3", rerun.DocFreq["golang"])
+	}
+	if len(rerun.VideoIDs) != 3 {
+		t.Errorf("VideoIDs = %v, want a, b and c", rerun.VideoIDs)
+	}
+
+	// Texts without IDs are always counted
+	rerun.Add([]string{"golang tips"}, []string{""})
+	if rerun.Documents != 4 {
+		t.Errorf("Documents = %d, want 4 after a text without ID", rerun.Documents)
+	}
+}
+
+func TestCorpus_SaveLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "corpus.json")
+
+	c := NewCorpus()
+	c.Add([]string{"golang tips"}, nil)
+	if err := c.Save(path); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+
+	loaded, err := LoadCorpus(path)
+	if err != nil {
+		t.Fatalf("LoadCorpus() error = %v", err)
+	}
+	if loaded.Documents != 1 || loaded.DocFreq["tips"] != 1 {
+		t.Errorf("LoadCorpus() = %+v, want saved corpus", loaded)
+	}
+}
+
+func TestLoadCorpus_Missing(t *testing.T) {
+	c, err := LoadCorpus(filepath.Join(t.TempDir(), "missing.json"))
+	if err != nil {
+		t.Fatalf("LoadCorpus() error = %v", err)
+	}
+	if !c.Empty() {
+		t.Error("missing corpus should be empty")
+	}
+	c.Add([]string{"golang"}, nil)
+	if c.Documents != 1 {
+		t.Error("empty corpus should accept documents")
+	}
+}
@@ -0,0 +1,104 @@
+package keywords
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/mikelady/kingmaker/internal/text"
+)
+
+// Exclusion drops known terms, such as the search query or per-niche
+// boring words, from keyword results.
+type Exclusion struct {
+	words   map[string]bool // Excluded words, lowercased
+	stemsOf []string        // Words whose inflected forms are also excluded
+}
+
+// NewExclusion creates an Exclusion for the given words (matched
+// case-insensitively) and for every inflected form of stemsOf (e.g., the
+// query "coding tips" also excludes "code" and "tip").
+func NewExclusion(words, stemsOf []string) *Exclusion {
+	e := &Exclusion{words: make(map[string]bool, len(words)+len(stemsOf))}
+	for _, w := range words {
+		for _, tok := range text.Tokenize(w) {
+			e.words[tok] = true
+		}
+	}
+	for _, w := range stemsOf {
+		for _, tok := range text.Tokenize(w) {
+			e.words[tok] = true
+			e.stemsOf = append(e.stemsOf, tok)
+		}
+	}
+	return e
+}
+
+// Excludes reports whether word in lang is excluded.
+func (e *Exclusion) Excludes(word, lang string) bool {
+	if e == nil {
+		return false
+	}
+	if e.words[word] {
+		return true
+	}
+	if len(e.stemsOf) == 0 {
+		return false
+	}
+	key := stemKey(lang, word)
+	for _, s := range e.stemsOf {
+		if stemKey(lang, s) == key {
+			return true
+		}
+	}
+	return false
+}
+
+// Empty reports whether the exclusion drops nothing.
+func (e *Exclusion) Empty() bool {
+	return e == nil || len(e.words) == 0
+}
+
+// exclusion builds the Exclusion described by the options, or nil.
+func (o Options) exclusion() *Exclusion {
+	if len(o.Exclude) == 0 && len(o.ExcludeStemsOf) == 0 {
+		return nil
+	}
+	return NewExclusion(o.Exclude, o.ExcludeStemsOf)
+}
+
+// LoadWordList reads a word list file with one word per line. Blank lines
+// and lines starting with # are ignored.
+func LoadWordList(path string) ([]string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading word list: %w", err)
+	}
+	defer f.Close()
+
+	var words []string
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		words = append(words, line)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("reading word list %s: %w", path, err)
+	}
+	return words, nil
+}
+
+// SplitWordList splits a comma-separated word list as given on the command line.
+func SplitWordList(list string) []string {
+	var words []string
+	for _, w := range strings.Split(list, ",") {
+		if w = strings.TrimSpace(w); w != "" {
+			words = append(words, w)
+		}
+	}
+	return words
+}
@@ -0,0 +1,100 @@
+package keywords
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestExclusion_Excludes(t *testing.T) {
+	e := NewExclusion([]string{"Tutorial"}, []string{"Coding Tips"})
+
+	tests := map[string]bool{
+		"tutorial": true,
+		"coding":   true,
+		"code":     true, // inflection of a query token
+		"tip":      true,
+		"python":   false,
+	}
+	for word, want := range tests {
+		if got := e.Excludes(word, "en"); got != want {
+			t.Errorf("Excludes(%q) = %v, want %v", word, got, want)
+		}
+	}
+
+	var nilExclusion *Exclusion
+	if nilExclusion.Excludes("coding", "en") || !nilExclusion.Empty() {
+		t.Error("nil Exclusion should exclude nothing")
+	}
+}
+
+func TestExtractKeywordsWithOptions_ExcludesQueryAndStopWords(t *testing.T) {
+	texts := []string{
+		"vibe coding with cursor",
+		"vibe code tutorial cursor",
+		"vibe coded app in cursor tutorial",
+	}
+
+	keywords := ExtractKeywordsWithOptions(texts, Options{
+		TopN:           10,
+		Exclude:        []string{"tutorial"},
+		ExcludeStemsOf: []string{"vibe coding"},
+	})
+	if len(keywords) == 0 || keywords[0].Word != "cursor" {
+		t.Fatalf("top keyword = %v, want cursor", keywords)
+	}
+	for _, kw := range keywords {
+		switch kw.Word {
+		case "vibe", "coding", "code", "coded", "tutorial":
+			t.Errorf("excluded word %q returned", kw.Word)
+		}
+	}
+}
+
+func TestExtractKeyphrasesWithOptions_ExcludesQueryPhrase(t *testing.T) {
+	texts := []string{
+		"vibe coding tips. cursor rules",
+		"vibe coding tricks. cursor rules",
+	}
+
+	phrases := ExtractKeyphrasesWithOptions(texts, Options{TopN: 10, ExcludeStemsOf: []string{"vibe coding"}})
+	for _, p := range phrases {
+		if p.Phrase == "vibe coding" {
+			t.Error("query phrase should be excluded")
+		}
+	}
+	if len(phrases) == 0 || phrases[0].Phrase != "cursor rules" {
+		t.Errorf("phrases = %v, want cursor rules", phrases)
+	}
+}
+
+func TestLoadWordList(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "stopwords.txt")
+	data := "# boring words\nvideo\n\n  shorts  \n"
+	if err := os.WriteFile(path, []byte(data), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := LoadWordList(path)
+	if err != nil {
+		t.Fatalf("LoadWordList() error = %v", err)
+	}
+	if want := []string{"video", "shorts"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("LoadWordList() = %v, want %v", got, want)
+	}
+
+	if _, err := LoadWordList(filepath.Join(t.TempDir(), "missing.txt")); err == nil {
+		t.Error("LoadWordList() expected error for missing file")
+	}
+}
+
+func TestSplitWordList(t *testing.T) {
+	got := SplitWordList(" video, shorts,,viral ")
+	if want := []string{"video", "shorts", "viral"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("SplitWordList() = %v, want %v", got, want)
+	}
+	if SplitWordList("") != nil {
+		t.Error("SplitWordList(\"\") should be nil")
+	}
+}
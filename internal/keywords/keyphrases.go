@@ -0,0 +1,175 @@
+package keywords
+
+import (
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/mikelady/kingmaker/internal/text"
+)
+
+// Keyphrase represents an extracted multi-word phrase.
+type Keyphrase struct {
+	Phrase    string   // Space-joined phrase (e.g., "vibe coding")
+	Words     []string // Words in the phrase
+	Frequency int      // Occurrences across all texts
+	Score     float64  // Frequency-weighted normalized pointwise mutual information
+}
+
+// minKeyphraseFrequency is the minimum number of occurrences for a phrase to
+// be considered; PMI is unreliable for phrases seen only once.
+const minKeyphraseFrequency = 2
+
+// ExtractKeyphrases extracts the top N bigram and trigram keyphrases from a
+// collection of texts. Candidates never span stop words or punctuation and
+// are scored by how much more often their words occur together than
+// independently (PMI), weighted by frequency. PMI is normalized by the
+// phrase's self-information so bigrams and trigrams rank on the same scale.
+func ExtractKeyphrases(texts []string, topN int) []Keyphrase {
+	return ExtractKeyphrasesWithOptions(texts, Options{TopN: topN})
+}
+
+// ExtractKeyphrasesWithOptions extracts keyphrases using the stop words of
+// each text's language. Phrases made up entirely of excluded words (such as
+// the query itself) are dropped. Scoring, Background and Stem are ignored.
+func ExtractKeyphrasesWithOptions(texts []string, opts Options) []Keyphrase {
+	topN := opts.TopN
+	if len(texts) == 0 || topN <= 0 {
+		return []Keyphrase{}
+	}
+
+	exclude := opts.exclusion()
+	wordCounts := make(map[string]int)
+	phraseCounts := make(map[string]int)
+	phraseLangs := make(map[string]string)
+	totalWords := 0
+	totalPhrases := map[int]int{2: 0, 3: 0}
+
+	for i, t := range texts {
+		lang := opts.textLanguage(i, t)
+		for _, run := range text.PhraseCandidates(t, lang) {
+			for _, word := range run {
+				wordCounts[word]++
+				totalWords++
+			}
+			for n := 2; n <= 3; n++ {
+				for _, phrase := range text.NGrams(run, n) {
+					phraseCounts[phrase]++
+					if _, ok := phraseLangs[phrase]; !ok {
+						phraseLangs[phrase] = lang
+					}
+					totalPhrases[n]++
+				}
+			}
+		}
+	}
+
+	phrases := make([]Keyphrase, 0)
+	for phrase, count := range phraseCounts {
+		if count < minKeyphraseFrequency {
+			continue
+		}
+		words := strings.Fields(phrase)
+		if repeatsWord(words) || allExcluded(exclude, words, phraseLangs[phrase]) {
+			continue
+		}
+
+		// PMI = log P(phrase) - sum(log P(word)). A trigram subtracts one
+		// more word's log-probability than a bigram, so PMI is normalized by
+		// -log P(phrase) to compare phrases of different lengths.
+		logP := math.Log(float64(count) / float64(totalPhrases[len(words)]))
+		pmi := logP
+		for _, w := range words {
+			pmi -= math.Log(float64(wordCounts[w]) / float64(totalWords))
+		}
+		if pmi <= 0 {
+			continue
+		}
+		npmi := 1.0
+		if logP < 0 {
+			npmi = min(pmi/-logP, 1)
+		}
+
+		phrases = append(phrases, Keyphrase{
+			Phrase:    phrase,
+			Words:     words,
+			Frequency: count,
+			Score:     npmi * float64(count),
+		})
+	}
+
+	phrases = dropSubsumedPhrases(phrases)
+
+	sort.Slice(phrases, func(i, j int) bool {
+		if phrases[i].Score != phrases[j].Score {
+			return phrases[i].Score > phrases[j].Score
+		}
+		if phrases[i].Frequency != phrases[j].Frequency {
+			return phrases[i].Frequency > phrases[j].Frequency
+		}
+		return phrases[i].Phrase < phrases[j].Phrase
+	})
+
+	if len(phrases) > topN {
+		phrases = phrases[:topN]
+	}
+
+	return phrases
+}
+
+// repeatsWord reports whether a phrase uses the same word more than once
+// (e.g., "video video").
+func repeatsWord(words []string) bool {
+	seen := make(map[string]bool, len(words))
+	for _, w := range words {
+		if seen[w] {
+			return true
+		}
+		seen[w] = true
+	}
+	return false
+}
+
+// allExcluded reports whether every word of a phrase is excluded.
+func allExcluded(e *Exclusion, words []string, lang string) bool {
+	if e.Empty() {
+		return false
+	}
+	for _, w := range words {
+		if !e.Excludes(w, lang) {
+			return false
+		}
+	}
+	return true
+}
+
+// dropSubsumedPhrases removes bigrams that only ever occur inside a longer
+// phrase, so "vibe coding tutorial" is not also reported as "vibe coding".
+func dropSubsumedPhrases(phrases []Keyphrase) []Keyphrase {
+	result := phrases[:0:0]
+	for _, p := range phrases {
+		subsumed := false
+		for _, longer := range phrases {
+			if len(longer.Words) > len(p.Words) && longer.Frequency == p.Frequency &&
+				strings.Contains(" "+longer.Phrase+" ", " "+p.Phrase+" ") {
+				subsumed = true
+				break
+			}
+		}
+		if !subsumed {
+			result = append(result, p)
+		}
+	}
+	return result
+}
+
+// PhraseWords returns the set of words covered by the given keyphrases.
+func PhraseWords(phrases []Keyphrase) map[string]bool {
+	words := make(map[string]bool)
+	for _, p := range phrases {
+		for _, w := range p.Words {
+			words[w] = true
+		}
+	}
+	return words
+}
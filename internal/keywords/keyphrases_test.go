@@ -0,0 +1,96 @@
+package keywords
+
+import (
+	"testing"
+)
+
+func TestExtractKeyphrases_EmptyInput(t *testing.T) {
+	if got := ExtractKeyphrases(nil, 10); len(got) != 0 {
+		t.Errorf("ExtractKeyphrases(nil) = %v, want none", got)
+	}
+	if got := ExtractKeyphrases([]string{"vibe coding", "vibe coding"}, 0); len(got) != 0 {
+		t.Errorf("ExtractKeyphrases(topN 0) = %v, want none", got)
+	}
+}
+
+func TestExtractKeyphrases_FindsCollocations(t *testing.T) {
+	texts := []string{
+		"Vibe coding with Claude",
+		"I tried vibe coding for a week",
+		"Vibe coding tips for beginners",
+		"Coding interview tips",
+	}
+
+	phrases := ExtractKeyphrases(texts, 10)
+	if len(phrases) == 0 {
+		t.Fatal("ExtractKeyphrases() returned no phrases")
+	}
+	if phrases[0].Phrase != "vibe coding" {
+		t.Errorf("top phrase = %q, want %q", phrases[0].Phrase, "vibe coding")
+	}
+	if phrases[0].Frequency != 3 {
+		t.Errorf("Frequency = %d, want 3", phrases[0].Frequency)
+	}
+	if len(phrases[0].Words) != 2 || phrases[0].Words[0] != "vibe" {
+		t.Errorf("Words = %v, want [vibe coding]", phrases[0].Words)
+	}
+}
+
+func TestExtractKeyphrases_NoStopWordsOrPunctuation(t *testing.T) {
+	texts := []string{
+		"coding for beginners. Python rocks",
+		"coding for beginners. Python rocks",
+	}
+
+	for _, p := range ExtractKeyphrases(texts, 10) {
+		if p.Phrase == "coding for beginners" || p.Phrase == "beginners python" {
+			t.Errorf("phrase %q spans a stop word or punctuation", p.Phrase)
+		}
+	}
+}
+
+func TestExtractKeyphrases_MinFrequency(t *testing.T) {
+	phrases := ExtractKeyphrases([]string{"unique phrase here"}, 10)
+	if len(phrases) != 0 {
+		t.Errorf("phrases seen once should be dropped, got %v", phrases)
+	}
+}
+
+func TestExtractKeyphrases_DropsSubsumedBigrams(t *testing.T) {
+	texts := []string{
+		"claude code tutorial",
+		"claude code tutorial",
+	}
+
+	phrases := ExtractKeyphrases(texts, 10)
+	if len(phrases) != 1 || phrases[0].Phrase != "claude code tutorial" {
+		t.Errorf("phrases = %v, want only the trigram", phrases)
+	}
+}
+
+func TestExtractKeyphrases_TrigramsShareBigramScale(t *testing.T) {
+	texts := []string{
+		"rust borrow checker explained",
+		"rust borrow checker fights",
+		"vibe coding session",
+		"vibe coding live",
+		"vibe coding again",
+	}
+
+	phrases := ExtractKeyphrases(texts, 10)
+	if len(phrases) < 2 || phrases[0].Phrase != "vibe coding" {
+		t.Fatalf("phrases = %v, want the more frequent bigram first", phrases)
+	}
+	for _, p := range phrases {
+		if p.Score > float64(p.Frequency) {
+			t.Errorf("%q: Score %v exceeds Frequency %d", p.Phrase, p.Score, p.Frequency)
+		}
+	}
+}
+
+func TestPhraseWords(t *testing.T) {
+	words := PhraseWords([]Keyphrase{{Words: []string{"vibe", "coding"}}})
+	if !words["vibe"] || !words["coding"] || len(words) != 2 {
+		t.Errorf("PhraseWords() = %v", words)
+	}
+}
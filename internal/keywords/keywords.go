@@ -1,9 +1,8 @@
-// Package keywords provides keyword extraction with term frequency scoring.
+// Package keywords provides keyword extraction with term frequency, TF-IDF
+// and log-odds scoring.
 package keywords
 
 import (
-	"sort"
-
 	"github.com/mikelady/kingmaker/internal/text"
 )
 
@@ -12,63 +11,84 @@ type Keyword struct {
 	Word      string
 	Frequency int
 	Score     float64
+	Variants  []string // Surface forms merged into this keyword, most common first (nil when not stemmed)
 }
 
 // ExtractKeywords extracts the top N keywords from a collection of texts.
 // Keywords are ranked by term frequency with stop words removed.
 // Returns keywords sorted by frequency (highest first).
 func ExtractKeywords(texts []string, topN int) []Keyword {
-	if len(texts) == 0 || topN <= 0 {
-		return []Keyword{}
-	}
+	return ExtractKeywordsWithOptions(texts, Options{TopN: topN, Scoring: ScoringFrequency})
+}
 
-	// Count word frequencies across all texts
+// countTerms counts keyword terms across all texts and returns the counts,
+// the language each term was first seen in, and the total number of terms.
+func countTerms(texts []string, opts Options) (map[string]int, map[string]string, int) {
 	wordCounts := make(map[string]int)
+	wordLangs := make(map[string]string)
 	totalWords := 0
+	exclude := opts.exclusion()
+	excluded := make(map[string]bool)
 
-	for _, t := range texts {
-		tokens := text.Tokenize(t)
-		filtered := text.RemoveStopWords(tokens)
-
-		for _, word := range filtered {
-			// Skip very short words (likely noise)
-			if len(word) < 2 {
+	for i, t := range texts {
+		lang := opts.textLanguage(i, t)
+		for _, word := range extractTerms(t, lang) {
+			key := lang + ":" + word
+			skip, seen := excluded[key]
+			if !seen {
+				skip = exclude.Excludes(word, lang)
+				excluded[key] = skip
+			}
+			if skip {
 				continue
 			}
 			wordCounts[word]++
+			if _, ok := wordLangs[word]; !ok {
+				wordLangs[word] = lang
+			}
 			totalWords++
 		}
 	}
 
-	if totalWords == 0 {
-		return []Keyword{}
-	}
+	return wordCounts, wordLangs, totalWords
+}
 
-	// Convert to slice for sorting
-	keywords := make([]Keyword, 0, len(wordCounts))
-	for word, count := range wordCounts {
-		// Calculate TF score (term frequency)
-		score := float64(count) / float64(totalWords)
-		keywords = append(keywords, Keyword{
-			Word:      word,
-			Frequency: count,
-			Score:     score,
-		})
-	}
+// extractTerms tokenizes a text into keyword terms with the stop words of
+// lang removed.
+func extractTerms(t, lang string) []string {
+	tokens := text.Tokenize(t)
+	filtered := text.RemoveStopWordsLang(tokens, lang)
 
-	// Sort by frequency descending
-	sort.Slice(keywords, func(i, j int) bool {
-		if keywords[i].Frequency != keywords[j].Frequency {
-			return keywords[i].Frequency > keywords[j].Frequency
+	terms := filtered[:0]
+	for _, word := range filtered {
+		// Skip very short words (likely noise)
+		if text.IsNoiseToken(word) {
+			continue
 		}
-		// Tie-breaker: alphabetical order
-		return keywords[i].Word < keywords[j].Word
-	})
+		terms = append(terms, word)
+	}
+	return terms
+}
 
-	// Return top N
-	if len(keywords) > topN {
-		keywords = keywords[:topN]
+// textLanguage resolves the language of the i-th text: the forced
+// Options.Language, else its entry in Options.Languages, else the detected
+// language, falling back to English.
+func (o Options) textLanguage(i int, t string) string {
+	if lang := text.NormalizeLanguage(o.Language); lang != "" {
+		return lang
 	}
+	if i < len(o.Languages) {
+		if lang := text.NormalizeLanguage(o.Languages[i]); lang != "" {
+			return lang
+		}
+	}
+	return detectLanguage(t)
+}
 
-	return keywords
+// detectLanguage detects the language of a text, falling back to English.
+func detectLanguage(t string) string {
+	if lang := text.DetectLanguage(t); lang != "" {
+		return lang
+	}
+	return "en"
 }
@@ -138,6 +138,15 @@ func TestExtractKeywords_StopWordsRemoved(t *testing.T) {
 	}
 }
 
+func TestExtractKeywords_EnglishAITitles(t *testing.T) {
+	texts := []string{"AI vs AI: the best AI", "The secret AI tools", "Top AI tools for the win"}
+	for _, kw := range ExtractKeywords(texts, 10) {
+		if kw.Word == "the" || kw.Word == "for" {
+			t.Errorf("English stop word %q returned; titles mentioning AI were not treated as English", kw.Word)
+		}
+	}
+}
+
 func TestExtractKeywords_CaseInsensitive(t *testing.T) {
 	texts := []string{
 		"Golang GOLANG golang GoLang",
@@ -272,3 +281,26 @@ func TestKeyword_Fields(t *testing.T) {
 		t.Errorf("Score = %f, want 0.25", kw.Score)
 	}
 }
+
+func TestExtractKeywordsWithOptions_Languages(t *testing.T) {
+	// "de" and "para" are Spanish stop words but not English ones
+	texts := []string{"receta de pan para casa", "receta de pan para casa"}
+
+	keywords := ExtractKeywordsWithOptions(texts, Options{TopN: 10, Languages: []string{"es-419", ""}})
+	for _, kw := range keywords {
+		if kw.Word == "de" || kw.Word == "para" {
+			t.Errorf("Spanish stop word %q should be filtered", kw.Word)
+		}
+	}
+
+	keywords = ExtractKeywordsWithOptions(texts, Options{TopN: 10, Language: "en"})
+	found := false
+	for _, kw := range keywords {
+		if kw.Word == "para" {
+			found = true
+		}
+	}
+	if !found {
+		t.Error("forcing English should keep Spanish stop words")
+	}
+}
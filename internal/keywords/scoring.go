@@ -0,0 +1,140 @@
+package keywords
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+)
+
+// Scoring selects how keywords are scored and ranked.
+type Scoring string
+
+const (
+	// ScoringFrequency ranks by raw term frequency.
+	ScoringFrequency Scoring = "tf"
+	// ScoringTFIDF weights term frequency by inverse document frequency in
+	// the background corpus, so generic words rank below niche terms.
+	ScoringTFIDF Scoring = "tfidf"
+	// ScoringLogOdds ranks by the z-scored log-odds ratio of a term in the
+	// texts versus the background corpus, with an informative Dirichlet prior.
+	ScoringLogOdds Scoring = "logodds"
+)
+
+// logOddsPriorScale is the total pseudo-count of the Dirichlet prior.
+const logOddsPriorScale = 100.0
+
+// Options configures keyword extraction.
+type Options struct {
+	TopN       int      // Number of keywords to return
+	Scoring    Scoring  // Scoring method (default ScoringFrequency)
+	Background *Corpus  // Background corpus for TF-IDF and log-odds (default DefaultCorpus)
+	Stem       bool     // Merge inflected forms (code, coding, codes) into one keyword
+	Language   string   // Language code for all texts, overriding Languages and detection (e.g., "es")
+	Languages  []string // Per-text language codes parallel to texts; empty entries are detected
+
+	Exclude        []string // Extra stop words to drop (e.g., per-niche boring words)
+	ExcludeStemsOf []string // Words dropped together with their inflected forms (e.g., query tokens)
+}
+
+// ParseScoring parses a scoring method name as given on the command line.
+func ParseScoring(name string) (Scoring, error) {
+	switch s := Scoring(strings.ToLower(strings.TrimSpace(name))); s {
+	case ScoringFrequency, ScoringTFIDF, ScoringLogOdds:
+		return s, nil
+	case "":
+		return ScoringFrequency, nil
+	default:
+		return "", fmt.Errorf("unknown keyword scoring %q (available: %s, %s, %s)",
+			name, ScoringFrequency, ScoringTFIDF, ScoringLogOdds)
+	}
+}
+
+// ExtractKeywordsWithOptions extracts the top N keywords from a collection of
+// texts, scored with the selected method. Keywords are sorted by score
+// (highest first), then frequency, then alphabetically.
+func ExtractKeywordsWithOptions(texts []string, opts Options) []Keyword {
+	if len(texts) == 0 || opts.TopN <= 0 {
+		return []Keyword{}
+	}
+
+	wordCounts, wordLangs, totalWords := countTerms(texts, opts)
+	if totalWords == 0 {
+		return []Keyword{}
+	}
+
+	background := opts.Background
+	if opts.Scoring != ScoringFrequency && background.Empty() {
+		background = DefaultCorpus()
+	}
+
+	terms := ungroupedTerms(wordCounts)
+	if opts.Stem {
+		terms = groupTerms(wordCounts, wordLangs)
+	}
+
+	keywords := make([]Keyword, 0, len(terms))
+	for _, term := range terms {
+		var score float64
+		switch opts.Scoring {
+		case ScoringTFIDF:
+			score = tfidf(term.count, totalWords, background.docFreq(term.forms), background.Documents)
+		case ScoringLogOdds:
+			score = logOdds(term.count, totalWords, background.termFreq(term.forms), background, len(terms))
+		default:
+			score = float64(term.count) / float64(totalWords)
+		}
+		keywords = append(keywords, Keyword{
+			Word:      term.word,
+			Frequency: term.count,
+			Score:     score,
+			Variants:  term.variants(),
+		})
+	}
+
+	sort.Slice(keywords, func(i, j int) bool {
+		if keywords[i].Score != keywords[j].Score {
+			return keywords[i].Score > keywords[j].Score
+		}
+		if keywords[i].Frequency != keywords[j].Frequency {
+			return keywords[i].Frequency > keywords[j].Frequency
+		}
+		return keywords[i].Word < keywords[j].Word
+	})
+
+	if len(keywords) > opts.TopN {
+		keywords = keywords[:opts.TopN]
+	}
+
+	return keywords
+}
+
+// tfidf returns the term frequency weighted by the smoothed inverse document
+// frequency of a term appearing in df of the background's docs documents.
+func tfidf(count, total, df, docs int) float64 {
+	tf := float64(count) / float64(total)
+	idf := math.Log(float64(docs+1)/float64(df+1)) + 1
+	return tf * idf
+}
+
+// logOdds returns the z-score of the log-odds ratio of a term between the
+// texts and the background corpus, where it occurs bgFreq times (Monroe et
+// al., "Fightin' Words"). The prior is proportional to the smoothed
+// background frequency of the term.
+// Background counts are add-one smoothed so that terms absent from the
+// background get a finite variance instead of being swamped by the prior.
+func logOdds(count, total, bgFreq int, bg *Corpus, vocab int) float64 {
+	bgCount := float64(bgFreq) + 1
+	bgTotal := float64(bg.TotalTerms + len(bg.TermFreq) + vocab)
+
+	alpha := logOddsPriorScale * bgCount / bgTotal
+	alpha0 := logOddsPriorScale
+
+	y := float64(count)
+	n := float64(total)
+	delta := math.Log((y+alpha)/(n+alpha0-y-alpha)) -
+		math.Log((bgCount+alpha)/(bgTotal+alpha0-bgCount-alpha))
+	variance := 1/(y+alpha) + 1/(bgCount+alpha)
+
+	return delta / math.Sqrt(variance)
+}
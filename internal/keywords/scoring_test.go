@@ -0,0 +1,89 @@
+package keywords
+
+import (
+	"testing"
+)
+
+func TestParseScoring(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    Scoring
+		wantErr bool
+	}{
+		{"tf", ScoringFrequency, false},
+		{"", ScoringFrequency, false},
+		{"TFIDF", ScoringTFIDF, false},
+		{" logodds ", ScoringLogOdds, false},
+		{"bm25", "", true},
+	}
+
+	for _, tt := range tests {
+		got, err := ParseScoring(tt.input)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ParseScoring(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("ParseScoring(%q) = %q, want %q", tt.input, got, tt.want)
+		}
+	}
+}
+
+// nicheTexts mentions the generic word "video" more often than the niche
+// term "sourdough".
+var nicheTexts = []string{
+	"sourdough video new video shorts",
+	"sourdough starter video tips video",
+	"best video for sourdough bread video",
+}
+
+func TestExtractKeywordsWithOptions_FrequencyFavorsGenericWords(t *testing.T) {
+	keywords := ExtractKeywordsWithOptions(nicheTexts, Options{TopN: 1, Scoring: ScoringFrequency})
+	if len(keywords) != 1 || keywords[0].Word != "video" {
+		t.Errorf("top keyword = %v, want video", keywords)
+	}
+}
+
+func TestExtractKeywordsWithOptions_DistinctiveTermsFirst(t *testing.T) {
+	for _, scoring := range []Scoring{ScoringTFIDF, ScoringLogOdds} {
+		keywords := ExtractKeywordsWithOptions(nicheTexts, Options{TopN: 10, Scoring: scoring})
+		if len(keywords) == 0 {
+			t.Fatalf("%s: no keywords", scoring)
+		}
+		if keywords[0].Word != "sourdough" {
+			t.Errorf("%s: top keyword = %q, want sourdough", scoring, keywords[0].Word)
+		}
+	}
+}
+
+func TestExtractKeywordsWithOptions_CustomBackground(t *testing.T) {
+	bg := NewCorpus()
+	bg.Add([]string{"sourdough bread", "sourdough starter", "sourdough tips"}, nil)
+
+	keywords := ExtractKeywordsWithOptions(nicheTexts, Options{TopN: 10, Scoring: ScoringTFIDF, Background: bg})
+	if len(keywords) == 0 {
+		t.Fatal("no keywords")
+	}
+	if keywords[0].Word == "sourdough" {
+		t.Error("sourdough is common in the background and should not rank first")
+	}
+}
+
+func TestExtractKeywordsWithOptions_TopN(t *testing.T) {
+	keywords := ExtractKeywordsWithOptions(nicheTexts, Options{TopN: 2, Scoring: ScoringLogOdds})
+	if len(keywords) != 2 {
+		t.Errorf("len = %d, want 2", len(keywords))
+	}
+	if len(ExtractKeywordsWithOptions(nicheTexts, Options{})) != 0 {
+		t.Error("TopN 0 should return no keywords")
+	}
+}
+
+func TestExtractKeywordsWithOptions_SortedByScore(t *testing.T) {
+	keywords := ExtractKeywordsWithOptions(nicheTexts, Options{TopN: 20, Scoring: ScoringTFIDF})
+	for i := 1; i < len(keywords); i++ {
+		if keywords[i].Score > keywords[i-1].Score {
+			t.Errorf("keywords not sorted by score at %d: %v > %v", i, keywords[i].Score, keywords[i-1].Score)
+		}
+	}
+}
@@ -0,0 +1,103 @@
+package keywords
+
+import (
+	"sort"
+
+	"github.com/mikelady/kingmaker/internal/text"
+)
+
+// term is a keyword candidate: a single word, or a group of inflected
+// surface forms sharing a stem.
+type term struct {
+	word   string   // Reported surface form (the most common one)
+	count  int      // Combined occurrences of all forms
+	forms  []string // Surface forms, most common first
+	counts []int    // Occurrences of each form
+}
+
+// variants returns the surface forms of a merged term, or nil when the
+// term has a single form.
+func (t term) variants() []string {
+	if len(t.forms) < 2 {
+		return nil
+	}
+	return t.forms
+}
+
+// ungroupedTerms returns one term per distinct word.
+func ungroupedTerms(wordCounts map[string]int) []term {
+	terms := make([]term, 0, len(wordCounts))
+	for word, count := range wordCounts {
+		terms = append(terms, term{word: word, count: count, forms: []string{word}, counts: []int{count}})
+	}
+	return terms
+}
+
+// groupTerms merges words sharing a lemmatized stem in the language each
+// word was seen in. Each group is reported under its most common surface
+// form; ties go to the shorter, then alphabetically first, form. Words in
+// languages without a registered stemmer are left ungrouped.
+func groupTerms(wordCounts map[string]int, wordLangs map[string]string) []term {
+	groups := make(map[string]*term)
+	for word, count := range wordCounts {
+		key := stemKey(wordLangs[word], word)
+		g, ok := groups[key]
+		if !ok {
+			g = &term{}
+			groups[key] = g
+		}
+		g.forms = append(g.forms, word)
+		g.counts = append(g.counts, count)
+		g.count += count
+	}
+
+	terms := make([]term, 0, len(groups))
+	for _, g := range groups {
+		sortForms(g)
+		g.word = g.forms[0]
+		terms = append(terms, *g)
+	}
+	return terms
+}
+
+// stemKey returns the grouping key for a word in lang. The English
+// lemmatizer maps irregular forms (built, children) before stemming.
+func stemKey(lang, word string) string {
+	stemmer, ok := text.StemmerFor(lang)
+	if !ok {
+		return lang + ":" + word
+	}
+	if lang == "en" {
+		word = text.Lemmatize(word)
+	}
+	return lang + ":" + stemmer.Stem(word)
+}
+
+// sortForms orders a group's forms by count descending, then length, then
+// alphabetically.
+func sortForms(g *term) {
+	idx := make([]int, len(g.forms))
+	for i := range idx {
+		idx[i] = i
+	}
+	sort.Slice(idx, func(a, b int) bool {
+		fa, fb := g.forms[idx[a]], g.forms[idx[b]]
+		ca, cb := g.counts[idx[a]], g.counts[idx[b]]
+		if ca != cb {
+			return ca > cb
+		}
+		if len(fa) != len(fb) {
+			return len(fa) < len(fb)
+		}
+		return fa < fb
+	})
+
+	forms := make([]string, len(idx))
+	counts := make([]int, len(idx))
+	for i, j := range idx {
+		forms[i] = g.forms[j]
+		counts[i] = g.counts[j]
+	}
+	g.forms = forms
+	g.counts = counts
+}
@@ -0,0 +1,63 @@
+package keywords
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestExtractKeywordsWithOptions_StemMergesVariants(t *testing.T) {
+	texts := []string{
+		"coding coding coding",
+		"code codes",
+		"coded python",
+	}
+
+	keywords := ExtractKeywordsWithOptions(texts, Options{TopN: 10, Stem: true})
+	if len(keywords) != 2 {
+		t.Fatalf("len = %d, want 2 (code group and python): %v", len(keywords), keywords)
+	}
+
+	top := keywords[0]
+	if top.Word != "coding" {
+		t.Errorf("Word = %q, want most common surface form %q", top.Word, "coding")
+	}
+	if top.Frequency != 6 {
+		t.Errorf("Frequency = %d, want 6", top.Frequency)
+	}
+	wantVariants := []string{"coding", "code", "coded", "codes"}
+	if !reflect.DeepEqual(top.Variants, wantVariants) {
+		t.Errorf("Variants = %v, want %v", top.Variants, wantVariants)
+	}
+	if keywords[1].Variants != nil {
+		t.Errorf("single-form keyword should have no variants, got %v", keywords[1].Variants)
+	}
+}
+
+func TestExtractKeywordsWithOptions_StemLemmatizesIrregulars(t *testing.T) {
+	keywords := ExtractKeywordsWithOptions([]string{"build built building"}, Options{TopN: 10, Stem: true})
+	if len(keywords) != 1 || keywords[0].Frequency != 3 {
+		t.Errorf("expected build/built/building merged, got %v", keywords)
+	}
+}
+
+func TestExtractKeywordsWithOptions_StemDisabled(t *testing.T) {
+	keywords := ExtractKeywordsWithOptions([]string{"code coding codes"}, Options{TopN: 10})
+	if len(keywords) != 3 {
+		t.Errorf("without stemming forms should stay separate, got %v", keywords)
+	}
+}
+
+func TestExtractKeywordsWithOptions_StemUnknownLanguage(t *testing.T) {
+	keywords := ExtractKeywordsWithOptions([]string{"code coding codes"}, Options{TopN: 10, Stem: true, Language: "zz"})
+	if len(keywords) != 3 {
+		t.Errorf("unknown language should leave forms ungrouped, got %v", keywords)
+	}
+}
+
+func TestExtractKeywordsWithOptions_StemWithTFIDF(t *testing.T) {
+	texts := []string{"videos video sourdough"}
+	keywords := ExtractKeywordsWithOptions(texts, Options{TopN: 10, Stem: true, Scoring: ScoringTFIDF})
+	if len(keywords) != 2 || keywords[0].Word != "sourdough" {
+		t.Errorf("expected sourdough above merged video group, got %v", keywords)
+	}
+}
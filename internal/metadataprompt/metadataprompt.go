@@ -1,4 +1,5 @@
-// Package metadataprompt generates OpusClip "create-default" prompts for viral video metadata.
+// Package metadataprompt generates metadata prompts, such as OpusClip's
+// "create-default" prompt, for viral video titles and descriptions.
 // Unlike the prompt package (which creates prompts for finding clips), this package
 // creates prompts that help generate viral-worthy titles and descriptions.
 package metadataprompt
@@ -9,6 +10,9 @@ import (
 	"strings"
 
 	"github.com/mikelady/kingmaker/internal/analyzer"
+	"github.com/mikelady/kingmaker/internal/hooks"
+	"github.com/mikelady/kingmaker/internal/target"
+	"github.com/mikelady/kingmaker/internal/text"
 )
 
 // OpenAIClient defines the interface for LLM completion.
@@ -24,8 +28,9 @@ type MetadataPromptGenerator interface {
 
 // Options configures the prompt generation.
 type Options struct {
-	Niche     string // Content niche (e.g., "AI vibe coding")
-	MaxLength int    // Maximum prompt length (0 = no limit)
+	Niche     string        // Content niche (e.g., "AI vibe coding")
+	MaxLength int           // Maximum prompt length (0 = the target's limit, if any)
+	Target    target.Target // Clipping tool the prompt is for (default OpusClip)
 }
 
 // Generator creates metadata prompts using LLM.
@@ -38,8 +43,14 @@ func NewGenerator(client OpenAIClient) *Generator {
 	return &Generator{client: client}
 }
 
-// Generate creates an OpusClip create-default prompt based on analyzed patterns.
+// Generate creates a metadata prompt for the target tool (by default an
+// OpusClip create-default prompt) based on analyzed patterns.
 func (g *Generator) Generate(ctx context.Context, patterns analyzer.Patterns, opts Options) (string, error) {
+	opts.Target = opts.Target.OrDefault()
+	if opts.MaxLength <= 0 {
+		opts.MaxLength = opts.Target.MaxMetaChars
+	}
+
 	// Build the system prompt for the LLM
 	systemPrompt := buildSystemPrompt(patterns, opts)
 
@@ -50,14 +61,9 @@ func (g *Generator) Generate(ctx context.Context, patterns analyzer.Patterns, op
 	}
 
 	// Trim and validate result
-	result = strings.TrimSpace(result)
-	if opts.MaxLength > 0 && len(result) > opts.MaxLength {
-		// Find the last space before the limit to avoid cutting mid-word
-		truncated := result[:opts.MaxLength]
-		if lastSpace := strings.LastIndex(truncated, " "); lastSpace > opts.MaxLength/2 {
-			truncated = truncated[:lastSpace]
-		}
-		result = strings.TrimSpace(truncated) + "..."
+	result = opts.Target.Clean(result)
+	if opts.MaxLength > 0 {
+		result = text.TruncateGraphemes(result, opts.MaxLength)
 	}
 
 	return result, nil
@@ -69,7 +75,7 @@ func buildSystemPrompt(patterns analyzer.Patterns, opts Options) string {
 
 	sb.WriteString("You are an expert at creating viral YouTube Shorts content. ")
 	sb.WriteString("Based on the following analysis of top-performing videos, ")
-	sb.WriteString("create an OpusClip 'create-default' prompt that will generate ")
+	sb.WriteString(fmt.Sprintf("create a %s '%s' prompt that will generate ", opts.Target.Label, opts.Target.MetadataFeature))
 	sb.WriteString("viral-worthy titles and descriptions.\n\n")
 
 	// Add niche context
@@ -80,8 +86,17 @@ func buildSystemPrompt(patterns analyzer.Patterns, opts Options) string {
 	sb.WriteString(fmt.Sprintf("Niche: %s\n", niche))
 	sb.WriteString(fmt.Sprintf("Videos analyzed: %d\n\n", patterns.VideoCount))
 
-	// Add hooks analysis
-	if len(patterns.TopHooks) > 0 {
+	// Add hooks analysis, ranked by lift when view counts are known
+	if best := hooks.BestPerforming(patterns.TopHooks, 5); len(best) > 0 {
+		sb.WriteString("Top performing hooks:\n")
+		for _, h := range best {
+			sb.WriteString(fmt.Sprintf("- %s (%s): used %d times, median views: %d (%.1fx titles without it)\n", h.Pattern, h.Type, h.Frequency, h.MedianViews, h.Lift))
+			if len(h.Examples) > 0 {
+				sb.WriteString(fmt.Sprintf("  Best example: %q\n", h.Examples[0]))
+			}
+		}
+		sb.WriteString("\n")
+	} else if len(patterns.TopHooks) > 0 {
 		sb.WriteString("Top performing hooks:\n")
 		for i, h := range patterns.TopHooks {
 			if i >= 5 {
@@ -92,6 +107,20 @@ func buildSystemPrompt(patterns analyzer.Patterns, opts Options) string {
 		sb.WriteString("\n")
 	}
 
+	// Add hook placement guidance
+	if hp := patterns.HookPlacement; hp.TitlesWithHooks > 0 {
+		sb.WriteString("Hook placement:\n")
+		sb.WriteString(fmt.Sprintf("- %.0f%% of hooked titles open a hook within the first %d characters (median first hook: word %d)\n",
+			hp.EarlyRate*100, analyzer.MobileTitleChars, hp.MedianFirstWord+1))
+		if hp.EarlyLift > 0 {
+			sb.WriteString(fmt.Sprintf("- Early hooks: median views %d vs %d for later hooks (%.1fx)\n", hp.EarlyMedianViews, hp.LateMedianViews, hp.EarlyLift))
+		}
+		if hp.BestDensity != nil {
+			sb.WriteString(fmt.Sprintf("- Best-performing hooks per title: %s (median views: %d across %d videos)\n", hp.BestDensity.Label, hp.BestDensity.MedianViews, hp.BestDensity.Count))
+		}
+		sb.WriteString("\n")
+	}
+
 	// Add keywords analysis
 	if len(patterns.TopKeywords) > 0 {
 		sb.WriteString("Top keywords:\n")
@@ -99,11 +128,51 @@ func buildSystemPrompt(patterns analyzer.Patterns, opts Options) string {
 			if i >= 5 {
 				break
 			}
+			if len(kw.Variants) > 0 {
+				sb.WriteString(fmt.Sprintf("- %s (frequency: %d; variants: %s)\n", kw.Word, kw.Frequency, strings.Join(kw.Variants, ", ")))
+				continue
+			}
 			sb.WriteString(fmt.Sprintf("- %s (frequency: %d)\n", kw.Word, kw.Frequency))
 		}
 		sb.WriteString("\n")
 	}
 
+	// Add keyphrases analysis
+	if len(patterns.TopKeyphrases) > 0 {
+		sb.WriteString("Top keyphrases (keep these words together):\n")
+		for i, kp := range patterns.TopKeyphrases {
+			if i >= 5 {
+				break
+			}
+			sb.WriteString(fmt.Sprintf("- %s (frequency: %d)\n", kp.Phrase, kp.Frequency))
+		}
+		sb.WriteString("\n")
+	}
+
+	// Add named entities
+	if len(patterns.TopEntities) > 0 {
+		sb.WriteString("Top tools, products and people (spell these exactly):\n")
+		for i, e := range patterns.TopEntities {
+			if i >= 5 {
+				break
+			}
+			sb.WriteString(fmt.Sprintf("- %s (mentions: %d, median views: %d)\n", e.Name, e.Frequency, e.MedianViews))
+		}
+		sb.WriteString("\n")
+	}
+
+	// Add co-occurring keyword pairs
+	if len(patterns.KeywordGraph.TopPairs) > 0 {
+		sb.WriteString("Keyword pairs that perform (mention both together):\n")
+		for i, p := range patterns.KeywordGraph.TopPairs {
+			if i >= 5 {
+				break
+			}
+			sb.WriteString(fmt.Sprintf("- %s + %s (%d videos, median views: %d)\n", p.Words[0], p.Words[1], p.Videos, p.MedianViews))
+		}
+		sb.WriteString("\n")
+	}
+
 	// Add hashtags analysis
 	if len(patterns.TopHashtags) > 0 {
 		sb.WriteString("Top hashtags:\n")
@@ -129,16 +198,126 @@ func buildSystemPrompt(patterns analyzer.Patterns, opts Options) string {
 				sb.WriteString(fmt.Sprintf("  - '%s' (used %d times)\n", p.Name, p.Count))
 			}
 		}
+
+		if len(patterns.TitleMetrics.StyleFeatures) > 0 {
+			sb.WriteString("- Title style (prevalence, correlation with views):\n")
+			for _, f := range patterns.TitleMetrics.StyleFeatures {
+				if f.Prevalence == 0 {
+					continue
+				}
+				sb.WriteString(fmt.Sprintf("  - %s: %.0f%% of titles, correlation %+.2f\n", f.Name, f.Prevalence*100, f.Correlation))
+			}
+		}
+		sb.WriteString("\n")
+	}
+
+	// Add description structure
+	if dm := patterns.DescriptionMetrics; dm.AvgLength > 0 {
+		sb.WriteString("Description metrics:\n")
+		sb.WriteString(fmt.Sprintf("- Average length: %d characters over %.1f lines\n", dm.AvgLength, dm.AvgLines))
+		sb.WriteString(fmt.Sprintf("- First line contains a hook: %.0f%% of descriptions\n", dm.FirstLineHookRate*100))
+		for _, line := range dm.TopFirstLines {
+			sb.WriteString(fmt.Sprintf("  - Top first line: %q\n", line))
+		}
+		sb.WriteString(fmt.Sprintf("- Links: %.1f per description (affiliate %d, social %d, own site %d, other %d)\n",
+			dm.AvgLinks, dm.LinkTypes[analyzer.LinkAffiliate], dm.LinkTypes[analyzer.LinkSocial], dm.LinkTypes[analyzer.LinkOwnSite], dm.LinkTypes[analyzer.LinkOther]))
+		if len(dm.TopCTAs) > 0 {
+			ctas := make([]string, 0, len(dm.TopCTAs))
+			for _, cta := range dm.TopCTAs {
+				ctas = append(ctas, cta.Phrase)
+			}
+			sb.WriteString(fmt.Sprintf("- Calls to action in %.0f%% of descriptions: %s\n", dm.CTARate*100, strings.Join(ctas, ", ")))
+		}
+		sb.WriteString(fmt.Sprintf("- Hashtags: %.1f per description (top %d, inline %d, bottom %d)\n",
+			dm.AvgHashtags, dm.HashtagPlacement.Top, dm.HashtagPlacement.Inline, dm.HashtagPlacement.Bottom))
+		sb.WriteString(fmt.Sprintf("- Sponsor blocks: %.0f%%, timestamps: %.0f%%, emoji: %.0f%%\n",
+			dm.SponsorRate*100, dm.TimestampRate*100, dm.EmojiRate*100))
+		sb.WriteString("\n")
+	}
+
+	// Add duration sweet spot
+	if rec := patterns.Durations.Recommended; rec != nil {
+		sb.WriteString(fmt.Sprintf("Target duration: %s (median %d views across %d videos, %.1f%% engagement)\n", rec.Label, rec.MedianViews, rec.Count, rec.EngagementRate))
+		sb.WriteString("Duration breakdown:\n")
+		for _, b := range patterns.Durations.Bins {
+			if b.Count == 0 {
+				continue
+			}
+			sb.WriteString(fmt.Sprintf("- %s: %d videos, median %d views\n", b.Label, b.Count, b.MedianViews))
+		}
+		sb.WriteString("\n")
+	}
+
+	// Add recurring title templates
+	if len(patterns.TitleTemplates) > 0 {
+		sb.WriteString("Recurring title templates:\n")
+		for i, tt := range patterns.TitleTemplates {
+			if i >= 5 {
+				break
+			}
+			sb.WriteString(fmt.Sprintf("- %s (used %d times, median %d views)\n", tt.Template, tt.Count, tt.MedianViews))
+		}
+		sb.WriteString("\n")
+	}
+
+	// Add custom analyzer sections
+	for _, section := range patterns.Sections {
+		if len(section.Lines) == 0 {
+			continue
+		}
+		title := section.Title
+		if title == "" {
+			title = section.Name
+		}
+		sb.WriteString(fmt.Sprintf("%s:\n", title))
+		for _, line := range section.Lines {
+			sb.WriteString(fmt.Sprintf("- %s\n", line))
+		}
 		sb.WriteString("\n")
 	}
 
 	// Request format
-	sb.WriteString("Create a single, focused prompt (2-4 sentences) that instructs OpusClip how to:\n")
+	sb.WriteString(fmt.Sprintf("Create a single, focused prompt (2-4 sentences) that instructs %s how to:\n", opts.Target.Label))
 	sb.WriteString("1. Generate attention-grabbing titles using the proven hooks and patterns above\n")
-	sb.WriteString("2. Write compelling descriptions with relevant keywords and hashtags\n")
-	sb.WriteString("3. Match the style and energy of successful videos in this niche\n\n")
+	sb.WriteString("2. Write compelling descriptions with relevant keywords and hashtags, following the description structure above\n")
+	sb.WriteString("3. Match the style and energy of successful videos in this niche\n")
+	step := 4
+	if rec := patterns.Durations.Recommended; rec != nil {
+		sb.WriteString(fmt.Sprintf("%d. Favor clips in the %s duration range, where this niche performs best\n", step, rec.Label))
+		step++
+	}
+	if patterns.HookPlacement.TitlesWithHooks > 0 {
+		sb.WriteString(fmt.Sprintf("%d. %s\n", step, placementInstruction(patterns.HookPlacement)))
+	}
+	sb.WriteString("\n")
 	sb.WriteString("The prompt should be actionable and specific to this niche. ")
+	if opts.Target.Style != "" {
+		sb.WriteString(opts.Target.Style + " ")
+	}
+	if opts.MaxLength > 0 {
+		sb.WriteString(fmt.Sprintf("Keep it under %d characters. ", opts.MaxLength))
+	}
 	sb.WriteString("Do not include any explanations, just output the prompt itself.")
 
 	return sb.String()
 }
+
+// placementInstruction tells the LLM where to put hooks in titles, based on
+// how early and how many hooks the best-performing titles use.
+func placementInstruction(hp analyzer.HookPlacement) string {
+	where := fmt.Sprintf("Put the hook in the first %d characters of every title so it survives mobile truncation", analyzer.MobileTitleChars)
+	if hp.EarlyLift > 0 && hp.EarlyLift < 1 {
+		where = fmt.Sprintf("Lead with the topic and land the hook right after it, still within the first %d characters", analyzer.MobileTitleChars)
+	}
+	if hp.BestDensity == nil {
+		return where
+	}
+	switch hp.BestDensity.Hooks {
+	case 0:
+		return where + ", keeping it to a single understated hook"
+	case 1:
+		return where + ", using exactly one hook per title"
+	default:
+		return fmt.Sprintf("%s, stacking %s hooks per title (e.g., a number plus a power word)", where, hp.BestDensity.Label)
+	}
+}
@@ -9,6 +9,8 @@ import (
 	"github.com/mikelady/kingmaker/internal/analyzer"
 	"github.com/mikelady/kingmaker/internal/hooks"
 	"github.com/mikelady/kingmaker/internal/keywords"
+	"github.com/mikelady/kingmaker/internal/target"
+	"github.com/mikelady/kingmaker/internal/text"
 )
 
 // mockOpenAIClient implements openai.OpenAIClient for testing
@@ -207,3 +209,284 @@ func TestGenerator_Interface(t *testing.T) {
 	// Verify Generator implements MetadataPromptGenerator interface
 	var _ MetadataPromptGenerator = (*Generator)(nil)
 }
+
+func TestGenerate_IncludesKeywordVariants(t *testing.T) {
+	mock := &mockOpenAIClient{response: "Generated prompt"}
+	gen := NewGenerator(mock)
+
+	patterns := analyzer.Patterns{
+		TopKeywords: []keywords.Keyword{{Word: "coding", Frequency: 6, Variants: []string{"coding", "code"}}},
+		VideoCount:  10,
+	}
+
+	if _, err := gen.Generate(context.Background(), patterns, Options{}); err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+
+	if !strings.Contains(mock.lastPrompt, "coding (frequency: 6; variants: coding, code)") {
+		t.Error("prompt should list keyword variants")
+	}
+}
+
+func TestGenerate_IncludesKeyphrases(t *testing.T) {
+	mock := &mockOpenAIClient{response: "Generated prompt"}
+	gen := NewGenerator(mock)
+
+	patterns := analyzer.Patterns{
+		TopKeyphrases: []keywords.Keyphrase{{Phrase: "vibe coding", Frequency: 6}},
+		VideoCount:    10,
+	}
+
+	if _, err := gen.Generate(context.Background(), patterns, Options{}); err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+
+	if !strings.Contains(mock.lastPrompt, "vibe coding (frequency: 6)") {
+		t.Error("prompt should include keyphrases")
+	}
+}
+
+func TestGenerate_IncludesEntities(t *testing.T) {
+	mock := &mockOpenAIClient{response: "Generated prompt"}
+	gen := NewGenerator(mock)
+
+	patterns := analyzer.Patterns{
+		TopEntities: []analyzer.Entity{{Name: "ChatGPT", Frequency: 7, MedianViews: 25000}},
+		VideoCount:  10,
+	}
+
+	if _, err := gen.Generate(context.Background(), patterns, Options{}); err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+
+	if !strings.Contains(mock.lastPrompt, "ChatGPT (mentions: 7, median views: 25000)") {
+		t.Error("prompt should include entities")
+	}
+}
+
+func TestGenerate_RanksHooksByLift(t *testing.T) {
+	mock := &mockOpenAIClient{response: "Generated prompt"}
+	gen := NewGenerator(mock)
+
+	patterns := analyzer.Patterns{
+		TopHooks: []hooks.Hook{
+			{Type: hooks.Question, Pattern: "how", Frequency: 6, MedianViews: 1200, Lift: 1.2},
+			{Type: hooks.Warning, Pattern: "don't", Frequency: 3, MedianViews: 8000, Lift: 3.5, Examples: []string{"Don't Ship This"}},
+		},
+		VideoCount: 10,
+	}
+
+	if _, err := gen.Generate(context.Background(), patterns, Options{}); err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+
+	warning := strings.Index(mock.lastPrompt, "- don't (Warning): used 3 times, median views: 8000 (3.5x titles without it)")
+	question := strings.Index(mock.lastPrompt, "- how (Question)")
+	if warning < 0 || question < 0 || warning > question {
+		t.Errorf("prompt should rank hooks by lift, got:\n%s", mock.lastPrompt)
+	}
+	if !strings.Contains(mock.lastPrompt, `Best example: "Don't Ship This"`) {
+		t.Error("prompt should include the best-performing example title")
+	}
+}
+
+func TestGenerate_IncludesHookPlacement(t *testing.T) {
+	mock := &mockOpenAIClient{response: "Generated prompt"}
+	gen := NewGenerator(mock)
+
+	best := analyzer.HookDensityBucket{Label: "2", Hooks: 2, Count: 5, MedianViews: 9000}
+	patterns := analyzer.Patterns{
+		HookPlacement: analyzer.HookPlacement{
+			TitlesWithHooks:  8,
+			EarlyRate:        0.75,
+			EarlyMedianViews: 6000,
+			LateMedianViews:  2000,
+			EarlyLift:        3,
+			BestDensity:      &best,
+		},
+		VideoCount: 10,
+	}
+
+	if _, err := gen.Generate(context.Background(), patterns, Options{}); err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+
+	for _, want := range []string{
+		"75% of hooked titles open a hook within the first 40 characters",
+		"Early hooks: median views 6000 vs 2000 for later hooks (3.0x)",
+		"4. Put the hook in the first 40 characters of every title so it survives mobile truncation, stacking 2 hooks per title",
+	} {
+		if !strings.Contains(mock.lastPrompt, want) {
+			t.Errorf("prompt should include %q, got:\n%s", want, mock.lastPrompt)
+		}
+	}
+}
+
+func TestPlacementInstruction(t *testing.T) {
+	one := analyzer.HookDensityBucket{Label: "1", Hooks: 1, Count: 4}
+	tests := []struct {
+		name string
+		hp   analyzer.HookPlacement
+		want string
+	}{
+		{"no density", analyzer.HookPlacement{TitlesWithHooks: 3}, "Put the hook in the first 40 characters"},
+		{"early hooks underperform", analyzer.HookPlacement{TitlesWithHooks: 3, EarlyLift: 0.5}, "Lead with the topic"},
+		{"one hook", analyzer.HookPlacement{TitlesWithHooks: 3, BestDensity: &one}, "using exactly one hook per title"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := placementInstruction(tt.hp); !strings.Contains(got, tt.want) {
+				t.Errorf("placementInstruction() = %q, want it to contain %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGenerate_IncludesKeywordPairs(t *testing.T) {
+	mock := &mockOpenAIClient{response: "Generated prompt"}
+	gen := NewGenerator(mock)
+
+	patterns := analyzer.Patterns{
+		KeywordGraph: analyzer.KeywordGraph{
+			TopPairs: []analyzer.KeywordPair{
+				{Words: [2]string{"nextjs", "react"}, Videos: 3, MedianViews: 80000},
+			},
+		},
+		VideoCount: 10,
+	}
+
+	if _, err := gen.Generate(context.Background(), patterns, Options{}); err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+
+	if !strings.Contains(mock.lastPrompt, "nextjs + react (3 videos, median views: 80000)") {
+		t.Error("prompt should include keyword pairs")
+	}
+}
+
+func TestGenerate_IncludesTitleTemplates(t *testing.T) {
+	mock := &mockOpenAIClient{response: "Generated prompt"}
+	gen := NewGenerator(mock)
+
+	patterns := analyzer.Patterns{
+		TitleTemplates: []analyzer.TitleTemplate{
+			{Template: "I built [THING] in [N] [UNIT]", Count: 4, MedianViews: 5000},
+		},
+		VideoCount: 10,
+	}
+
+	if _, err := gen.Generate(context.Background(), patterns, Options{}); err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+
+	if !strings.Contains(mock.lastPrompt, "I built [THING] in [N] [UNIT]") {
+		t.Error("prompt should include recurring title templates")
+	}
+}
+
+func TestGenerate_IncludesTargetDuration(t *testing.T) {
+	mock := &mockOpenAIClient{response: "Generated prompt"}
+	gen := NewGenerator(mock)
+
+	rec := analyzer.DurationBin{Label: "15-30s", Count: 8, MedianViews: 25000}
+	patterns := analyzer.Patterns{
+		Durations: analyzer.DurationAnalysis{
+			Bins:        []analyzer.DurationBin{rec},
+			Recommended: &rec,
+		},
+		VideoCount: 8,
+	}
+
+	if _, err := gen.Generate(context.Background(), patterns, Options{}); err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+
+	if !strings.Contains(mock.lastPrompt, "Target duration: 15-30s") {
+		t.Error("prompt should include the recommended duration range")
+	}
+}
+
+func TestGenerate_IncludesDescriptionMetrics(t *testing.T) {
+	mock := &mockOpenAIClient{response: "Generated prompt"}
+	gen := NewGenerator(mock)
+
+	patterns := analyzer.Patterns{
+		DescriptionMetrics: analyzer.DescriptionMetrics{
+			AvgLength:         220,
+			AvgLines:          4,
+			FirstLineHookRate: 0.6,
+			TopFirstLines:     []string{"How I built this in 5 minutes"},
+			CTARate:           0.8,
+			TopCTAs:           []analyzer.CTA{{Phrase: "link in bio", Count: 8}},
+		},
+		VideoCount: 10,
+	}
+
+	if _, err := gen.Generate(context.Background(), patterns, Options{}); err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+
+	for _, want := range []string{"Description metrics", "How I built this in 5 minutes", "link in bio"} {
+		if !strings.Contains(mock.lastPrompt, want) {
+			t.Errorf("prompt should include %q", want)
+		}
+	}
+}
+
+func TestGenerate_IncludesCustomSections(t *testing.T) {
+	mock := &mockOpenAIClient{response: "Generated prompt"}
+	gen := NewGenerator(mock)
+
+	patterns := analyzer.Patterns{
+		Sections:   []analyzer.Section{{Name: "brand", Title: "Brand heuristics", Lines: []string{"Mention the product by name"}}},
+		VideoCount: 5,
+	}
+
+	if _, err := gen.Generate(context.Background(), patterns, Options{}); err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+	if !strings.Contains(mock.lastPrompt, "Brand heuristics") || !strings.Contains(mock.lastPrompt, "Mention the product by name") {
+		t.Error("prompt should include custom analyzer sections")
+	}
+}
+
+func TestGenerate_DefaultTargetIsOpusClip(t *testing.T) {
+	mock := &mockOpenAIClient{response: "prompt"}
+	if _, err := NewGenerator(mock).Generate(context.Background(), analyzer.Patterns{}, Options{}); err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+	if !strings.Contains(mock.lastPrompt, "OpusClip 'create-default' prompt") {
+		t.Errorf("prompt should ask for an OpusClip create-default prompt, got:\n%s", mock.lastPrompt)
+	}
+	if strings.Contains(mock.lastPrompt, "characters") {
+		t.Error("OpusClip prompts have no length limit to mention")
+	}
+}
+
+func TestGenerate_Target(t *testing.T) {
+	capcut, err := target.Parse(target.CapCut)
+	if err != nil {
+		t.Fatal(err)
+	}
+	mock := &mockOpenAIClient{response: "Title it \"5 #ai tricks\" and " + strings.Repeat("keep going ", 40)}
+	result, err := NewGenerator(mock).Generate(context.Background(), analyzer.Patterns{}, Options{Target: capcut})
+	if err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+
+	for _, want := range []string{"CapCut 'AI caption prompt'", "instructs CapCut how to", capcut.Style, "under 300 characters"} {
+		if !strings.Contains(mock.lastPrompt, want) {
+			t.Errorf("prompt should contain %q", want)
+		}
+	}
+	if strings.ContainsAny(result, capcut.Disallowed) {
+		t.Errorf("result %q keeps characters CapCut rejects", result)
+	}
+	if n := text.GraphemeCount(result); n > capcut.MaxMetaChars {
+		t.Errorf("result has %d characters, want at most %d", n, capcut.MaxMetaChars)
+	}
+	if !strings.HasSuffix(result, "...") {
+		t.Errorf("truncated result %q should end with an ellipsis", result)
+	}
+}
@@ -0,0 +1,33 @@
+package model
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+)
+
+// SaveVideos writes videos to a JSON file, so later runs can analyze the
+// same niche without fetching it again.
+func SaveVideos(path string, videos []Video) error {
+	data, err := json.MarshalIndent(videos, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		return fmt.Errorf("writing videos: %w", err)
+	}
+	return nil
+}
+
+// LoadVideos reads videos written by SaveVideos.
+func LoadVideos(path string) ([]Video, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading videos: %w", err)
+	}
+	var videos []Video
+	if err := json.Unmarshal(data, &videos); err != nil {
+		return nil, fmt.Errorf("parsing videos %s: %w", path, err)
+	}
+	return videos, nil
+}
@@ -0,0 +1,51 @@
+package model
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestSaveLoadVideos(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "videos.json")
+	videos := []Video{
+		{
+			ID:          "abc123",
+			Title:       "I Built an App in 10 Minutes",
+			Tags:        []string{"ai", "coding"},
+			ViewCount:   12000,
+			LikeCount:   600,
+			ChannelID:   "UC123",
+			PublishedAt: time.Date(2025, 1, 15, 10, 30, 0, 0, time.UTC),
+			Duration:    45,
+		},
+	}
+
+	if err := SaveVideos(path, videos); err != nil {
+		t.Fatalf("SaveVideos() error = %v", err)
+	}
+	got, err := LoadVideos(path)
+	if err != nil {
+		t.Fatalf("LoadVideos() error = %v", err)
+	}
+	if !reflect.DeepEqual(got, videos) {
+		t.Errorf("LoadVideos() = %+v, want %+v", got, videos)
+	}
+}
+
+func TestLoadVideos_Errors(t *testing.T) {
+	dir := t.TempDir()
+	if _, err := LoadVideos(filepath.Join(dir, "missing.json")); err == nil {
+		t.Error("LoadVideos() expected error for a missing file")
+	}
+
+	bad := filepath.Join(dir, "bad.json")
+	if err := os.WriteFile(bad, []byte("{not json"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := LoadVideos(bad); err == nil {
+		t.Error("LoadVideos() expected error for invalid JSON")
+	}
+}
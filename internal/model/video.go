@@ -4,15 +4,17 @@ import "time"
 
 // Video represents a YouTube video with its metadata.
 type Video struct {
-	ID          string
-	Title       string
-	Description string
-	ViewCount   int64
-	LikeCount   int64
-	Channel     string
-	ChannelID   string
-	PublishedAt time.Time
-	Duration    int // seconds
+	ID                   string
+	Title                string
+	Description          string
+	Tags                 []string
+	DefaultAudioLanguage string // BCP-47 tag reported by YouTube (e.g., "es-419"), may be empty
+	ViewCount            int64
+	LikeCount            int64
+	Channel              string
+	ChannelID            string
+	PublishedAt          time.Time
+	Duration             int // seconds
 }
 
 // IsShort returns true if the video is 60 seconds or less (YouTube Shorts format).
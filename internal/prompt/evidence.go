@@ -0,0 +1,197 @@
+package prompt
+
+import (
+	"strings"
+
+	"github.com/mikelady/kingmaker/internal/analyzer"
+	"github.com/mikelady/kingmaker/internal/keywords"
+	"github.com/mikelady/kingmaker/internal/model"
+	"github.com/mikelady/kingmaker/internal/text"
+)
+
+// videoIndex finds the videos that mention a term or use a hashtag.
+type videoIndex struct {
+	ids      []string
+	texts    []string            // Space-padded, space-joined tokens of title, description and tags
+	hashtags []map[string]bool   // Lowercase hashtags of each video
+	variants map[string][]string // Surface forms of stemmed keywords
+	known    map[string]bool     // IDs of the indexed videos
+}
+
+// newVideoIndex indexes videos. Keywords contribute their merged surface
+// forms, so "coding" also finds videos that only say "code".
+func newVideoIndex(videos []model.Video, kws []keywords.Keyword) *videoIndex {
+	idx := &videoIndex{
+		variants: make(map[string][]string),
+		known:    make(map[string]bool, len(videos)),
+	}
+	for _, kw := range kws {
+		idx.variants[kw.Word] = kw.Variants
+	}
+	for _, v := range videos {
+		if v.ID == "" {
+			continue
+		}
+		all := v.Title + "\n" + v.Description + "\n" + strings.Join(v.Tags, " ")
+		tags := make(map[string]bool)
+		for _, tag := range text.ExtractHashtags(v.Description) {
+			tags[strings.ToLower(tag)] = true
+		}
+		idx.ids = append(idx.ids, v.ID)
+		idx.texts = append(idx.texts, " "+strings.Join(text.Tokenize(all), " ")+" ")
+		idx.hashtags = append(idx.hashtags, tags)
+		idx.known[v.ID] = true
+	}
+	return idx
+}
+
+// empty reports whether no videos are indexed.
+func (idx *videoIndex) empty() bool {
+	return len(idx.ids) == 0
+}
+
+// forms returns the token sequences a term may appear as.
+func (idx *videoIndex) forms(term string) []string {
+	forms := []string{" " + strings.Join(text.Tokenize(term), " ") + " "}
+	for _, v := range idx.variants[term] {
+		forms = append(forms, " "+strings.Join(text.Tokenize(v), " ")+" ")
+	}
+	return forms
+}
+
+// mentions reports whether video i mentions term.
+func (idx *videoIndex) mentions(i int, term string) bool {
+	for _, form := range idx.forms(term) {
+		if strings.TrimSpace(form) != "" && strings.Contains(idx.texts[i], form) {
+			return true
+		}
+	}
+	return false
+}
+
+// mentioning returns the videos mentioning any of terms.
+func (idx *videoIndex) mentioning(terms ...string) []string {
+	var ids []string
+	for i, id := range idx.ids {
+		for _, term := range terms {
+			if idx.mentions(i, term) {
+				ids = append(ids, id)
+				break
+			}
+		}
+	}
+	return ids
+}
+
+// mentioningAll returns the videos mentioning every one of terms.
+func (idx *videoIndex) mentioningAll(terms ...string) []string {
+	var ids []string
+	for i, id := range idx.ids {
+		all := true
+		for _, term := range terms {
+			if !idx.mentions(i, term) {
+				all = false
+				break
+			}
+		}
+		if all {
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
+
+// tagged returns the videos using any of the hashtags.
+func (idx *videoIndex) tagged(tags ...string) []string {
+	var ids []string
+	for i, id := range idx.ids {
+		for _, tag := range tags {
+			if idx.hashtags[i][strings.ToLower(strings.TrimPrefix(tag, "#"))] {
+				ids = append(ids, id)
+				break
+			}
+		}
+	}
+	return ids
+}
+
+// restrict keeps the IDs of indexed videos. Without indexed videos every ID
+// is kept.
+func (idx *videoIndex) restrict(ids []string) []string {
+	if idx.empty() {
+		return ids
+	}
+	var kept []string
+	for _, id := range ids {
+		if idx.known[id] {
+			kept = append(kept, id)
+		}
+	}
+	return kept
+}
+
+// evidence is the number of supporting videos, or the fallback mention count
+// when no videos are indexed.
+func evidence(ids []string, idx *videoIndex, fallback int) int {
+	if idx.empty() {
+		return fallback
+	}
+	return len(ids)
+}
+
+// hookEvidence is the number of videos using a hook group, falling back to
+// the group's frequency when the hooks carry no video IDs.
+func hookEvidence(g hookGroup, idx *videoIndex) int {
+	if len(g.videoIDs) == 0 {
+		return g.frequency
+	}
+	return len(idx.restrict(g.videoIDs))
+}
+
+// termMentions is the highest frequency among terms that are keyphrases or
+// keywords.
+func termMentions(terms []string, phrases []keywords.Keyphrase, kws []keywords.Keyword) int {
+	frequency := make(map[string]int, len(phrases)+len(kws))
+	for _, p := range phrases {
+		frequency[p.Phrase] = p.Frequency
+	}
+	for _, kw := range kws {
+		frequency[kw.Word] = kw.Frequency
+	}
+
+	best := 0
+	for _, term := range terms {
+		best = max(best, frequency[term])
+	}
+	return best
+}
+
+// clusterVideos returns the videos in a topic cluster.
+func clusterVideos(videos []model.Video, c analyzer.TopicCluster) []model.Video {
+	inCluster := make(map[string]bool, len(c.VideoIDs))
+	for _, id := range c.VideoIDs {
+		inCluster[id] = true
+	}
+	var result []model.Video
+	for _, v := range videos {
+		if inCluster[v.ID] {
+			result = append(result, v)
+		}
+	}
+	return result
+}
+
+// union appends the IDs of b missing from a, keeping order.
+func union(a, b []string) []string {
+	seen := make(map[string]bool, len(a))
+	for _, id := range a {
+		seen[id] = true
+	}
+	for _, id := range b {
+		if !seen[id] {
+			seen[id] = true
+			a = append(a, id)
+		}
+	}
+	return a
+}
@@ -0,0 +1,58 @@
+package prompt
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/mikelady/kingmaker/internal/analyzer"
+	"github.com/mikelady/kingmaker/internal/model"
+)
+
+func TestVideoIndex(t *testing.T) {
+	videos := []model.Video{
+		{ID: "a", Title: "Vibe coding with Cursor", Tags: []string{"nextjs"}},
+		{ID: "b", Title: "Cursor vs Claude Code"},
+		{ID: "", Title: "Untracked cursor video"},
+	}
+	idx := newVideoIndex(videos, nil)
+
+	if got := idx.mentioning("vibe coding", "claude code"); !reflect.DeepEqual(got, []string{"a", "b"}) {
+		t.Errorf("mentioning() = %v, want [a b]", got)
+	}
+	if got := idx.mentioning("coding vibe"); got != nil {
+		t.Errorf("mentioning(reordered phrase) = %v, want none", got)
+	}
+	if got := idx.mentioningAll("cursor", "nextjs"); !reflect.DeepEqual(got, []string{"a"}) {
+		t.Errorf("mentioningAll() = %v, want [a] via tags", got)
+	}
+	if got := idx.restrict([]string{"b", "z"}); !reflect.DeepEqual(got, []string{"b"}) {
+		t.Errorf("restrict() = %v, want [b]", got)
+	}
+}
+
+func TestEvidence_FallsBackWithoutVideos(t *testing.T) {
+	idx := newVideoIndex(nil, nil)
+	if got := evidence(nil, idx, 7); got != 7 {
+		t.Errorf("evidence() = %d, want the fallback 7", got)
+	}
+	if got := idx.restrict([]string{"a"}); !reflect.DeepEqual(got, []string{"a"}) {
+		t.Errorf("restrict() without videos = %v, want the IDs unchanged", got)
+	}
+	if got := hookEvidence(hookGroup{frequency: 4}, idx); got != 4 {
+		t.Errorf("hookEvidence() = %d, want the frequency 4", got)
+	}
+}
+
+func TestClusterVideos(t *testing.T) {
+	videos := []model.Video{{ID: "a"}, {ID: "b"}, {ID: "c"}}
+	got := clusterVideos(videos, analyzer.TopicCluster{VideoIDs: []string{"c", "a"}})
+	if len(got) != 2 || got[0].ID != "a" || got[1].ID != "c" {
+		t.Errorf("clusterVideos() = %+v, want a and c in input order", got)
+	}
+}
+
+func TestUnion(t *testing.T) {
+	if got := union([]string{"a", "b"}, []string{"b", "c"}); !reflect.DeepEqual(got, []string{"a", "b", "c"}) {
+		t.Errorf("union() = %v, want [a b c]", got)
+	}
+}
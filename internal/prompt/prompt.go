@@ -1,20 +1,73 @@
-// Package prompt generates OpusClip-compatible prompts from analyzed video patterns.
+// Package prompt generates clip prompts for OpusClip and other clipping tools
+// from analyzed video patterns.
 package prompt
 
 import (
-	"fmt"
+	"sort"
 	"strings"
 
 	"github.com/mikelady/kingmaker/internal/analyzer"
 	"github.com/mikelady/kingmaker/internal/hooks"
 	"github.com/mikelady/kingmaker/internal/keywords"
+	"github.com/mikelady/kingmaker/internal/model"
+	"github.com/mikelady/kingmaker/internal/target"
+	"github.com/mikelady/kingmaker/internal/text"
 )
 
+// Prompt strategies, in tie-break order.
+const (
+	StrategyKeywords   = "keywords"   // Top keywords and keyphrases
+	StrategyPairs      = "pairs"      // Keyword pairs that co-occur in performing videos
+	StrategyEntities   = "entities"   // Named tools, products and people
+	StrategyHooks      = "hooks"      // One hook type's patterns
+	StrategyTrend      = "trend"      // Top hashtags
+	StrategyEngagement = "engagement" // Fallback when other strategies run short
+)
+
+// strategyOrder breaks evidence ties between strategies.
+var strategyOrder = map[string]int{
+	StrategyKeywords:   0,
+	StrategyPairs:      1,
+	StrategyEntities:   2,
+	StrategyHooks:      3,
+	StrategyTrend:      4,
+	StrategyEngagement: 5,
+}
+
+// strategyRank is a strategy's tie-break position; strategies added by
+// templates come after the built-in ones.
+func strategyRank(strategy string) int {
+	if rank, ok := strategyOrder[strategy]; ok {
+		return rank
+	}
+	return len(strategyOrder)
+}
+
+// nearDuplicateAt is the word overlap from which two prompts count as the
+// same prompt.
+const nearDuplicateAt = 0.8
+
+// Prompt is a generated clip prompt with the patterns and videos behind it.
+type Prompt struct {
+	Text     string   // Prompt text
+	Strategy string   // Strategy that produced it (e.g., "keywords")
+	Target   string   // Clipping tool it is formatted for (e.g., "opusclip")
+	Keywords []string // Keywords, keyphrases, pairs or entities it names
+	Hooks    []string // Hook patterns it is based on
+	Hashtags []string // Hashtags it is based on
+	VideoIDs []string // Videos supporting it, in input order
+	Evidence int      // Supporting videos; mention counts when the videos are unknown
+}
+
 // Options configures prompt generation behavior.
 type Options struct {
-	MaxPrompts      int    // Maximum number of prompts to generate (default 5)
-	MaxPromptLength int    // Maximum length per prompt in characters (default 280)
-	Query           string // Original search query for context
+	MaxPrompts      int           // Maximum number of prompts to generate (default 5)
+	MaxPromptLength int           // Maximum length per prompt in characters (default and cap: the target's limit)
+	Query           string        // Original search query for context
+	Cluster         int           // Topic cluster ID to target (0 = whole result set)
+	Videos          []model.Video // Videos the patterns were learned from, to cite supporting videos
+	Templates       *Templates    // Templates phrasing each strategy (default bundled)
+	Target          target.Target // Clipping tool to format prompts for (default OpusClip)
 }
 
 // DefaultOptions returns sensible defaults for prompt generation.
@@ -25,176 +78,470 @@ func DefaultOptions() Options {
 	}
 }
 
-// Generate creates OpusClip-compatible prompts from analyzed patterns.
-// Prompts are designed for ClipAnything's natural language format:
+// Generate creates clip prompts from analyzed patterns. Prompts are phrased
+// for OpusClip ClipAnything's natural language format:
 // Subject + Action + Setting + Emotion/Sentiment
-func Generate(patterns analyzer.Patterns, opts Options) []string {
+// and then adapted to the target tool's conventions and limits.
+//
+// Each strategy proposes candidate prompts, which are ranked by the number of
+// videos supporting them; near-identical prompts are dropped. The engagement
+// prompt only fills remaining slots. Identical input yields identical prompts.
+func Generate(patterns analyzer.Patterns, opts Options) []Prompt {
 	if patterns.VideoCount == 0 && len(patterns.TopKeywords) == 0 {
-		return []string{}
+		return []Prompt{}
 	}
 
 	// Apply defaults
 	if opts.MaxPrompts <= 0 {
 		opts.MaxPrompts = 5
 	}
-	if opts.MaxPromptLength <= 0 {
-		opts.MaxPromptLength = 280
+	opts.Target = opts.Target.OrDefault()
+	if opts.MaxPromptLength <= 0 || opts.MaxPromptLength > opts.Target.MaxClipChars {
+		opts.MaxPromptLength = opts.Target.MaxClipChars
+	}
+	if opts.Templates == nil {
+		opts.Templates = DefaultTemplates()
 	}
 
-	var prompts []string
+	// Narrow to a single topic cluster if requested; unknown IDs fall back
+	// to the whole result set
+	videos := opts.Videos
+	if opts.Cluster > 0 {
+		if c, ok := patterns.FindCluster(opts.Cluster); ok {
+			patterns = clusterPatterns(patterns, c)
+			videos = clusterVideos(videos, c)
+		}
+	}
+	idx := newVideoIndex(videos, patterns.TopKeywords)
 
 	// Extract key elements
-	topKeywords := extractTopWords(patterns.TopKeywords, 5)
+	// Skip terms that only repeat the query, unless nothing else is left
+	phrases, kws := withoutQueryTerms(patterns.TopKeyphrases, patterns.TopKeywords, opts.Query)
+	if len(phrases) == 0 && len(kws) == 0 {
+		phrases, kws = patterns.TopKeyphrases, patterns.TopKeywords
+	}
+	topKeywords := extractTopTerms(phrases, kws, 5)
+	topPairs := extractTopPairs(patterns.KeywordGraph.TopPairs, opts.Query, 3)
+	topEntities := extractTopEntities(patterns.TopEntities, opts.Query, 3)
 	topHashtags := extractTopTags(patterns.TopHashtags, 3)
-	hookTypes := categorizeHooks(patterns.TopHooks)
 
-	// Generate prompts based on patterns found
+	// Every strategy's template sees the patterns, query and top terms
+	data := TemplateData{
+		Query:      opts.Query,
+		Patterns:   patterns,
+		Keywords:   topKeywords,
+		Hashtags:   topHashtags,
+		VideoCount: patterns.VideoCount,
+	}
+
+	var candidates []Prompt
 
 	// 1. Keyword-focused prompt
 	if len(topKeywords) > 0 {
-		prompt := generateKeywordPrompt(topKeywords, opts.Query)
-		if prompt != "" {
-			prompts = append(prompts, truncate(prompt, opts.MaxPromptLength))
-		}
+		ids := idx.mentioning(topKeywords...)
+		candidates = append(candidates, Prompt{
+			Text:     opts.Templates.render(StrategyKeywords, data),
+			Strategy: StrategyKeywords,
+			Keywords: topKeywords,
+			VideoIDs: ids,
+			Evidence: evidence(ids, idx, termMentions(topKeywords, phrases, kws)),
+		})
 	}
 
-	// 2. Hook-based prompts (one per hook type found)
-	for hookType, examples := range hookTypes {
-		if len(prompts) >= opts.MaxPrompts {
-			break
+	// 2. Keyword pairs that co-occur in the best-performing videos
+	if len(topPairs) > 0 {
+		names := make([]string, len(topPairs))
+		var ids []string
+		mentions := 0
+		for i, p := range topPairs {
+			names[i] = p.Words[0] + " + " + p.Words[1]
+			ids = union(ids, idx.mentioningAll(p.Words[0], p.Words[1]))
+			mentions = max(mentions, p.Videos)
 		}
-		prompt := generateHookPrompt(hookType, examples, topKeywords)
-		if prompt != "" {
-			prompts = append(prompts, truncate(prompt, opts.MaxPromptLength))
+		pairData := data
+		pairData.Pairs = pairWords(topPairs)
+		candidates = append(candidates, Prompt{
+			Text:     opts.Templates.render(StrategyPairs, pairData),
+			Strategy: StrategyPairs,
+			Keywords: names,
+			VideoIDs: ids,
+			Evidence: evidence(ids, idx, mentions),
+		})
+	}
+
+	// 3. Named tools, products and people
+	if len(topEntities) > 0 {
+		names := make([]string, len(topEntities))
+		mentions := 0
+		for i, e := range topEntities {
+			names[i] = e.Name
+			mentions = max(mentions, e.Videos)
 		}
+		ids := idx.mentioning(names...)
+		entityData := data
+		entityData.Entities = names
+		candidates = append(candidates, Prompt{
+			Text:     opts.Templates.render(StrategyEntities, entityData),
+			Strategy: StrategyEntities,
+			Keywords: names,
+			VideoIDs: ids,
+			Evidence: evidence(ids, idx, mentions),
+		})
 	}
 
-	// 3. Hashtag/trend-focused prompt
-	if len(topHashtags) > 0 && len(prompts) < opts.MaxPrompts {
-		prompt := generateTrendPrompt(topHashtags, topKeywords)
-		if prompt != "" {
-			prompts = append(prompts, truncate(prompt, opts.MaxPromptLength))
+	// 4. Hook-based prompts (one per hook type found, in type order)
+	for _, group := range categorizeHooks(patterns.TopHooks) {
+		hookData := data
+		hookData.HookType = group.hookType.Name()
+		hookData.Hooks = group.patterns
+		prompt := opts.Templates.render(StrategyHooks, hookData)
+		if prompt == "" {
+			continue
 		}
+		candidates = append(candidates, Prompt{
+			Text:     prompt,
+			Strategy: StrategyHooks,
+			Keywords: topKeywords[:min(2, len(topKeywords))],
+			Hooks:    group.patterns,
+			VideoIDs: idx.restrict(group.videoIDs),
+			Evidence: hookEvidence(group, idx),
+		})
 	}
 
-	// 4. Engagement-focused prompt
-	if len(prompts) < opts.MaxPrompts && len(topKeywords) > 0 {
-		prompt := generateEngagementPrompt(topKeywords, patterns.VideoCount)
-		if prompt != "" {
-			prompts = append(prompts, truncate(prompt, opts.MaxPromptLength))
+	// 5. Hashtag/trend-focused prompt
+	if len(topHashtags) > 0 {
+		ids := idx.tagged(topHashtags...)
+		mentions := 0
+		for _, tag := range patterns.TopHashtags[:len(topHashtags)] {
+			mentions = max(mentions, tag.Frequency)
 		}
+		candidates = append(candidates, Prompt{
+			Text:     opts.Templates.render(StrategyTrend, data),
+			Strategy: StrategyTrend,
+			Keywords: topKeywords[:min(3, len(topKeywords))],
+			Hashtags: topHashtags,
+			VideoIDs: ids,
+			Evidence: evidence(ids, idx, mentions),
+		})
+	}
+
+	// Strategies added by user templates, supported by the top keywords
+	for _, strategy := range opts.Templates.added() {
+		ids := idx.mentioning(topKeywords...)
+		candidates = append(candidates, Prompt{
+			Text:     opts.Templates.render(strategy, data),
+			Strategy: strategy,
+			Keywords: topKeywords,
+			Hashtags: topHashtags,
+			VideoIDs: ids,
+			Evidence: evidence(ids, idx, termMentions(topKeywords, phrases, kws)),
+		})
 	}
 
-	// Limit to max prompts
-	if len(prompts) > opts.MaxPrompts {
-		prompts = prompts[:opts.MaxPrompts]
+	for i := range candidates {
+		formatFor(&candidates[i], opts.Target)
+	}
+	prompts := rankPrompts(candidates, opts.MaxPrompts, opts.MaxPromptLength)
+
+	// 6. Engagement-focused prompt
+	if len(prompts) < opts.MaxPrompts && len(topKeywords) > 0 {
+		terms := topKeywords[:min(3, len(topKeywords))]
+		ids := idx.mentioning(terms...)
+		engagement := Prompt{
+			Text:     opts.Templates.render(StrategyEngagement, data),
+			Strategy: StrategyEngagement,
+			Keywords: terms,
+			VideoIDs: ids,
+			Evidence: evidence(ids, idx, termMentions(terms, phrases, kws)),
+		}
+		formatFor(&engagement, opts.Target)
+		prompts = rankPrompts(append(prompts, engagement), opts.MaxPrompts, opts.MaxPromptLength)
 	}
 
 	return prompts
 }
 
-func extractTopWords(kws []keywords.Keyword, n int) []string {
-	result := make([]string, 0, n)
-	for i, kw := range kws {
-		if i >= n {
+// formatFor adapts a prompt's text to a clipping tool and labels it with
+// the tool.
+func formatFor(p *Prompt, t target.Target) {
+	p.Text = t.Format(p.Text)
+	p.Target = t.Name
+}
+
+// rankPrompts orders prompts by evidence, then strategy and text, drops
+// empty and near-identical prompts in favor of the better-supported one, and
+// truncates the text of the first n that remain.
+func rankPrompts(candidates []Prompt, n, maxLen int) []Prompt {
+	sort.SliceStable(candidates, func(i, j int) bool {
+		a, b := candidates[i], candidates[j]
+		if a.Strategy == StrategyEngagement || b.Strategy == StrategyEngagement {
+			// The engagement fallback never displaces another prompt
+			return b.Strategy == StrategyEngagement && a.Strategy != StrategyEngagement
+		}
+		if a.Evidence != b.Evidence {
+			return a.Evidence > b.Evidence
+		}
+		if strategyRank(a.Strategy) != strategyRank(b.Strategy) {
+			return strategyRank(a.Strategy) < strategyRank(b.Strategy)
+		}
+		return a.Text < b.Text
+	})
+
+	result := make([]Prompt, 0, min(n, len(candidates)))
+	for _, c := range candidates {
+		if len(result) >= n {
 			break
 		}
-		result = append(result, kw.Word)
+		if strings.TrimSpace(c.Text) == "" {
+			continue
+		}
+		c.Text = truncate(c.Text, maxLen)
+		duplicate := false
+		for _, kept := range result {
+			if similarity(c.Text, kept.Text) >= nearDuplicateAt {
+				duplicate = true
+				break
+			}
+		}
+		if !duplicate {
+			result = append(result, c)
+		}
 	}
 	return result
 }
 
-func extractTopTags(tags []analyzer.Hashtag, n int) []string {
-	result := make([]string, 0, n)
-	for i, tag := range tags {
-		if i >= n {
-			break
+// similarity is the Jaccard overlap of two prompts' word sets.
+func similarity(a, b string) float64 {
+	wa, wb := wordSet(a), wordSet(b)
+	if len(wa) == 0 && len(wb) == 0 {
+		return 1
+	}
+	shared := 0
+	for w := range wa {
+		if wb[w] {
+			shared++
 		}
-		result = append(result, tag.Tag)
 	}
-	return result
+	return float64(shared) / float64(len(wa)+len(wb)-shared)
 }
 
-func categorizeHooks(allHooks []hooks.Hook) map[hooks.HookType][]string {
-	result := make(map[hooks.HookType][]string)
-	for _, h := range allHooks {
-		if h.Frequency > 0 {
-			result[h.Type] = append(result[h.Type], h.Pattern)
-		}
+func wordSet(s string) map[string]bool {
+	set := make(map[string]bool)
+	for _, w := range text.Tokenize(s) {
+		set[w] = true
 	}
-	return result
+	return set
+}
+
+func pairWords(pairs []analyzer.KeywordPair) [][2]string {
+	words := make([][2]string, len(pairs))
+	for i, p := range pairs {
+		words[i] = p.Words
+	}
+	return words
 }
 
-func generateKeywordPrompt(keywords []string, query string) string {
-	if len(keywords) == 0 {
-		return ""
+// clusterPatterns returns a copy of patterns restricted to a topic cluster's
+// keywords, the keyphrases, keyword pairs and entities sharing a word with
+// them, and hooks.
+func clusterPatterns(patterns analyzer.Patterns, c analyzer.TopicCluster) analyzer.Patterns {
+	clusterWords := make(map[string]bool, len(c.Keywords))
+	for _, kw := range c.Keywords {
+		clusterWords[kw.Word] = true
+	}
+	var phrases []keywords.Keyphrase
+	for _, p := range patterns.TopKeyphrases {
+		for _, w := range p.Words {
+			if clusterWords[w] {
+				phrases = append(phrases, p)
+				break
+			}
+		}
 	}
 
-	kwList := strings.Join(keywords, ", ")
+	var pairs []analyzer.KeywordPair
+	for _, p := range patterns.KeywordGraph.TopPairs {
+		if clusterWords[p.Words[0]] || clusterWords[p.Words[1]] {
+			pairs = append(pairs, p)
+		}
+	}
 
-	if query != "" {
-		return fmt.Sprintf("Find clips about %s featuring discussions of %s with high energy moments", query, kwList)
+	var entities []analyzer.Entity
+	for _, e := range patterns.TopEntities {
+		for _, w := range text.Tokenize(e.Name) {
+			if clusterWords[w] {
+				entities = append(entities, e)
+				break
+			}
+		}
 	}
-	return fmt.Sprintf("Find engaging moments where the creator discusses %s with enthusiasm or excitement", kwList)
+
+	patterns.TopKeywords = c.Keywords
+	patterns.TopKeyphrases = phrases
+	patterns.KeywordGraph.TopPairs = pairs
+	patterns.TopEntities = entities
+	patterns.TopHooks = c.TopHooks
+	patterns.VideoCount = c.Size
+	return patterns
 }
 
-func generateHookPrompt(hookType hooks.HookType, patterns []string, keywords []string) string {
-	kwContext := ""
-	if len(keywords) > 0 {
-		kwContext = " about " + strings.Join(keywords[:min(2, len(keywords))], " or ")
+// withoutQueryTerms drops keyphrases and keywords that only repeat the
+// query (or inflections of its words), which the keyword prompt already names.
+func withoutQueryTerms(phrases []keywords.Keyphrase, kws []keywords.Keyword, query string) ([]keywords.Keyphrase, []keywords.Keyword) {
+	if strings.TrimSpace(query) == "" {
+		return phrases, kws
+	}
+	lang := text.DetectLanguage(query)
+	if lang == "" {
+		lang = "en"
 	}
+	exclude := keywords.NewExclusion(nil, []string{query})
 
-	switch hookType {
-	case hooks.Question:
-		return fmt.Sprintf("Clip moments where the creator asks thought-provoking questions%s and provides surprising answers", kwContext)
-	case hooks.Numerical:
-		return fmt.Sprintf("Find segments with numbered tips, lists, or step-by-step explanations%s", kwContext)
-	case hooks.PowerWord:
-		return fmt.Sprintf("Extract high-impact moments with bold claims or revelations%s", kwContext)
-	case hooks.CuriosityGap:
-		return fmt.Sprintf("Find teaser moments that create suspense or curiosity%s before revealing insights", kwContext)
-	default:
-		return ""
+	keptPhrases := make([]keywords.Keyphrase, 0, len(phrases))
+	for _, p := range phrases {
+		for _, w := range p.Words {
+			if !exclude.Excludes(w, lang) {
+				keptPhrases = append(keptPhrases, p)
+				break
+			}
+		}
+	}
+
+	keptKeywords := make([]keywords.Keyword, 0, len(kws))
+	for _, kw := range kws {
+		if !exclude.Excludes(kw.Word, lang) {
+			keptKeywords = append(keptKeywords, kw)
+		}
 	}
+	return keptPhrases, keptKeywords
 }
 
-func generateTrendPrompt(hashtags []string, keywords []string) string {
-	if len(hashtags) == 0 {
-		return ""
+// extractTopTerms returns up to n terms, preferring multi-word keyphrases
+// and filling the remainder with keywords not already covered by a phrase.
+func extractTopTerms(phrases []keywords.Keyphrase, kws []keywords.Keyword, n int) []string {
+	result := make([]string, 0, n)
+	for _, p := range phrases {
+		if len(result) >= n {
+			return result
+		}
+		result = append(result, p.Phrase)
+	}
+
+	covered := keywords.PhraseWords(phrases[:len(result)])
+	for _, kw := range kws {
+		if len(result) >= n {
+			break
+		}
+		if covered[kw.Word] {
+			continue
+		}
+		result = append(result, kw.Word)
 	}
+	return result
+}
 
-	trendTerms := strings.Join(hashtags, ", ")
+// extractTopPairs returns up to n performing keyword pairs, skipping pairs
+// made up entirely of query words.
+func extractTopPairs(pairs []analyzer.KeywordPair, query string, n int) []analyzer.KeywordPair {
+	var exclude *keywords.Exclusion
+	lang := "en"
+	if strings.TrimSpace(query) != "" {
+		exclude = keywords.NewExclusion(nil, []string{query})
+		if detected := text.DetectLanguage(query); detected != "" {
+			lang = detected
+		}
+	}
 
-	if len(keywords) > 0 {
-		return fmt.Sprintf("Find viral-worthy moments discussing trending topics like %s with keywords %s", trendTerms, strings.Join(keywords[:min(3, len(keywords))], ", "))
+	result := make([]analyzer.KeywordPair, 0, n)
+	for _, p := range pairs {
+		if len(result) >= n {
+			break
+		}
+		if exclude.Excludes(p.Words[0], lang) && exclude.Excludes(p.Words[1], lang) {
+			continue
+		}
+		result = append(result, p)
 	}
-	return fmt.Sprintf("Extract shareable clips covering trending topics: %s", trendTerms)
+	return result
 }
 
-func generateEngagementPrompt(keywords []string, videoCount int) string {
-	if len(keywords) == 0 {
-		return ""
+// extractTopEntities returns up to n entities, skipping those the query
+// already names.
+func extractTopEntities(entities []analyzer.Entity, query string, n int) []analyzer.Entity {
+	queryWords := make(map[string]bool)
+	for _, w := range text.Tokenize(query) {
+		queryWords[w] = true
 	}
 
-	return fmt.Sprintf("Find the most engaging moments with clear value delivery about %s - reactions, demonstrations, or aha moments", strings.Join(keywords[:min(3, len(keywords))], ", "))
+	result := make([]analyzer.Entity, 0, n)
+	for _, e := range entities {
+		if len(result) >= n {
+			break
+		}
+		named := true
+		for _, w := range text.Tokenize(e.Name) {
+			if !queryWords[w] {
+				named = false
+				break
+			}
+		}
+		if named {
+			continue
+		}
+		result = append(result, e)
+	}
+	return result
 }
 
-func truncate(s string, maxLen int) string {
-	if len(s) <= maxLen {
-		return s
+func extractTopTags(tags []analyzer.Hashtag, n int) []string {
+	result := make([]string, 0, n)
+	for i, tag := range tags {
+		if i >= n {
+			break
+		}
+		result = append(result, tag.Tag)
 	}
-	// Truncate at word boundary if possible
-	truncated := s[:maxLen-3]
-	lastSpace := strings.LastIndex(truncated, " ")
-	if lastSpace > maxLen/2 {
-		truncated = truncated[:lastSpace]
+	return result
+}
+
+// hookGroup is the hooks of one type.
+type hookGroup struct {
+	hookType  hooks.HookType
+	patterns  []string
+	videoIDs  []string // Videos using any of the patterns
+	frequency int      // Total uses of the patterns
+}
+
+// categorizeHooks groups hooks by type, in hooks.HookTypes order.
+func categorizeHooks(allHooks []hooks.Hook) []hookGroup {
+	var result []hookGroup
+	for _, t := range hooks.HookTypes {
+		group := hookGroup{hookType: t}
+		for _, h := range allHooks {
+			if h.Type != t || h.Frequency <= 0 {
+				continue
+			}
+			group.patterns = append(group.patterns, h.Pattern)
+			group.videoIDs = union(group.videoIDs, h.VideoIDs)
+			group.frequency += h.Frequency
+		}
+		if len(group.patterns) > 0 {
+			result = append(result, group)
+		}
 	}
-	return truncated + "..."
+	return result
 }
 
-func min(a, b int) int {
-	if a < b {
-		return a
+// joinOr joins items as "a", "a or b", or "a, b or c".
+func joinOr(items []string) string {
+	if len(items) <= 1 {
+		return strings.Join(items, "")
 	}
-	return b
+	return strings.Join(items[:len(items)-1], ", ") + " or " + items[len(items)-1]
+}
+
+// truncate shortens s to maxLen characters, counting user-perceived
+// characters rather than bytes so multi-byte runes are never split.
+func truncate(s string, maxLen int) string {
+	return text.TruncateGraphemes(s, maxLen)
 }
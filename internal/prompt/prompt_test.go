@@ -3,10 +3,13 @@ package prompt
 import (
 	"strings"
 	"testing"
+	"unicode/utf8"
 
 	"github.com/mikelady/kingmaker/internal/analyzer"
 	"github.com/mikelady/kingmaker/internal/hooks"
 	"github.com/mikelady/kingmaker/internal/keywords"
+	"github.com/mikelady/kingmaker/internal/model"
+	"github.com/mikelady/kingmaker/internal/target"
 )
 
 func TestGenerate_EmptyPatterns(t *testing.T) {
@@ -43,7 +46,7 @@ func TestGenerate_WithHooksAndKeywords(t *testing.T) {
 	}
 
 	// Check prompts contain relevant content
-	allPromptsText := strings.Join(prompts, " ")
+	allPromptsText := strings.Join(texts(prompts), " ")
 
 	// Should reference top keywords
 	if !strings.Contains(strings.ToLower(allPromptsText), "ai") {
@@ -91,8 +94,8 @@ func TestGenerate_PromptLength(t *testing.T) {
 	prompts := Generate(patterns, opts)
 
 	for i, p := range prompts {
-		if len(p) > 100 {
-			t.Errorf("prompt %d exceeds max length: %d > 100", i, len(p))
+		if len(p.Text) > 100 {
+			t.Errorf("prompt %d exceeds max length: %d > 100", i, len(p.Text))
 		}
 	}
 }
@@ -114,7 +117,7 @@ func TestGenerate_IncludesHashtags(t *testing.T) {
 	// At least one prompt should mention hashtags or trending topics
 	found := false
 	for _, p := range prompts {
-		lower := strings.ToLower(p)
+		lower := strings.ToLower(p.Text)
 		if strings.Contains(lower, "viral") || strings.Contains(lower, "trending") {
 			found = true
 			break
@@ -166,7 +169,7 @@ func TestPromptFormat(t *testing.T) {
 	// Prompts should be actionable for ClipAnything
 	for _, p := range prompts {
 		// Should not be empty
-		if strings.TrimSpace(p) == "" {
+		if strings.TrimSpace(p.Text) == "" {
 			t.Error("prompt should not be empty")
 		}
 		// Note: prompts may start with lowercase (e.g., action verbs) - this is acceptable
@@ -190,8 +193,375 @@ func TestGenerateWithQuery(t *testing.T) {
 	prompts := Generate(patterns, opts)
 
 	// Should incorporate query context
-	allText := strings.ToLower(strings.Join(prompts, " "))
+	allText := strings.ToLower(strings.Join(texts(prompts), " "))
 	if !strings.Contains(allText, "vibe") && !strings.Contains(allText, "coding") {
 		t.Error("expected prompts to incorporate query keywords")
 	}
 }
+
+func TestTruncate_DoesNotSplitRunes(t *testing.T) {
+	got := truncate("Encuentra momentos épicos sobre programación ñoña", 30)
+
+	if !utf8.ValidString(got) {
+		t.Errorf("truncate produced invalid UTF-8: %q", got)
+	}
+	if n := utf8.RuneCountInString(got); n > 30 {
+		t.Errorf("truncate returned %d characters, want <= 30", n)
+	}
+}
+
+func TestGenerate_TargetsCluster(t *testing.T) {
+	patterns := analyzer.Patterns{
+		TopKeywords: []keywords.Keyword{{Word: "ai", Frequency: 10}},
+		Clusters: []analyzer.TopicCluster{
+			{ID: 1, Size: 4, Keywords: []keywords.Keyword{{Word: "tutorial"}, {Word: "setup"}}},
+			{ID: 2, Size: 3, Keywords: []keywords.Keyword{{Word: "meme"}, {Word: "funny"}}},
+		},
+		VideoCount: 7,
+	}
+
+	prompts := Generate(patterns, Options{Cluster: 2})
+	all := strings.Join(texts(prompts), " ")
+
+	if !strings.Contains(all, "meme") {
+		t.Errorf("expected prompts about cluster 2 keywords, got %v", prompts)
+	}
+	if strings.Contains(all, "tutorial") {
+		t.Errorf("prompts should not use other cluster keywords, got %v", prompts)
+	}
+}
+
+func TestGenerate_UnknownClusterFallsBack(t *testing.T) {
+	patterns := analyzer.Patterns{
+		TopKeywords: []keywords.Keyword{{Word: "ai", Frequency: 10}},
+		VideoCount:  5,
+	}
+
+	prompts := Generate(patterns, Options{Cluster: 9})
+	if !strings.Contains(strings.Join(texts(prompts), " "), "ai") {
+		t.Errorf("expected fallback to whole result set, got %v", prompts)
+	}
+}
+
+func TestGenerate_PrefersKeyphrases(t *testing.T) {
+	patterns := analyzer.Patterns{
+		TopKeywords: []keywords.Keyword{
+			{Word: "vibe", Frequency: 10},
+			{Word: "coding", Frequency: 9},
+			{Word: "claude", Frequency: 5},
+		},
+		TopKeyphrases: []keywords.Keyphrase{
+			{Phrase: "vibe coding", Words: []string{"vibe", "coding"}, Frequency: 8},
+		},
+		VideoCount: 5,
+	}
+
+	prompts := Generate(patterns, DefaultOptions())
+	if len(prompts) == 0 {
+		t.Fatal("Generate() returned no prompts")
+	}
+	if !strings.Contains(prompts[0].Text, "vibe coding, claude") {
+		t.Errorf("expected phrase before remaining keywords, got %q", prompts[0].Text)
+	}
+	if strings.Contains(prompts[0].Text, "vibe, coding") {
+		t.Errorf("phrase should not be split into words, got %q", prompts[0].Text)
+	}
+}
+
+func TestExtractTopTerms(t *testing.T) {
+	phrases := []keywords.Keyphrase{
+		{Phrase: "vibe coding", Words: []string{"vibe", "coding"}},
+		{Phrase: "claude code", Words: []string{"claude", "code"}},
+	}
+	kws := []keywords.Keyword{{Word: "vibe"}, {Word: "cursor"}, {Word: "code"}}
+
+	got := extractTopTerms(phrases, kws, 3)
+	want := []string{"vibe coding", "claude code", "cursor"}
+	if strings.Join(got, "|") != strings.Join(want, "|") {
+		t.Errorf("extractTopTerms() = %v, want %v", got, want)
+	}
+
+	got = extractTopTerms(phrases, kws, 1)
+	if len(got) != 1 || got[0] != "vibe coding" {
+		t.Errorf("extractTopTerms(n=1) = %v, want [vibe coding]", got)
+	}
+}
+
+func TestGenerate_ClusterKeepsMatchingKeyphrases(t *testing.T) {
+	patterns := analyzer.Patterns{
+		TopKeyphrases: []keywords.Keyphrase{
+			{Phrase: "setup guide", Words: []string{"setup", "guide"}},
+			{Phrase: "funny meme", Words: []string{"funny", "meme"}},
+		},
+		Clusters: []analyzer.TopicCluster{
+			{ID: 1, Size: 4, Keywords: []keywords.Keyword{{Word: "setup"}}},
+		},
+		VideoCount: 7,
+	}
+
+	all := strings.Join(texts(Generate(patterns, Options{Cluster: 1})), " ")
+	if !strings.Contains(all, "setup guide") || strings.Contains(all, "funny meme") {
+		t.Errorf("expected only cluster keyphrases, got %q", all)
+	}
+}
+
+func TestGenerate_SkipsQueryTerms(t *testing.T) {
+	patterns := analyzer.Patterns{
+		TopKeywords: []keywords.Keyword{
+			{Word: "coding", Frequency: 10},
+			{Word: "code", Frequency: 9},
+			{Word: "cursor", Frequency: 5},
+			{Word: "claude", Frequency: 4},
+		},
+		TopKeyphrases: []keywords.Keyphrase{
+			{Phrase: "vibe coding", Words: []string{"vibe", "coding"}, Frequency: 8},
+		},
+		VideoCount: 10,
+	}
+
+	prompts := Generate(patterns, Options{Query: "vibe coding", MaxPrompts: 1})
+	if len(prompts) != 1 {
+		t.Fatalf("Generate() = %v, want one prompt", prompts)
+	}
+	want := "Find clips about vibe coding featuring discussions of cursor, claude with high energy moments"
+	if prompts[0].Text != want {
+		t.Errorf("prompt = %q, want %q", prompts[0].Text, want)
+	}
+}
+
+func TestGenerate_KeywordPairs(t *testing.T) {
+	patterns := analyzer.Patterns{
+		TopKeywords: []keywords.Keyword{{Word: "react", Frequency: 5}},
+		KeywordGraph: analyzer.KeywordGraph{
+			TopPairs: []analyzer.KeywordPair{
+				{Words: [2]string{"nextjs", "react"}, MedianViews: 80000},
+				{Words: [2]string{"coding", "vibe"}, MedianViews: 50000},
+				{Words: [2]string{"django", "python"}, MedianViews: 2000},
+			},
+		},
+		VideoCount: 6,
+	}
+
+	prompts := Generate(patterns, Options{Query: "vibe coding"})
+	if len(prompts) < 2 {
+		t.Fatalf("Generate() = %v, want a pair prompt", prompts)
+	}
+	want := "Find moments where the creator combines nextjs with react, django with python - the pairings that drive the most views"
+	if prompts[1].Text != want {
+		t.Errorf("pair prompt = %q, want %q", prompts[1].Text, want)
+	}
+}
+
+func TestGenerate_ClusterKeepsMatchingPairs(t *testing.T) {
+	patterns := analyzer.Patterns{
+		KeywordGraph: analyzer.KeywordGraph{
+			TopPairs: []analyzer.KeywordPair{
+				{Words: [2]string{"nextjs", "react"}},
+				{Words: [2]string{"django", "python"}},
+			},
+		},
+		Clusters: []analyzer.TopicCluster{
+			{ID: 1, Size: 3, Keywords: []keywords.Keyword{{Word: "python"}}},
+		},
+		VideoCount: 6,
+	}
+
+	all := strings.Join(texts(Generate(patterns, Options{Cluster: 1})), " ")
+	if !strings.Contains(all, "django with python") || strings.Contains(all, "nextjs") {
+		t.Errorf("expected only cluster pairs, got %q", all)
+	}
+}
+
+func TestGenerate_NamesEntities(t *testing.T) {
+	patterns := analyzer.Patterns{
+		TopKeywords: []keywords.Keyword{{Word: "agents", Frequency: 5}},
+		TopEntities: []analyzer.Entity{
+			{Name: "Cursor", Frequency: 6},
+			{Name: "ChatGPT", Frequency: 4},
+			{Name: "Sam Altman", Frequency: 2},
+		},
+		VideoCount: 6,
+	}
+
+	all := strings.Join(texts(Generate(patterns, Options{Query: "cursor ai"})), "\n")
+	want := "Find moments where the creator demos, compares or reacts to ChatGPT or Sam Altman - name the tool on screen"
+	if !strings.Contains(all, want) {
+		t.Errorf("expected entity prompt %q, got:\n%s", want, all)
+	}
+}
+
+func TestJoinOr(t *testing.T) {
+	tests := map[string][]string{
+		"":          nil,
+		"a":         {"a"},
+		"a or b":    {"a", "b"},
+		"a, b or c": {"a", "b", "c"},
+	}
+	for want, items := range tests {
+		if got := joinOr(items); got != want {
+			t.Errorf("joinOr(%v) = %q, want %q", items, got, want)
+		}
+	}
+}
+
+func TestHooksTemplate_EveryType(t *testing.T) {
+	seen := make(map[string]bool)
+	for _, typ := range hooks.HookTypes {
+		prompt, err := DefaultTemplates().Render(StrategyHooks, TemplateData{
+			HookType: typ.Name(),
+			Hooks:    []string{"pattern"},
+			Keywords: []string{"cursor"},
+		})
+		if err != nil {
+			t.Fatalf("Render() error: %v", err)
+		}
+		if prompt == "" {
+			t.Errorf("no prompt strategy for %s hooks", typ)
+			continue
+		}
+		if !strings.Contains(prompt, "cursor") {
+			t.Errorf("%s prompt %q should mention the keyword", typ, prompt)
+		}
+		if seen[prompt] {
+			t.Errorf("%s prompt %q duplicates another type's", typ, prompt)
+		}
+		seen[prompt] = true
+	}
+}
+
+func TestGenerate_Deterministic(t *testing.T) {
+	patterns := analyzer.Patterns{
+		TopHooks: []hooks.Hook{
+			{Type: hooks.Warning, Pattern: "don't", Frequency: 3},
+			{Type: hooks.Question, Pattern: "how", Frequency: 3},
+			{Type: hooks.Story, Pattern: "how i", Frequency: 3},
+			{Type: hooks.Numerical, Pattern: "numerical", Frequency: 3},
+			{Type: hooks.Comparison, Pattern: "vs", Frequency: 3},
+		},
+		TopKeywords: []keywords.Keyword{{Word: "cursor", Frequency: 2}},
+		VideoCount:  10,
+	}
+
+	first := texts(Generate(patterns, Options{MaxPrompts: 3}))
+	for i := 0; i < 20; i++ {
+		if got := texts(Generate(patterns, Options{MaxPrompts: 3})); strings.Join(got, "|") != strings.Join(first, "|") {
+			t.Fatalf("Generate() = %v, want %v on identical input", got, first)
+		}
+	}
+}
+
+func TestGenerate_RanksByEvidence(t *testing.T) {
+	patterns := analyzer.Patterns{
+		TopHooks: []hooks.Hook{
+			{Type: hooks.Question, Pattern: "how", Frequency: 2, VideoIDs: []string{"a", "b"}},
+			{Type: hooks.Warning, Pattern: "don't", Frequency: 3, VideoIDs: []string{"a", "b", "c"}},
+		},
+		TopKeywords: []keywords.Keyword{{Word: "cursor", Frequency: 1}},
+		VideoCount:  4,
+	}
+
+	prompts := Generate(patterns, DefaultOptions())
+	var order []string
+	for _, p := range prompts {
+		order = append(order, p.Strategy+":"+strings.Join(p.Hooks, ","))
+	}
+	want := []string{"hooks:don't", "hooks:how", "keywords:", "engagement:"}
+	if strings.Join(order, " ") != strings.Join(want, " ") {
+		t.Errorf("prompt order = %v, want %v", order, want)
+	}
+	if prompts[0].Evidence != 3 || strings.Join(prompts[0].VideoIDs, ",") != "a,b,c" {
+		t.Errorf("warning prompt evidence = %d %v, want 3 [a b c]", prompts[0].Evidence, prompts[0].VideoIDs)
+	}
+}
+
+func TestGenerate_CitesSupportingVideos(t *testing.T) {
+	patterns := analyzer.Patterns{
+		TopKeywords: []keywords.Keyword{
+			{Word: "coding", Frequency: 3, Variants: []string{"coding", "code"}},
+		},
+		TopHashtags: []analyzer.Hashtag{{Tag: "shorts", Frequency: 2}},
+		VideoCount:  3,
+	}
+	videos := []model.Video{
+		{ID: "a", Title: "Coding with AI", Description: "#shorts"},
+		{ID: "b", Title: "Clean code tips"},
+		{ID: "c", Title: "Morning routine", Description: "#Shorts #vlog"},
+	}
+
+	prompts := Generate(patterns, Options{Videos: videos})
+	byStrategy := make(map[string]Prompt)
+	for _, p := range prompts {
+		byStrategy[p.Strategy] = p
+	}
+
+	kw := byStrategy[StrategyKeywords]
+	if strings.Join(kw.VideoIDs, ",") != "a,b" || kw.Evidence != 2 {
+		t.Errorf("keyword prompt videos = %v (evidence %d), want [a b] via the variant", kw.VideoIDs, kw.Evidence)
+	}
+	if strings.Join(kw.Keywords, ",") != "coding" {
+		t.Errorf("keyword prompt keywords = %v, want [coding]", kw.Keywords)
+	}
+	trend := byStrategy[StrategyTrend]
+	if strings.Join(trend.VideoIDs, ",") != "a,c" || strings.Join(trend.Hashtags, ",") != "shorts" {
+		t.Errorf("trend prompt videos = %v hashtags = %v, want [a c] [shorts]", trend.VideoIDs, trend.Hashtags)
+	}
+}
+
+func TestRankPrompts_DropsNearDuplicates(t *testing.T) {
+	candidates := []Prompt{
+		{Text: "Find moments about cursor and claude with high energy", Strategy: StrategyKeywords, Evidence: 5},
+		{Text: "Find moments about cursor and claude with high energy!", Strategy: StrategyTrend, Evidence: 3},
+		{Text: "Clip warnings about mistakes to avoid", Strategy: StrategyHooks, Evidence: 4},
+	}
+
+	got := rankPrompts(candidates, 5, 280)
+	if len(got) != 2 || got[0].Strategy != StrategyKeywords || got[1].Strategy != StrategyHooks {
+		t.Errorf("rankPrompts() = %+v, want the keyword and hook prompts", got)
+	}
+}
+
+func texts(prompts []Prompt) []string {
+	result := make([]string, len(prompts))
+	for i, p := range prompts {
+		result[i] = p.Text
+	}
+	return result
+}
+
+func TestGenerate_Target(t *testing.T) {
+	patterns := analyzer.Patterns{
+		VideoCount:  10,
+		TopKeywords: []keywords.Keyword{{Word: "cursor", Frequency: 5}, {Word: "claude", Frequency: 4}},
+		TopHashtags: []analyzer.Hashtag{{Tag: "#ai", Frequency: 3}},
+		TopHooks:    []hooks.Hook{{Type: hooks.Numerical, Pattern: "numerical", Frequency: 2}},
+	}
+	klap, err := target.Parse(target.Klap)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	prompts := Generate(patterns, Options{MaxPromptLength: 500, Target: klap})
+	if len(prompts) == 0 {
+		t.Fatal("expected prompts")
+	}
+	for _, p := range prompts {
+		if p.Target != target.Klap {
+			t.Errorf("prompt %q labeled %q, want %q", p.Text, p.Target, target.Klap)
+		}
+		if len([]rune(p.Text)) > klap.MaxClipChars {
+			t.Errorf("prompt %q exceeds Klap's %d characters", p.Text, klap.MaxClipChars)
+		}
+		if strings.ContainsAny(p.Text, klap.Disallowed) {
+			t.Errorf("prompt %q keeps characters Klap rejects", p.Text)
+		}
+		if strings.HasPrefix(p.Text, "Find ") {
+			t.Errorf("prompt %q should describe clips rather than command", p.Text)
+		}
+	}
+
+	for _, p := range Generate(patterns, Options{}) {
+		if p.Target != target.OpusClip {
+			t.Errorf("default prompt labeled %q, want %q", p.Target, target.OpusClip)
+		}
+	}
+}
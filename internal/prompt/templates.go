@@ -0,0 +1,194 @@
+package prompt
+
+import (
+	"bytes"
+	"embed"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"text/template"
+
+	"github.com/mikelady/kingmaker/internal/analyzer"
+)
+
+//go:embed templates/*.tmpl
+var bundledTemplates embed.FS
+
+// templateExt is the file extension of prompt templates. A template's file
+// name without it is the strategy it phrases.
+const templateExt = ".tmpl"
+
+// TemplateData is what prompt templates are executed with.
+type TemplateData struct {
+	Query      string            // Original search query
+	Patterns   analyzer.Patterns // Patterns the prompts are generated from
+	Keywords   []string          // Top keywords and keyphrases, without query terms
+	Pairs      [][2]string       // Top keyword pairs (pairs template)
+	Entities   []string          // Top named tools, products and people (entities template)
+	Hashtags   []string          // Top hashtags
+	HookType   string            // Hook pack type name, e.g. "power_word" (hooks template)
+	Hooks      []string          // Hook patterns of that type (hooks template)
+	VideoCount int               // Number of videos analyzed
+}
+
+// templateFuncs are the functions available to prompt templates.
+var templateFuncs = template.FuncMap{
+	"join":   func(items []string, sep string) string { return strings.Join(items, sep) },
+	"joinOr": joinOr,
+	"first": func(n int, items []string) []string {
+		return items[:max(0, min(n, len(items)))]
+	},
+	"lower": strings.ToLower,
+	"upper": strings.ToUpper,
+}
+
+// Templates phrase the prompts of each strategy, one text/template per
+// strategy. Templates for strategies other than the built-in ones add
+// strategies of their own, rendered with the top keywords and hashtags.
+type Templates struct {
+	byStrategy map[string]*template.Template
+}
+
+// DefaultTemplates returns the bundled prompt templates.
+func DefaultTemplates() *Templates {
+	t := &Templates{byStrategy: make(map[string]*template.Template)}
+	entries, err := bundledTemplates.ReadDir("templates")
+	if err != nil {
+		panic(fmt.Sprintf("bundled prompt templates: %v", err))
+	}
+	for _, e := range entries {
+		data, err := bundledTemplates.ReadFile(path.Join("templates", e.Name()))
+		if err != nil {
+			panic(fmt.Sprintf("bundled prompt templates: %v", err))
+		}
+		template.Must(t.parse(strategyName(e.Name()), string(data)))
+	}
+	return t
+}
+
+// LoadTemplates returns the bundled prompt templates overridden by the
+// *.tmpl files in dir. Each file replaces the template of the strategy it
+// is named after (e.g., hooks.tmpl); other names add strategies. A file
+// that renders nothing disables its strategy.
+func LoadTemplates(dir string) (*Templates, error) {
+	paths, err := filepath.Glob(filepath.Join(dir, "*"+templateExt))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list prompt templates: %w", err)
+	}
+	if len(paths) == 0 {
+		if _, err := os.Stat(dir); err != nil {
+			return nil, fmt.Errorf("failed to read prompt templates: %w", err)
+		}
+		return nil, fmt.Errorf("no %s prompt templates in %s", templateExt, dir)
+	}
+
+	t := DefaultTemplates()
+	for _, p := range paths {
+		data, err := os.ReadFile(p)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read prompt template: %w", err)
+		}
+		if _, err := t.parse(strategyName(p), string(data)); err != nil {
+			return nil, fmt.Errorf("failed to parse prompt template %s: %w", p, err)
+		}
+	}
+
+	if err := t.validate(); err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+// parse sets the template of a strategy, replacing any previous one.
+func (t *Templates) parse(strategy, text string) (*template.Template, error) {
+	tmpl, err := template.New(strategy).Funcs(templateFuncs).Parse(text)
+	if err != nil {
+		return nil, err
+	}
+	t.byStrategy[strategy] = tmpl
+	return tmpl, nil
+}
+
+// strategyName is the strategy a template file phrases.
+func strategyName(file string) string {
+	return strings.TrimSuffix(filepath.Base(file), templateExt)
+}
+
+// Strategies returns the strategies with a template: the built-in ones in
+// tie-break order, then added ones by name.
+func (t *Templates) Strategies() []string {
+	var builtin, added []string
+	for name := range t.byStrategy {
+		if _, ok := strategyOrder[name]; ok {
+			builtin = append(builtin, name)
+		} else {
+			added = append(added, name)
+		}
+	}
+	sort.Slice(builtin, func(i, j int) bool {
+		return strategyOrder[builtin[i]] < strategyOrder[builtin[j]]
+	})
+	sort.Strings(added)
+	return append(builtin, added...)
+}
+
+// added returns the strategies that are not built in.
+func (t *Templates) added() []string {
+	var names []string
+	for _, name := range t.Strategies() {
+		if _, ok := strategyOrder[name]; !ok {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// Render phrases a strategy's prompt, collapsing whitespace so templates may
+// span lines. Strategies without a template render nothing.
+func (t *Templates) Render(strategy string, data TemplateData) (string, error) {
+	tmpl, ok := t.byStrategy[strategy]
+	if !ok {
+		return "", nil
+	}
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return "", fmt.Errorf("failed to render %s prompt: %w", strategy, err)
+	}
+	return strings.Join(strings.Fields(buf.String()), " "), nil
+}
+
+// render is Render for generation, where a template that fails on real data
+// yields no prompt; LoadTemplates has already rejected templates that fail
+// on sample data.
+func (t *Templates) render(strategy string, data TemplateData) string {
+	prompt, err := t.Render(strategy, data)
+	if err != nil {
+		return ""
+	}
+	return prompt
+}
+
+// validate renders every template with sample data, so that misspelled
+// fields and functions fail at load time rather than silently dropping
+// prompts.
+func (t *Templates) validate() error {
+	sample := TemplateData{
+		Query:      "query",
+		Keywords:   []string{"alpha", "beta", "gamma"},
+		Pairs:      [][2]string{{"alpha", "beta"}},
+		Entities:   []string{"Acme"},
+		Hashtags:   []string{"#alpha"},
+		HookType:   "question",
+		Hooks:      []string{"how"},
+		VideoCount: 1,
+	}
+	for _, strategy := range t.Strategies() {
+		if _, err := t.Render(strategy, sample); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,117 @@
+package prompt
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/mikelady/kingmaker/internal/analyzer"
+	"github.com/mikelady/kingmaker/internal/keywords"
+)
+
+func TestDefaultTemplates_Phrasing(t *testing.T) {
+	kws := []string{"cursor", "claude", "vibe coding", "nextjs"}
+	tests := []struct {
+		strategy string
+		data     TemplateData
+		want     string
+	}{
+		{StrategyKeywords, TemplateData{Keywords: kws}, "Find engaging moments where the creator discusses cursor, claude, vibe coding, nextjs with enthusiasm or excitement"},
+		{StrategyKeywords, TemplateData{Query: "ai coding", Keywords: kws[:2]}, "Find clips about ai coding featuring discussions of cursor, claude with high energy moments"},
+		{StrategyPairs, TemplateData{Pairs: [][2]string{{"cursor", "claude"}, {"react", "nextjs"}}}, "Find moments where the creator combines cursor with claude, react with nextjs - the pairings that drive the most views"},
+		{StrategyHooks, TemplateData{HookType: "numerical", Keywords: kws}, "Find segments with numbered tips, lists, or step-by-step explanations about cursor or claude"},
+		{StrategyHooks, TemplateData{HookType: "warning"}, "Extract warnings and mistakes to avoid, starting on the \"don't do this\" line"},
+		{StrategyTrend, TemplateData{Hashtags: []string{"#ai", "#coding"}, Keywords: kws}, "Find viral-worthy moments discussing trending topics like #ai, #coding with keywords cursor, claude, vibe coding"},
+		{StrategyTrend, TemplateData{Hashtags: []string{"#ai"}}, "Extract shareable clips covering trending topics: #ai"},
+		{StrategyEngagement, TemplateData{Keywords: kws}, "Find the most engaging moments with clear value delivery about cursor, claude, vibe coding - reactions, demonstrations, or aha moments"},
+	}
+	templates := DefaultTemplates()
+	for _, tt := range tests {
+		got, err := templates.Render(tt.strategy, tt.data)
+		if err != nil {
+			t.Fatalf("Render(%s) error: %v", tt.strategy, err)
+		}
+		if got != tt.want {
+			t.Errorf("Render(%s) =\n  %q\nwant\n  %q", tt.strategy, got, tt.want)
+		}
+	}
+}
+
+func TestDefaultTemplates_Strategies(t *testing.T) {
+	want := []string{StrategyKeywords, StrategyPairs, StrategyEntities, StrategyHooks, StrategyTrend, StrategyEngagement}
+	if got := DefaultTemplates().Strategies(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Strategies() = %v, want %v", got, want)
+	}
+}
+
+func writeTemplates(t *testing.T, files map[string]string) string {
+	t.Helper()
+	dir := t.TempDir()
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return dir
+}
+
+func TestLoadTemplates_OverridesAndAdds(t *testing.T) {
+	dir := writeTemplates(t, map[string]string{
+		"keywords.tmpl": "Clip {{ .Patterns.VideoCount }} videos'\n  take on {{ joinOr .Keywords }}",
+		"tutorial.tmpl": "Find step-by-step walkthroughs of {{ index .Keywords 0 }}",
+		"trend.tmpl":    "{{/* disabled */}}",
+		"notes.txt":     "not a template",
+	})
+	templates, err := LoadTemplates(dir)
+	if err != nil {
+		t.Fatalf("LoadTemplates() error: %v", err)
+	}
+
+	want := []string{StrategyKeywords, StrategyPairs, StrategyEntities, StrategyHooks, StrategyTrend, StrategyEngagement, "tutorial"}
+	if got := templates.Strategies(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Strategies() = %v, want %v", got, want)
+	}
+
+	patterns := analyzer.Patterns{
+		VideoCount:  4,
+		TopKeywords: []keywords.Keyword{{Word: "cursor", Frequency: 5}, {Word: "claude", Frequency: 3}},
+		TopHashtags: []analyzer.Hashtag{{Tag: "#ai", Frequency: 2}},
+	}
+	prompts := Generate(patterns, Options{MaxPrompts: 10, Templates: templates})
+
+	byStrategy := make(map[string]string)
+	for _, p := range prompts {
+		byStrategy[p.Strategy] = p.Text
+	}
+	if got, want := byStrategy[StrategyKeywords], "Clip 4 videos' take on cursor or claude"; got != want {
+		t.Errorf("keywords prompt = %q, want %q", got, want)
+	}
+	if got, want := byStrategy["tutorial"], "Find step-by-step walkthroughs of cursor"; got != want {
+		t.Errorf("tutorial prompt = %q, want %q", got, want)
+	}
+	if got, ok := byStrategy[StrategyTrend]; ok {
+		t.Errorf("empty trend template should disable the strategy, got %q", got)
+	}
+	if !strings.HasPrefix(byStrategy[StrategyEngagement], "Find the most engaging moments") {
+		t.Errorf("engagement prompt should keep the default template, got %q", byStrategy[StrategyEngagement])
+	}
+}
+
+func TestLoadTemplates_Errors(t *testing.T) {
+	tests := map[string]map[string]string{
+		"syntax":   {"keywords.tmpl": "Find {{ .Keywords"},
+		"function": {"keywords.tmpl": "Find {{ shout .Keywords }}"},
+		"field":    {"keywords.tmpl": "Find {{ .Topic }}"},
+		"empty":    {"readme.md": "no templates here"},
+	}
+	for name, files := range tests {
+		if _, err := LoadTemplates(writeTemplates(t, files)); err == nil {
+			t.Errorf("%s: LoadTemplates() should fail", name)
+		}
+	}
+	if _, err := LoadTemplates(filepath.Join(t.TempDir(), "missing")); err == nil {
+		t.Error("LoadTemplates(missing dir) should fail")
+	}
+}
@@ -0,0 +1,510 @@
+// Package score rates draft titles against the patterns learned from a
+// niche's videos and suggests concrete improvements.
+package score
+
+import (
+	"bufio"
+	"fmt"
+	"math"
+	"os"
+	"strings"
+	"unicode"
+
+	"github.com/mikelady/kingmaker/internal/analyzer"
+	"github.com/mikelady/kingmaker/internal/hooks"
+	"github.com/mikelady/kingmaker/internal/text"
+)
+
+// Criterion names, in report order.
+const (
+	CriterionLength      = "length"
+	CriterionHooks       = "hooks"
+	CriterionKeywords    = "keywords"
+	CriterionEntities    = "entities"
+	CriterionFormula     = "formula"
+	CriterionReadability = "readability"
+	CriterionTruncation  = "truncation"
+)
+
+// criterionWeights is each criterion's share of the overall score. Criteria
+// that do not apply to a niche (no entities, no recurring templates) are left
+// out and the rest are reweighted.
+var criterionWeights = map[string]float64{
+	CriterionLength:      0.15,
+	CriterionHooks:       0.25,
+	CriterionKeywords:    0.20,
+	CriterionEntities:    0.10,
+	CriterionFormula:     0.10,
+	CriterionReadability: 0.10,
+	CriterionTruncation:  0.10,
+}
+
+// SearchTitleChars is roughly where search results truncate titles.
+const SearchTitleChars = 70
+
+const (
+	maxReadableWords  = 14 // Titles with more words read as a sentence, not a title
+	minReadableWords  = 3  // Titles with fewer words rarely say what the video is
+	maxWordLength     = 13 // Words longer than this slow skimming
+	keywordsChecked   = 10 // Top keywords checked for coverage
+	keyphrasesChecked = 5  // Top keyphrases checked for coverage
+)
+
+// Criterion is one scored aspect of a draft title.
+type Criterion struct {
+	Name   string  // Criterion name (e.g., "hooks")
+	Score  float64 // 0.0 (poor) to 1.0 (matches what performs)
+	Weight float64 // Share of the overall score
+	Detail string  // What was measured
+}
+
+// Result is the score breakdown of one draft title.
+type Result struct {
+	Title       string
+	Score       int           // Overall score (0-100)
+	Criteria    []Criterion   // Per-criterion breakdown, in report order
+	Hooks       []hooks.Match // Hooks found in the draft
+	Suggestions []string      // Concrete changes that would raise the score
+}
+
+// Options configures scoring.
+type Options struct {
+	Hooks     *hooks.Matcher  // Hook definitions (default bundled hook packs)
+	Gazetteer *text.Gazetteer // Known entity names (default bundled gazetteer)
+}
+
+// DefaultOptions returns the default scoring options.
+func DefaultOptions() Options {
+	return Options{
+		Hooks:     hooks.DefaultMatcher(),
+		Gazetteer: text.DefaultGazetteer(),
+	}
+}
+
+// Titles scores each draft title against the niche patterns.
+func Titles(titles []string, patterns analyzer.Patterns, opts Options) []Result {
+	results := make([]Result, len(titles))
+	for i, title := range titles {
+		results[i] = Title(title, patterns, opts)
+	}
+	return results
+}
+
+// Title scores a draft title against the niche patterns.
+func Title(title string, patterns analyzer.Patterns, opts Options) Result {
+	if opts.Gazetteer == nil {
+		opts.Gazetteer = text.DefaultGazetteer()
+	}
+
+	title = strings.TrimSpace(title)
+	s := scorer{title: title, patterns: patterns, opts: opts}
+	s.result.Title = title
+	s.result.Hooks = opts.Hooks.Match(title)
+
+	s.scoreLength()
+	s.scoreHooks()
+	s.scoreKeywords()
+	s.scoreEntities()
+	s.scoreFormula()
+	s.scoreReadability()
+	s.scoreTruncation()
+
+	var total, weights float64
+	for _, c := range s.result.Criteria {
+		total += c.Score * c.Weight
+		weights += c.Weight
+	}
+	if weights > 0 {
+		s.result.Score = int(math.Round(total / weights * 100))
+	}
+	return s.result
+}
+
+// scorer accumulates the criteria and suggestions for one title.
+type scorer struct {
+	title    string
+	patterns analyzer.Patterns
+	opts     Options
+	result   Result
+}
+
+func (s *scorer) add(name string, score float64, detail string) {
+	s.result.Criteria = append(s.result.Criteria, Criterion{
+		Name:   name,
+		Score:  math.Max(0, math.Min(1, score)),
+		Weight: criterionWeights[name],
+		Detail: detail,
+	})
+}
+
+func (s *scorer) suggest(format string, args ...any) {
+	s.result.Suggestions = append(s.result.Suggestions, fmt.Sprintf(format, args...))
+}
+
+// scoreLength compares the title length with the range of the niche's top
+// performers, losing credit with distance from it.
+func (s *scorer) scoreLength() {
+	n := text.GraphemeCount(s.title)
+	tm := s.patterns.TitleMetrics
+	lo, hi := tm.TopLengthMin, tm.TopLengthMax
+	if hi == 0 {
+		lo, hi = tm.MinLength, tm.MaxLength
+	}
+	if hi == 0 {
+		s.add(CriterionLength, 1, fmt.Sprintf("%d characters (no niche data)", n))
+		return
+	}
+
+	target := fmt.Sprintf("%d-%d", lo, hi)
+	if lo == hi {
+		target = fmt.Sprintf("%d", lo)
+	}
+	detail := fmt.Sprintf("%d characters (top performers: %s)", n, target)
+	width := float64(max(hi-lo, 10))
+	switch {
+	case n < lo:
+		s.add(CriterionLength, 1-float64(lo-n)/width, detail)
+		s.suggest("Lengthen to %s characters (now %d)", target, n)
+	case n > hi:
+		s.add(CriterionLength, 1-float64(n-hi)/width, detail)
+		s.suggest("Shorten to %s characters (now %d)", target, n)
+	default:
+		s.add(CriterionLength, 1, detail)
+	}
+}
+
+// scoreHooks rewards hooks, most of all those whose titles outperform the
+// niche, and points at the best-performing hook when there is none.
+func (s *scorer) scoreHooks() {
+	best := hooks.BestPerforming(s.patterns.TopHooks, 1)
+	if len(s.result.Hooks) == 0 {
+		s.add(CriterionHooks, 0, "no hook")
+		switch {
+		case len(best) > 0:
+			s.suggest("Add a hook: %q (%s) titles get %.1fx the views, e.g. %q", best[0].Pattern, best[0].Type, best[0].Lift, firstExample(best[0]))
+		case len(s.patterns.TopHooks) > 0:
+			s.suggest("Add a hook such as %q (%s), common in this niche", s.patterns.TopHooks[0].Pattern, s.patterns.TopHooks[0].Type)
+		default:
+			s.suggest("Add a hook: a question, a number, or a bold claim")
+		}
+		return
+	}
+
+	score := 0.0
+	var found []string
+	var weakest *hooks.Hook
+	for _, m := range s.result.Hooks {
+		h, known := s.nicheHook(m)
+		switch {
+		case !known || h.Lift == 0:
+			found = append(found, fmt.Sprintf("%s (%s)", m.Pattern, m.Type))
+			score = math.Max(score, 0.7)
+		default:
+			found = append(found, fmt.Sprintf("%s (%s, %.1fx)", m.Pattern, m.Type, h.Lift))
+			switch {
+			case h.Lift >= 1.2:
+				score = math.Max(score, 1)
+			case h.Lift >= 1:
+				score = math.Max(score, 0.85)
+			default:
+				score = math.Max(score, 0.5)
+				if weakest == nil || h.Lift < weakest.Lift {
+					weakest = &h
+				}
+			}
+		}
+	}
+	s.add(CriterionHooks, score, strings.Join(dedupe(found), ", "))
+
+	if score < 0.85 && weakest != nil && len(best) > 0 && best[0].Pattern != weakest.Pattern {
+		s.suggest("%q titles underperform here (%.1fx); try %q (%.1fx)", weakest.Pattern, weakest.Lift, best[0].Pattern, best[0].Lift)
+	}
+}
+
+// nicheHook returns the niche's statistics for a matched hook.
+func (s *scorer) nicheHook(m hooks.Match) (hooks.Hook, bool) {
+	for _, h := range s.patterns.TopHooks {
+		if h.Type == m.Type && h.Pattern == m.Pattern {
+			return h, true
+		}
+	}
+	return hooks.Hook{}, false
+}
+
+// scoreKeywords checks which of the niche's top keywords and keyphrases the
+// title uses.
+func (s *scorer) scoreKeywords() {
+	candidates := s.keywordCandidates()
+	if len(candidates) == 0 {
+		return
+	}
+
+	tokens := text.Tokenize(s.title)
+	joined := " " + strings.Join(tokens, " ") + " "
+	var used, missing []string
+	for _, c := range candidates {
+		hit := false
+		for _, form := range c.forms {
+			if strings.Contains(joined, " "+form+" ") {
+				hit = true
+				break
+			}
+		}
+		if hit {
+			used = append(used, c.label)
+		} else {
+			missing = append(missing, c.label)
+		}
+	}
+
+	switch len(used) {
+	case 0:
+		s.add(CriterionKeywords, 0, "no top keywords")
+		s.suggest("Work in a top keyword: %s", joinOr(missing[:min(3, len(missing))]))
+	case 1:
+		s.add(CriterionKeywords, 0.7, "uses "+used[0])
+	default:
+		s.add(CriterionKeywords, 1, "uses "+strings.Join(used, ", "))
+	}
+}
+
+// keywordCandidate is a keyword or keyphrase with the forms it may take.
+type keywordCandidate struct {
+	label string
+	forms []string // Lowercase, space-joined token sequences
+}
+
+func (s *scorer) keywordCandidates() []keywordCandidate {
+	var candidates []keywordCandidate
+	for i, kp := range s.patterns.TopKeyphrases {
+		if i >= keyphrasesChecked {
+			break
+		}
+		candidates = append(candidates, keywordCandidate{label: kp.Phrase, forms: []string{strings.ToLower(kp.Phrase)}})
+	}
+	for i, kw := range s.patterns.TopKeywords {
+		if i >= keywordsChecked {
+			break
+		}
+		forms := []string{strings.ToLower(kw.Word)}
+		for _, v := range kw.Variants {
+			forms = append(forms, strings.ToLower(v))
+		}
+		candidates = append(candidates, keywordCandidate{label: kw.Word, forms: forms})
+	}
+	return candidates
+}
+
+// scoreEntities checks whether the title names a tool, product or person the
+// niche talks about. The criterion only applies when naming one pays off: the
+// title names a niche entity, or some niche entity outperforms.
+func (s *scorer) scoreEntities() {
+	top := s.patterns.TopEntities
+	var named, other []string
+	for _, m := range text.ExtractEntities(s.title, s.opts.Gazetteer) {
+		found := false
+		for _, e := range top {
+			if strings.EqualFold(e.Name, m.Name) {
+				found = true
+				break
+			}
+		}
+		switch {
+		case found:
+			named = append(named, m.Name)
+		case m.Known:
+			other = append(other, m.Name)
+		}
+	}
+	if len(named) > 0 {
+		s.add(CriterionEntities, 1, "names "+strings.Join(dedupe(named), ", "))
+		return
+	}
+
+	var best *analyzer.Entity
+	for i := range top {
+		if top[i].Lift > 1 && (best == nil || top[i].Lift > best.Lift) {
+			best = &top[i]
+		}
+	}
+	if best == nil {
+		return
+	}
+
+	if len(other) > 0 {
+		s.add(CriterionEntities, 0.5, "names "+strings.Join(dedupe(other), ", ")+" (not a niche favorite)")
+	} else {
+		s.add(CriterionEntities, 0, "no named tool, product or person")
+	}
+	s.suggest("Name a tool the niche responds to: %s (median %d views, %.1fx)", best.Name, best.MedianViews, best.Lift)
+}
+
+// scoreFormula checks whether the title follows one of the niche's recurring
+// title templates. Niches without templates skip this criterion.
+func (s *scorer) scoreFormula() {
+	templates := s.patterns.TitleTemplates
+	if len(templates) == 0 {
+		return
+	}
+
+	if t, ok := analyzer.MatchTemplate(s.title, templates, s.opts.Gazetteer); ok {
+		s.add(CriterionFormula, 1, fmt.Sprintf("follows %q (%d videos, median %d views)", t.Template, t.Count, t.MedianViews))
+		return
+	}
+
+	best := templates[0]
+	for _, t := range templates[1:] {
+		if t.MedianViews > best.MedianViews {
+			best = t
+		}
+	}
+	s.add(CriterionFormula, 0.3, "no recurring formula")
+	s.suggest("Try a proven formula: %q (median %d views), e.g. %q", best.Template, best.MedianViews, firstOf(best.Examples))
+}
+
+// scoreReadability penalizes titles that are hard to skim: too many or too
+// few words, very long words and shouting.
+func (s *scorer) scoreReadability() {
+	words := strings.Fields(s.title)
+	score := 1.0
+	var issues []string
+
+	if len(words) > maxReadableWords {
+		score -= 0.3
+		issues = append(issues, fmt.Sprintf("%d words", len(words)))
+		s.suggest("Cut to %d words or fewer (now %d)", maxReadableWords, len(words))
+	}
+	if len(words) < minReadableWords {
+		score -= 0.3
+		issues = append(issues, fmt.Sprintf("only %d words", len(words)))
+		s.suggest("Say what the video is about in at least %d words", minReadableWords)
+	}
+
+	var long, shouted []string
+	for _, w := range words {
+		core := strings.TrimFunc(w, func(r rune) bool { return !unicode.IsLetter(r) && !unicode.IsDigit(r) })
+		if text.GraphemeCount(core) > maxWordLength && !strings.ContainsAny(core, ".-") {
+			long = append(long, core)
+		}
+		if isShouted(core) {
+			shouted = append(shouted, core)
+		}
+	}
+	if len(long) > 0 {
+		score -= 0.2 * float64(len(long))
+		issues = append(issues, "long words: "+strings.Join(long, ", "))
+		s.suggest("Swap long words for shorter ones: %s", strings.Join(long, ", "))
+	}
+	if len(shouted) > 1 {
+		score -= 0.15 * float64(len(shouted)-1)
+		issues = append(issues, fmt.Sprintf("%d all-caps words", len(shouted)))
+		s.suggest("Keep ALL CAPS to one word for emphasis (now %d)", len(shouted))
+	}
+
+	detail := fmt.Sprintf("%d words", len(words))
+	if len(issues) > 0 {
+		detail = strings.Join(issues, "; ")
+	}
+	s.add(CriterionReadability, score, detail)
+}
+
+// isShouted reports whether a word of four or more letters is all uppercase.
+// Shorter all-caps words are usually acronyms (AI, AWS).
+func isShouted(word string) bool {
+	letters := 0
+	for _, r := range word {
+		if unicode.IsLetter(r) {
+			if !unicode.IsUpper(r) {
+				return false
+			}
+			letters++
+		}
+	}
+	return letters >= 4
+}
+
+// scoreTruncation checks that the hook survives mobile truncation and the
+// title fits in search results.
+func (s *scorer) scoreTruncation() {
+	n := text.GraphemeCount(s.title)
+	if n <= analyzer.MobileTitleChars {
+		s.add(CriterionTruncation, 1, fmt.Sprintf("fits in %d characters", analyzer.MobileTitleChars))
+		return
+	}
+
+	score := 1.0
+	var issues []string
+	if len(s.result.Hooks) > 0 && s.result.Hooks[0].Char >= analyzer.MobileTitleChars {
+		score -= 0.5
+		issues = append(issues, fmt.Sprintf("first hook at character %d", s.result.Hooks[0].Char))
+		s.suggest("Move %q into the first %d characters; mobile feeds cut the rest", s.result.Hooks[0].Pattern, analyzer.MobileTitleChars)
+	}
+	if n > SearchTitleChars {
+		score -= 0.3
+		issues = append(issues, fmt.Sprintf("over %d characters", SearchTitleChars))
+		s.suggest("Keep it under %d characters so search results show it whole", SearchTitleChars)
+	}
+
+	detail := fmt.Sprintf("hook visible in the first %d characters", analyzer.MobileTitleChars)
+	if len(s.result.Hooks) == 0 {
+		detail = fmt.Sprintf("%d characters, cut after %d on mobile", n, analyzer.MobileTitleChars)
+	}
+	if len(issues) > 0 {
+		detail = strings.Join(issues, "; ")
+	}
+	s.add(CriterionTruncation, score, detail)
+}
+
+// LoadDrafts reads draft titles from a file, one per line. Blank lines are
+// skipped.
+func LoadDrafts(path string) ([]string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading drafts: %w", err)
+	}
+	defer f.Close()
+
+	var titles []string
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		if line := strings.TrimSpace(scanner.Text()); line != "" {
+			titles = append(titles, line)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("reading drafts %s: %w", path, err)
+	}
+	return titles, nil
+}
+
+func firstExample(h hooks.Hook) string {
+	return firstOf(h.Examples)
+}
+
+func firstOf(items []string) string {
+	if len(items) == 0 {
+		return ""
+	}
+	return items[0]
+}
+
+// dedupe removes repeated items, keeping the first occurrence.
+func dedupe(items []string) []string {
+	seen := make(map[string]bool, len(items))
+	out := items[:0]
+	for _, item := range items {
+		if !seen[item] {
+			seen[item] = true
+			out = append(out, item)
+		}
+	}
+	return out
+}
+
+// joinOr joins items as "a", "a or b", or "a, b or c".
+func joinOr(items []string) string {
+	if len(items) <= 1 {
+		return strings.Join(items, "")
+	}
+	return strings.Join(items[:len(items)-1], ", ") + " or " + items[len(items)-1]
+}
@@ -0,0 +1,161 @@
+package score
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/mikelady/kingmaker/internal/analyzer"
+	"github.com/mikelady/kingmaker/internal/hooks"
+	"github.com/mikelady/kingmaker/internal/keywords"
+)
+
+func nichePatterns() analyzer.Patterns {
+	return analyzer.Patterns{
+		TopHooks: []hooks.Hook{
+			{Type: hooks.PowerWord, Pattern: "secret", Frequency: 6, Lift: 2.5, Examples: []string{"The Secret Go Feature"}},
+			{Type: hooks.TimeBound, Pattern: "today", Frequency: 4, Lift: 0.6},
+		},
+		TopKeywords: []keywords.Keyword{
+			{Word: "golang", Frequency: 12, Variants: []string{"go"}},
+			{Word: "concurrency", Frequency: 5},
+		},
+		TopEntities: []analyzer.Entity{
+			{Name: "VS Code", Frequency: 4, MedianViews: 9000, Lift: 2},
+		},
+		TitleMetrics: analyzer.TitleMetrics{MinLength: 10, MaxLength: 80, TopLengthMin: 20, TopLengthMax: 40},
+		TitleTemplates: []analyzer.TitleTemplate{
+			{Template: "[N] mistakes beginners make", Count: 3, MedianViews: 5000, Examples: []string{"5 Mistakes Beginners Make"}},
+		},
+	}
+}
+
+func criterion(t *testing.T, r Result, name string) Criterion {
+	t.Helper()
+	for _, c := range r.Criteria {
+		if c.Name == name {
+			return c
+		}
+	}
+	t.Fatalf("no %s criterion in %+v", name, r.Criteria)
+	return Criterion{}
+}
+
+func TestTitle_Strong(t *testing.T) {
+	r := Title("The Secret Go Concurrency Trick in VS Code", nichePatterns(), DefaultOptions())
+
+	for _, name := range []string{CriterionHooks, CriterionKeywords, CriterionEntities, CriterionReadability} {
+		if c := criterion(t, r, name); c.Score != 1 {
+			t.Errorf("%s score = %v (%s), want 1", name, c.Score, c.Detail)
+		}
+	}
+	if r.Score < 80 {
+		t.Errorf("Score = %d, want at least 80 (%+v)", r.Score, r.Criteria)
+	}
+}
+
+func TestTitle_Weak(t *testing.T) {
+	r := Title("Refactoring", nichePatterns(), DefaultOptions())
+
+	if r.Score > 40 {
+		t.Errorf("Score = %d, want at most 40 (%+v)", r.Score, r.Criteria)
+	}
+	if c := criterion(t, r, CriterionHooks); c.Score != 0 {
+		t.Errorf("hooks score = %v, want 0", c.Score)
+	}
+	joined := strings.Join(r.Suggestions, "\n")
+	for _, want := range []string{`"secret"`, "Lengthen to 20-40", "VS Code", "golang"} {
+		if !strings.Contains(joined, want) {
+			t.Errorf("suggestions missing %q:\n%s", want, joined)
+		}
+	}
+}
+
+func TestTitle_UnderperformingHook(t *testing.T) {
+	r := Title("Update Your Golang Setup Today", nichePatterns(), DefaultOptions())
+
+	if c := criterion(t, r, CriterionHooks); c.Score != 0.5 {
+		t.Errorf("hooks score = %v, want 0.5 for a hook with lift below 1", c.Score)
+	}
+	joined := strings.Join(r.Suggestions, "\n")
+	if !strings.Contains(joined, `"today" titles underperform`) {
+		t.Errorf("expected a suggestion to replace \"today\", got:\n%s", joined)
+	}
+}
+
+func TestTitle_Formula(t *testing.T) {
+	r := Title("7 Mistakes Beginners Make", nichePatterns(), DefaultOptions())
+	if c := criterion(t, r, CriterionFormula); c.Score != 1 {
+		t.Errorf("formula score = %v (%s), want 1", c.Score, c.Detail)
+	}
+}
+
+func TestTitle_Truncation(t *testing.T) {
+	title := "Channels and Goroutines Explained for Busy Developers: The Secret Trick Nobody Uses"
+	r := Title(title, nichePatterns(), DefaultOptions())
+
+	c := criterion(t, r, CriterionTruncation)
+	if c.Score != 0.2 {
+		t.Errorf("truncation score = %v (%s), want 0.2 for a late hook in an overlong title", c.Score, c.Detail)
+	}
+	joined := strings.Join(r.Suggestions, "\n")
+	if !strings.Contains(joined, `Move "secret" into the first 40 characters`) {
+		t.Errorf("expected a suggestion to move the hook, got:\n%s", joined)
+	}
+}
+
+func TestTitle_Readability(t *testing.T) {
+	r := Title("STOP Writing UNREADABLE Golang Internationalization Code", nichePatterns(), DefaultOptions())
+
+	c := criterion(t, r, CriterionReadability)
+	if c.Score >= 1 {
+		t.Errorf("readability score = %v (%s), want a penalty for shouting and long words", c.Score, c.Detail)
+	}
+	if !strings.Contains(c.Detail, "2 all-caps words") || !strings.Contains(c.Detail, "Internationalization") {
+		t.Errorf("readability detail = %q", c.Detail)
+	}
+}
+
+func TestTitle_SkipsInapplicableCriteria(t *testing.T) {
+	r := Title("The Secret Go Trick", analyzer.Patterns{}, DefaultOptions())
+
+	var names []string
+	for _, c := range r.Criteria {
+		names = append(names, c.Name)
+	}
+	want := []string{CriterionLength, CriterionHooks, CriterionReadability, CriterionTruncation}
+	if !reflect.DeepEqual(names, want) {
+		t.Errorf("criteria = %v, want %v", names, want)
+	}
+	if r.Score < 85 {
+		t.Errorf("Score = %d, want at least 85 when only applicable criteria count", r.Score)
+	}
+}
+
+func TestTitles(t *testing.T) {
+	results := Titles([]string{"One", "Two"}, nichePatterns(), DefaultOptions())
+	if len(results) != 2 || results[0].Title != "One" || results[1].Title != "Two" {
+		t.Errorf("Titles() = %+v, want one result per draft in order", results)
+	}
+}
+
+func TestLoadDrafts(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "drafts.txt")
+	if err := os.WriteFile(path, []byte("#1 Go Mistake\n\n  Secret Go Tips  \n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := LoadDrafts(path)
+	if err != nil {
+		t.Fatalf("LoadDrafts() error = %v", err)
+	}
+	if want := []string{"#1 Go Mistake", "Secret Go Tips"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("LoadDrafts() = %v, want %v", got, want)
+	}
+
+	if _, err := LoadDrafts(filepath.Join(t.TempDir(), "missing.txt")); err == nil {
+		t.Error("LoadDrafts() of a missing file should fail")
+	}
+}
@@ -0,0 +1,62 @@
+// Package stats provides the summary statistics shared by the analyzers:
+// medians and the view and engagement aggregates of video sets.
+package stats
+
+import (
+	"slices"
+
+	"github.com/mikelady/kingmaker/internal/model"
+)
+
+// Number is a numeric type Median can average.
+type Number interface {
+	~int | ~int64 | ~float64
+}
+
+// Median returns the median of values, averaging the two middle values for
+// even-length input. The input slice is sorted in place.
+func Median[T Number](values []T) T {
+	var zero T
+	if len(values) == 0 {
+		return zero
+	}
+
+	slices.Sort(values)
+
+	mid := len(values) / 2
+	if len(values)%2 == 0 {
+		return (values[mid-1] + values[mid]) / 2
+	}
+	return values[mid]
+}
+
+// MedianViews returns the median view count of videos.
+func MedianViews(videos []model.Video) int64 {
+	views := make([]int64, len(videos))
+	for i, v := range videos {
+		views[i] = v.ViewCount
+	}
+	return Median(views)
+}
+
+// MeanViews returns the mean view count of videos.
+func MeanViews(videos []model.Video) float64 {
+	if len(videos) == 0 {
+		return 0
+	}
+	var total float64
+	for _, v := range videos {
+		total += float64(v.ViewCount)
+	}
+	return total / float64(len(videos))
+}
+
+// MedianEngagement returns the median like-to-view ratio of videos as a
+// percentage.
+func MedianEngagement(videos []model.Video) float64 {
+	rates := make([]float64, len(videos))
+	for i := range videos {
+		rates[i] = videos[i].EngagementRate()
+	}
+	return Median(rates)
+}
@@ -0,0 +1,64 @@
+package stats
+
+import (
+	"math"
+	"testing"
+
+	"github.com/mikelady/kingmaker/internal/model"
+)
+
+func TestMedian(t *testing.T) {
+	tests := []struct {
+		name   string
+		values []int64
+		want   int64
+	}{
+		{"empty", nil, 0},
+		{"single", []int64{7}, 7},
+		{"odd", []int64{5, 1, 3}, 3},
+		{"even", []int64{4, 1, 3, 2}, 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Median(tt.values); got != tt.want {
+				t.Errorf("Median(%v) = %d, want %d", tt.values, got, tt.want)
+			}
+		})
+	}
+
+	if got := Median([]float64{1, 2}); got != 1.5 {
+		t.Errorf("Median(float64) = %v, want 1.5", got)
+	}
+}
+
+func TestMedianViews(t *testing.T) {
+	videos := []model.Video{{ViewCount: 10}, {ViewCount: 30}, {ViewCount: 20}}
+	if got := MedianViews(videos); got != 20 {
+		t.Errorf("MedianViews = %d, want 20", got)
+	}
+	if got := MedianViews(nil); got != 0 {
+		t.Errorf("MedianViews(nil) = %d, want 0", got)
+	}
+}
+
+func TestMeanViews(t *testing.T) {
+	videos := []model.Video{{ViewCount: 10}, {ViewCount: 30}, {ViewCount: 35}}
+	if got := MeanViews(videos); got != 25 {
+		t.Errorf("MeanViews = %v, want 25", got)
+	}
+	if got := MeanViews(nil); got != 0 {
+		t.Errorf("MeanViews(nil) = %v, want 0", got)
+	}
+}
+
+func TestMedianEngagement(t *testing.T) {
+	videos := []model.Video{
+		{ViewCount: 100, LikeCount: 1},
+		{ViewCount: 100, LikeCount: 5},
+		{ViewCount: 0, LikeCount: 5},
+	}
+	if got := MedianEngagement(videos); math.Abs(got-1) > 1e-9 {
+		t.Errorf("MedianEngagement = %v, want 1", got)
+	}
+}
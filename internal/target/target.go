@@ -0,0 +1,180 @@
+// Package target describes the clipping tools kingmaker writes prompts for:
+// how each tool expects prompts to be phrased and the length and character
+// limits of its prompt box.
+package target
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/mikelady/kingmaker/internal/text"
+)
+
+// Target names, as given on the command line.
+const (
+	OpusClip = "opusclip"
+	Vizard   = "vizard"
+	Klap     = "klap"
+	Descript = "descript"
+	CapCut   = "capcut"
+)
+
+// Target is a clipping tool prompts are written for.
+type Target struct {
+	Name            string // Command-line name (e.g., "opusclip")
+	Label           string // Display name (e.g., "OpusClip")
+	ClipFeature     string // Feature clip prompts are pasted into
+	MetadataFeature string // Feature metadata prompts are pasted into
+	MaxClipChars    int    // Maximum clip prompt length in characters
+	MaxMetaChars    int    // Maximum metadata prompt length in characters (0 = no limit)
+	Disallowed      string // Characters the prompt box rejects; removed from prompts
+	Style           string // How the tool wants prompts phrased, for LLM-written prompts
+
+	rewrite func(string) string // Adapts a clip prompt to the tool's conventions (nil = as is)
+}
+
+// targets are the supported tools. OpusClip is the default.
+var targets = []Target{
+	{
+		Name:            OpusClip,
+		Label:           "OpusClip",
+		ClipFeature:     "ClipAnything",
+		MetadataFeature: "create-default",
+		MaxClipChars:    280,
+	},
+	{
+		Name:            Vizard,
+		Label:           "Vizard",
+		ClipFeature:     "AI clipping prompt",
+		MetadataFeature: "caption instructions",
+		MaxClipChars:    200,
+		MaxMetaChars:    1000,
+		Disallowed:      "<>{}",
+		Style:           "Describe the clips and titles wanted rather than giving step-by-step commands.",
+		rewrite:         describe,
+	},
+	{
+		Name:            Klap,
+		Label:           "Klap",
+		ClipFeature:     "topic prompt",
+		MetadataFeature: "custom instructions",
+		MaxClipChars:    100,
+		MaxMetaChars:    500,
+		Disallowed:      "\"<>{}#",
+		Style:           "Use short, plain sentences; the tool works from topics, not detailed instructions.",
+		rewrite:         func(s string) string { return describe(mainClause(s)) },
+	},
+	{
+		Name:            Descript,
+		Label:           "Descript",
+		ClipFeature:     "Underlord clip request",
+		MetadataFeature: "Underlord instructions",
+		MaxClipChars:    500,
+		Disallowed:      "<>",
+		Style:           "Write it as a request to an editing assistant, in the second person.",
+	},
+	{
+		Name:            CapCut,
+		Label:           "CapCut",
+		ClipFeature:     "long-video-to-shorts prompt",
+		MetadataFeature: "AI caption prompt",
+		MaxClipChars:    150,
+		MaxMetaChars:    300,
+		Disallowed:      "\"<>{}#@",
+		Style:           "Keep it to one or two short sentences without hashtags or quotes.",
+		rewrite:         describe,
+	},
+}
+
+// Default returns the default target, OpusClip.
+func Default() Target {
+	return targets[0]
+}
+
+// Names returns the command-line names of the supported targets.
+func Names() []string {
+	names := make([]string, len(targets))
+	for i, t := range targets {
+		names[i] = t.Name
+	}
+	return names
+}
+
+// Parse returns the target with a command-line name. An empty name is the
+// default target.
+func Parse(name string) (Target, error) {
+	name = strings.ToLower(strings.TrimSpace(name))
+	if name == "" {
+		return Default(), nil
+	}
+	for _, t := range targets {
+		if t.Name == name {
+			return t, nil
+		}
+	}
+	return Target{}, fmt.Errorf("unknown target %q (available: %s)", name, strings.Join(Names(), ", "))
+}
+
+// OrDefault returns t, or the default target for the zero Target.
+func (t Target) OrDefault() Target {
+	if t.Name == "" {
+		return Default()
+	}
+	return t
+}
+
+// Format adapts a clip prompt to the tool: it rephrases it in the tool's
+// convention, removes disallowed characters, collapses whitespace and
+// truncates it to the tool's limit.
+func (t Target) Format(prompt string) string {
+	if t.rewrite != nil {
+		prompt = t.rewrite(prompt)
+	}
+	prompt = strings.Join(strings.Fields(t.Clean(prompt)), " ")
+	if t.MaxClipChars > 0 {
+		prompt = text.TruncateGraphemes(prompt, t.MaxClipChars)
+	}
+	return prompt
+}
+
+// Clean removes the characters the tool rejects and surrounding whitespace.
+func (t Target) Clean(prompt string) string {
+	if t.Disallowed != "" {
+		prompt = strings.Map(func(r rune) rune {
+			if strings.ContainsRune(t.Disallowed, r) {
+				return -1
+			}
+			return r
+		}, prompt)
+	}
+	return strings.TrimSpace(prompt)
+}
+
+// imperatives are the leading verbs of command-style prompts.
+var imperatives = map[string]bool{
+	"find":    true,
+	"clip":    true,
+	"extract": true,
+	"show":    true,
+}
+
+// describe turns a command ("Find moments where...") into a description of
+// the clips ("Moments where..."). Prompts in other forms are kept.
+func describe(prompt string) string {
+	verb, rest, ok := strings.Cut(strings.TrimSpace(prompt), " ")
+	if !ok || !imperatives[strings.ToLower(verb)] || rest == "" {
+		return prompt
+	}
+	first, size := utf8.DecodeRuneInString(rest)
+	return string(unicode.ToUpper(first)) + rest[size:]
+}
+
+// mainClause drops a trailing " - " aside, keeping what the clips are about.
+func mainClause(prompt string) string {
+	if clause, _, ok := strings.Cut(prompt, " - "); ok && clause != "" {
+		return clause
+	}
+	return prompt
+}
@@ -0,0 +1,101 @@
+package target
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/mikelady/kingmaker/internal/text"
+)
+
+func TestParse(t *testing.T) {
+	for _, name := range Names() {
+		got, err := Parse(strings.ToUpper(name))
+		if err != nil || got.Name != name {
+			t.Errorf("Parse(%q) = %q, %v; want %q", strings.ToUpper(name), got.Name, err, name)
+		}
+	}
+	if got, err := Parse(""); err != nil || got.Name != OpusClip {
+		t.Errorf("Parse(\"\") = %q, %v; want the default %q", got.Name, err, OpusClip)
+	}
+	if _, err := Parse("premiere"); err == nil || !strings.Contains(err.Error(), "vizard") {
+		t.Errorf("Parse(unknown) error = %v, want one listing the targets", err)
+	}
+}
+
+func TestTargets_Complete(t *testing.T) {
+	want := []string{OpusClip, Vizard, Klap, Descript, CapCut}
+	if got := Names(); strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Errorf("Names() = %v, want %v", got, want)
+	}
+	for _, name := range want {
+		tool, _ := Parse(name)
+		if tool.Label == "" || tool.ClipFeature == "" || tool.MetadataFeature == "" || tool.MaxClipChars <= 0 {
+			t.Errorf("%s: incomplete target %+v", name, tool)
+		}
+	}
+}
+
+func TestOrDefault(t *testing.T) {
+	if got := (Target{}).OrDefault(); got.Name != OpusClip {
+		t.Errorf("zero Target.OrDefault() = %q, want %q", got.Name, OpusClip)
+	}
+	klap, _ := Parse(Klap)
+	if got := klap.OrDefault(); got.Name != Klap {
+		t.Errorf("OrDefault() = %q, want %q", got.Name, Klap)
+	}
+}
+
+func TestFormat(t *testing.T) {
+	prompt := "Find moments where the creator combines cursor with claude - the pairings that drive the most views"
+	tests := map[string]string{
+		OpusClip: prompt,
+		Descript: prompt,
+		Vizard:   "Moments where the creator combines cursor with claude - the pairings that drive the most views",
+		Klap:     "Moments where the creator combines cursor with claude",
+	}
+	for name, want := range tests {
+		tool, _ := Parse(name)
+		if got := tool.Format(prompt); got != want {
+			t.Errorf("%s Format() = %q, want %q", name, got, want)
+		}
+	}
+}
+
+func TestFormat_Constraints(t *testing.T) {
+	prompt := `Extract warnings about #ai {agents}, starting on the "don't do this" line ` + strings.Repeat("and more ", 30)
+	for _, name := range Names() {
+		tool, _ := Parse(name)
+		got := tool.Format(prompt)
+		if n := text.GraphemeCount(got); n > tool.MaxClipChars {
+			t.Errorf("%s: %d characters, over the %d limit", name, n, tool.MaxClipChars)
+		}
+		if strings.ContainsAny(got, tool.Disallowed) {
+			t.Errorf("%s: %q keeps disallowed characters %q", name, got, tool.Disallowed)
+		}
+		if strings.Contains(got, "  ") {
+			t.Errorf("%s: %q has doubled spaces", name, got)
+		}
+	}
+}
+
+func TestDescribe(t *testing.T) {
+	tests := map[string]string{
+		"Clip quick wins":              "Quick wins",
+		"Find clips about go":          "Clips about go",
+		"Moments where it breaks":      "Moments where it breaks",
+		"Find":                         "Find",
+		"Highlight the demo, find fun": "Highlight the demo, find fun",
+	}
+	for in, want := range tests {
+		if got := describe(in); got != want {
+			t.Errorf("describe(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestClean_KeepsLines(t *testing.T) {
+	capcut, _ := Parse(CapCut)
+	if got, want := capcut.Clean("  Use \"hooks\"\nwith #tags  "), "Use hooks\nwith tags"; got != want {
+		t.Errorf("Clean() = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,189 @@
+package text
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+)
+
+var emailRegex = regexp.MustCompile(`(?i)\b[\w.+-]+@[\w-]+(?:\.[\w-]+)*\.[a-z]{2,}\b`)
+var mentionRegex = regexp.MustCompile(`(^|[^\w@])@[\w.]*\w`)
+var timestampRegex = regexp.MustCompile(`\b(?:\d{1,2}:)?\d{1,2}:[0-5]\d\b`)
+
+// bareLinkRegex matches links without a scheme, such as "bit.ly/abc" or
+// "amzn.to/xyz", which urlRegex does not cover.
+var bareLinkRegex = regexp.MustCompile(`(?i)\b[a-z0-9-]+(?:\.[a-z0-9-]+)*\.[a-z]{2,}/[^\s<>"']*`)
+
+// A line counts as boilerplate when it appears in at least
+// minBoilerplateVideos of a channel's videos and in at least
+// minBoilerplateShare of them, so the topic line shared by a short series
+// is kept.
+const (
+	minBoilerplateVideos = 3
+	minBoilerplateShare  = 0.5
+)
+
+// BoilerplateLine is a description line repeated across a channel's videos.
+type BoilerplateLine struct {
+	Line    string // Line as it first appeared
+	Channel string // Channel the line was repeated in
+	Videos  int    // Videos of the channel containing the line
+}
+
+// CleaningReport summarizes what description cleaning removed.
+type CleaningReport struct {
+	URLs        int               // Links removed
+	Mentions    int               // @mentions removed
+	Emails      int               // Email addresses removed
+	Timestamps  int               // Chapter timestamps removed (chapter titles are kept)
+	Boilerplate []BoilerplateLine // Repeated lines dropped, most repeated first
+}
+
+// Removed returns the total number of items removed.
+func (r CleaningReport) Removed() int {
+	n := r.URLs + r.Mentions + r.Emails + r.Timestamps
+	for _, b := range r.Boilerplate {
+		n += b.Videos
+	}
+	return n
+}
+
+// CleanText strips URLs, @mentions, email addresses and chapter timestamps
+// from text, reporting how many of each were removed. Line structure is kept.
+func CleanText(s string) (string, CleaningReport) {
+	var report CleaningReport
+	if s == "" {
+		return s, report
+	}
+
+	s, report.URLs = removeAll(urlRegex, s, " ")
+	var bare int
+	s, bare = removeAll(bareLinkRegex, s, " ")
+	report.URLs += bare
+	s, report.Emails = removeAll(emailRegex, s, " ")
+	s, report.Mentions = removeAll(mentionRegex, s, "$1 ")
+	s, report.Timestamps = removeAll(timestampRegex, s, " ")
+
+	lines := strings.Split(s, "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimSpace(whitespaceRegex.ReplaceAllString(line, " "))
+	}
+	return strings.Join(lines, "\n"), report
+}
+
+// removeAll replaces every match of re in s with repl and returns the
+// number of matches.
+func removeAll(re *regexp.Regexp, s, repl string) (string, int) {
+	n := len(re.FindAllStringIndex(s, -1))
+	if n == 0 {
+		return s, 0
+	}
+	return re.ReplaceAllString(s, repl), n
+}
+
+// CleanDescriptions removes boilerplate from video descriptions before
+// keyword and hashtag analysis. channels gives each description's channel;
+// lines repeated in at least three and at least half of a channel's videos
+// (social links, affiliate disclaimers, gear lists, footers) are dropped, then CleanText
+// strips the remaining URLs, mentions, emails and timestamps. Descriptions
+// with an empty channel are never treated as boilerplate.
+func CleanDescriptions(descriptions, channels []string) ([]string, CleaningReport) {
+	repeated, report := findBoilerplate(descriptions, channels)
+
+	cleaned := make([]string, len(descriptions))
+	for i, desc := range descriptions {
+		channel := ""
+		if i < len(channels) {
+			channel = channels[i]
+		}
+		var kept []string
+		for _, line := range strings.Split(desc, "\n") {
+			if channel != "" && repeated[channel][boilerplateKey(line)] {
+				continue
+			}
+			kept = append(kept, line)
+		}
+
+		text, r := CleanText(strings.Join(kept, "\n"))
+		cleaned[i] = strings.TrimSpace(text)
+		report.URLs += r.URLs
+		report.Mentions += r.Mentions
+		report.Emails += r.Emails
+		report.Timestamps += r.Timestamps
+	}
+	return cleaned, report
+}
+
+// findBoilerplate returns, per channel, the normalized lines repeated in
+// enough of the channel's videos, along with a report listing them.
+func findBoilerplate(descriptions, channels []string) (map[string]map[string]bool, CleaningReport) {
+	type lineStat struct {
+		first  string
+		videos int
+	}
+	stats := make(map[string]map[string]*lineStat)
+	channelVideos := make(map[string]int)
+	for i, desc := range descriptions {
+		if i >= len(channels) || channels[i] == "" {
+			continue
+		}
+		channel := channels[i]
+		channelVideos[channel]++
+		if stats[channel] == nil {
+			stats[channel] = make(map[string]*lineStat)
+		}
+		seen := make(map[string]bool)
+		for _, line := range strings.Split(desc, "\n") {
+			key := boilerplateKey(line)
+			if key == "" || seen[key] {
+				continue
+			}
+			seen[key] = true
+			s, ok := stats[channel][key]
+			if !ok {
+				s = &lineStat{first: strings.TrimSpace(line)}
+				stats[channel][key] = s
+			}
+			s.videos++
+		}
+	}
+
+	repeated := make(map[string]map[string]bool)
+	var report CleaningReport
+	for channel, lines := range stats {
+		for key, s := range lines {
+			if s.videos < minBoilerplateVideos || float64(s.videos) < minBoilerplateShare*float64(channelVideos[channel]) {
+				continue
+			}
+			if repeated[channel] == nil {
+				repeated[channel] = make(map[string]bool)
+			}
+			repeated[channel][key] = true
+			report.Boilerplate = append(report.Boilerplate, BoilerplateLine{
+				Line:    s.first,
+				Channel: channel,
+				Videos:  s.videos,
+			})
+		}
+	}
+	sort.Slice(report.Boilerplate, func(i, j int) bool {
+		a, b := report.Boilerplate[i], report.Boilerplate[j]
+		if a.Videos != b.Videos {
+			return a.Videos > b.Videos
+		}
+		if a.Channel != b.Channel {
+			return a.Channel < b.Channel
+		}
+		return a.Line < b.Line
+	})
+	return repeated, report
+}
+
+// boilerplateKey normalizes a line for repeat detection: lowercase with
+// whitespace collapsed. Lines without any word yield "".
+func boilerplateKey(line string) string {
+	if !wordRegex.MatchString(line) {
+		return ""
+	}
+	return strings.ToLower(strings.TrimSpace(whitespaceRegex.ReplaceAllString(line, " ")))
+}
@@ -0,0 +1,103 @@
+package text
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestCleanText(t *testing.T) {
+	input := "Full guide: https://example.com/guide and bit.ly/abc123\n" +
+		"Contact me at hello@example.com or @vibecoder\n" +
+		"0:00 Intro\n1:02:30 Deploying to prod\n#vibecoding"
+
+	got, report := CleanText(input)
+	want := "Full guide: and\nContact me at or\nIntro\nDeploying to prod\n#vibecoding"
+	if got != want {
+		t.Errorf("CleanText() =\n%q\nwant\n%q", got, want)
+	}
+	if report.URLs != 2 || report.Emails != 1 || report.Mentions != 1 || report.Timestamps != 2 {
+		t.Errorf("report = %+v, want 2 URLs, 1 email, 1 mention, 2 timestamps", report)
+	}
+}
+
+func TestCleanText_KeepsOrdinaryText(t *testing.T) {
+	input := "Building an app in 10 minutes with version 2.0"
+	got, report := CleanText(input)
+	if got != input {
+		t.Errorf("CleanText() = %q, want unchanged", got)
+	}
+	if report.Removed() != 0 {
+		t.Errorf("Removed() = %d, want 0", report.Removed())
+	}
+}
+
+func TestCleanDescriptions_DropsChannelBoilerplate(t *testing.T) {
+	footer := "Follow me on Instagram for more\nAs an Amazon Associate I earn from qualifying purchases"
+	descriptions := []string{
+		"Building a todo app with React\n" + footer,
+		"Deploying with Docker\n" + footer,
+		"Testing with Vitest\n" + footer,
+		"Follow me on Instagram for more",
+	}
+	channels := []string{"UC1", "UC1", "UC1", "UC2"}
+
+	cleaned, report := CleanDescriptions(descriptions, channels)
+
+	if cleaned[0] != "Building a todo app with React" || cleaned[1] != "Deploying with Docker" || cleaned[2] != "Testing with Vitest" {
+		t.Errorf("boilerplate not removed: %q", cleaned[:3])
+	}
+	if cleaned[3] != "Follow me on Instagram for more" {
+		t.Errorf("line from another channel should be kept, got %q", cleaned[3])
+	}
+	if len(report.Boilerplate) != 2 {
+		t.Fatalf("Boilerplate = %+v, want 2 lines", report.Boilerplate)
+	}
+	if report.Boilerplate[0].Channel != "UC1" || report.Boilerplate[0].Videos != 3 {
+		t.Errorf("Boilerplate[0] = %+v", report.Boilerplate[0])
+	}
+}
+
+func TestCleanDescriptions_IgnoresUnknownChannel(t *testing.T) {
+	descriptions := []string{"Same line", "Same line"}
+	cleaned, report := CleanDescriptions(descriptions, []string{"", ""})
+	if strings.Join(cleaned, "|") != "Same line|Same line" {
+		t.Errorf("cleaned = %q, want unchanged", cleaned)
+	}
+	if len(report.Boilerplate) != 0 {
+		t.Errorf("Boilerplate = %+v, want none", report.Boilerplate)
+	}
+}
+
+func TestCleanDescriptions_NormalizesRepeatedLines(t *testing.T) {
+	descriptions := []string{"Intro\n  GEAR:   Sony a7  ", "Other\ngear: sony a7", "Outro\nGear: Sony A7"}
+	cleaned, _ := CleanDescriptions(descriptions, []string{"UC1", "UC1", "UC1"})
+	if cleaned[0] != "Intro" || cleaned[1] != "Other" || cleaned[2] != "Outro" {
+		t.Errorf("cleaned = %q, want gear line removed", cleaned)
+	}
+}
+
+func TestCleanDescriptions_KeepsSeriesTopic(t *testing.T) {
+	topic := "Part of my series on building a Rust web server"
+	descriptions := []string{
+		"Part 1: routing\n" + topic,
+		"Part 2: middleware\n" + topic,
+	}
+	cleaned, report := CleanDescriptions(descriptions, []string{"UC1", "UC1"})
+	for i, desc := range cleaned {
+		if !strings.Contains(desc, topic) {
+			t.Errorf("cleaned[%d] = %q, want the series topic kept", i, desc)
+		}
+	}
+	if len(report.Boilerplate) != 0 {
+		t.Errorf("Boilerplate = %+v, want none", report.Boilerplate)
+	}
+}
+
+func TestCleanDescriptions_RelativeThreshold(t *testing.T) {
+	descriptions := []string{"Guest: Ana\nA", "Guest: Ana\nB", "Guest: Ana\nC", "D", "E", "F", "G"}
+	channels := []string{"UC1", "UC1", "UC1", "UC1", "UC1", "UC1", "UC1"}
+	cleaned, _ := CleanDescriptions(descriptions, channels)
+	if !strings.HasPrefix(cleaned[0], "Guest: Ana") {
+		t.Errorf("cleaned[0] = %q, want a line in 3 of 7 videos kept", cleaned[0])
+	}
+}
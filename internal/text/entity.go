@@ -0,0 +1,315 @@
+package text
+
+import (
+	"bufio"
+	_ "embed"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+//go:embed gazetteer.txt
+var bundledGazetteer string
+
+// entityTokenRegex matches words, keeping dotted and hyphenated names such
+// as "Next.js" and "GPT-4" whole, and single punctuation characters.
+var entityTokenRegex = regexp.MustCompile(`[\p{L}\p{N}][\p{L}\p{M}\p{N}]*(?:[.\-][\p{L}\p{N}][\p{L}\p{M}\p{N}]*)*|[^\s\p{L}\p{M}\p{N}]`)
+
+// maxEntityWords is the longest name the capitalization heuristics build.
+const maxEntityWords = 4
+
+// titleCaseRatio is the share of capitalized content words above which a
+// line is treated as Title Case (or ALL CAPS), where capitalization says
+// nothing about names. It is high enough that a short sentence mentioning a
+// two-word name is not mistaken for a title.
+const titleCaseRatio = 0.85
+
+// casedMarker prefixes gazetteer names that are also ordinary words
+// ("=Cursor", "=Meta"). Such names only match when capitalized, so "my
+// cursor freezes" names no product.
+const casedMarker = "="
+
+// Gazetteer is a list of known entity names (products, companies, people)
+// with their aliases. Lookups ignore case, except that names marked as
+// ordinary words must be capitalized.
+type Gazetteer struct {
+	names    map[string]string // normalized name or alias -> canonical name
+	cased    map[string]bool   // normalized names that must be capitalized
+	maxWords int               // most tokens in any name or alias
+}
+
+// NewGazetteer creates an empty gazetteer.
+func NewGazetteer() *Gazetteer {
+	return &Gazetteer{names: make(map[string]string), cased: make(map[string]bool)}
+}
+
+// DefaultGazetteer returns the bundled gazetteer of AI and coding products,
+// companies and people.
+func DefaultGazetteer() *Gazetteer {
+	g := NewGazetteer()
+	if err := g.Load(strings.NewReader(bundledGazetteer)); err != nil {
+		panic("text: invalid bundled gazetteer: " + err.Error())
+	}
+	return g
+}
+
+// LoadGazetteer returns the bundled gazetteer extended with the entries of
+// a gazetteer file.
+func LoadGazetteer(path string) (*Gazetteer, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("opening gazetteer: %w", err)
+	}
+	defer f.Close()
+
+	g := DefaultGazetteer()
+	if err := g.Load(f); err != nil {
+		return nil, fmt.Errorf("reading gazetteer %s: %w", path, err)
+	}
+	return g, nil
+}
+
+// Load adds entries read from r: one entity per line, the canonical name
+// optionally followed by aliases separated by "|". A name or alias prefixed
+// with "=" only matches when capitalized. Blank lines and lines starting
+// with # are skipped.
+func (g *Gazetteer) Load(r io.Reader) error {
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		names := strings.Split(line, "|")
+		canonical := strings.TrimPrefix(strings.TrimSpace(names[0]), casedMarker)
+		for _, n := range names {
+			alias, cased := strings.CutPrefix(strings.TrimSpace(n), casedMarker)
+			g.add(canonical, alias, cased)
+		}
+	}
+	return scanner.Err()
+}
+
+// Add registers an entity under its canonical name and any aliases.
+func (g *Gazetteer) Add(name string, aliases ...string) {
+	for _, alias := range append([]string{name}, aliases...) {
+		g.add(name, alias, false)
+	}
+}
+
+// add registers one name or alias of an entity. A cased alias only matches
+// when capitalized.
+func (g *Gazetteer) add(name, alias string, cased bool) {
+	words := entityWords(alias)
+	if len(words) == 0 {
+		return
+	}
+	key := strings.ToLower(strings.Join(words, " "))
+	g.names[key] = name
+	if cased {
+		g.cased[key] = true
+	} else {
+		delete(g.cased, key)
+	}
+	if len(words) > g.maxWords {
+		g.maxWords = len(words)
+	}
+}
+
+// Len returns the number of names and aliases in the gazetteer.
+func (g *Gazetteer) Len() int {
+	if g == nil {
+		return 0
+	}
+	return len(g.names)
+}
+
+// lookup returns the canonical name for a sequence of words.
+func (g *Gazetteer) lookup(words []string) (string, bool) {
+	if g == nil {
+		return "", false
+	}
+	key := strings.ToLower(strings.Join(words, " "))
+	name, ok := g.names[key]
+	if ok && g.cased[key] && !isCapitalized(words[0]) {
+		return "", false
+	}
+	return name, ok
+}
+
+// entityWords returns the word tokens of s.
+func entityWords(s string) []string {
+	var words []string
+	for _, tok := range entityTokenRegex.FindAllString(s, -1) {
+		if isEntityWord(tok) {
+			words = append(words, tok)
+		}
+	}
+	return words
+}
+
+// EntityMention is a named entity found in text.
+type EntityMention struct {
+	Name  string // Canonical gazetteer name, or the name as written
+	Text  string // Name as written, words joined by single spaces
+	Known bool   // Matched the gazetteer rather than capitalization heuristics
+}
+
+// ExtractEntities finds product, company and people names in text. Gazetteer
+// names match regardless of case, longest first. Other names are found by
+// capitalization: runs of capitalized words mid-sentence ("with Sam
+// Altman"), or words with internal capitals or acronyms ("LangGraph",
+// "AWS"). Capitalization is ignored on Title Case and ALL CAPS lines, where
+// only gazetteer names and mixed-case words count. g may be nil.
+func ExtractEntities(s string, g *Gazetteer) []EntityMention {
+	var mentions []EntityMention
+	for _, line := range strings.Split(s, "\n") {
+		mentions = append(mentions, lineEntities(line, g)...)
+	}
+	return mentions
+}
+
+// lineEntities extracts the entities of a single line.
+func lineEntities(line string, g *Gazetteer) []EntityMention {
+	tokens := entityTokenRegex.FindAllString(line, -1)
+	titleCase, shouting := lineCase(tokens)
+
+	// qualifies reports whether a token can be part of a heuristic name
+	qualifies := func(tok string, atStart bool) bool {
+		if !isEntityWord(tok) || stopWords[strings.ToLower(tok)] {
+			return false
+		}
+		if hasInnerUpper(tok) || (isAcronym(tok) && !shouting) {
+			return true
+		}
+		return isCapitalized(tok) && !titleCase && !atStart
+	}
+
+	var mentions []EntityMention
+	sentenceStart := true
+	for i := 0; i < len(tokens); {
+		tok := tokens[i]
+		if !isEntityWord(tok) {
+			if strings.ContainsAny(tok, ".!?:;|•-–—") {
+				sentenceStart = true
+			}
+			i++
+			continue
+		}
+
+		if name, n := g.longestMatch(tokens[i:]); n > 0 {
+			mentions = append(mentions, EntityMention{Name: name, Text: strings.Join(tokens[i:i+n], " "), Known: true})
+			sentenceStart = false
+			i += n
+			continue
+		}
+
+		// A capitalized sentence-initial word starts a name only when the
+		// next word continues it ("Sam Altman said...")
+		start := qualifies(tok, sentenceStart)
+		if !start && sentenceStart && isCapitalized(tok) && !titleCase && !stopWords[strings.ToLower(tok)] {
+			start = i+1 < len(tokens) && qualifies(tokens[i+1], false)
+		}
+		sentenceStart = false
+		if !start {
+			i++
+			continue
+		}
+
+		j := i + 1
+		for j < len(tokens) && j-i < maxEntityWords && qualifies(tokens[j], false) {
+			if _, n := g.longestMatch(tokens[j:]); n > 0 {
+				break
+			}
+			j++
+		}
+		name := strings.Join(tokens[i:j], " ")
+		mentions = append(mentions, EntityMention{Name: name, Text: name})
+		i = j
+	}
+	return mentions
+}
+
+// longestMatch returns the canonical name of the longest gazetteer entry
+// starting at tokens[0] and the number of tokens it spans (0 if none).
+func (g *Gazetteer) longestMatch(tokens []string) (string, int) {
+	if g == nil {
+		return "", 0
+	}
+	n := 0
+	for n < len(tokens) && n < g.maxWords && isEntityWord(tokens[n]) {
+		n++
+	}
+	for ; n > 0; n-- {
+		if name, ok := g.lookup(tokens[:n]); ok {
+			return name, n
+		}
+	}
+	return "", 0
+}
+
+// lineCase reports whether most content words of a line are capitalized
+// (Title Case) or fully uppercase (ALL CAPS).
+func lineCase(tokens []string) (titleCase, shouting bool) {
+	var words, capitalized, upper int
+	for _, tok := range tokens {
+		if !isEntityWord(tok) || stopWords[strings.ToLower(tok)] || !hasLetter(tok) {
+			continue
+		}
+		words++
+		if isCapitalized(tok) {
+			capitalized++
+		}
+		if isAcronym(tok) {
+			upper++
+		}
+	}
+	if words < 3 {
+		return false, false
+	}
+	return float64(capitalized) >= titleCaseRatio*float64(words),
+		float64(upper) >= titleCaseRatio*float64(words)
+}
+
+// isEntityWord reports whether a token is a word rather than punctuation.
+func isEntityWord(tok string) bool {
+	r, _ := utf8.DecodeRuneInString(tok)
+	return unicode.IsLetter(r) || unicode.IsNumber(r)
+}
+
+func hasLetter(tok string) bool {
+	return strings.IndexFunc(tok, unicode.IsLetter) >= 0
+}
+
+// isCapitalized reports whether a token starts with an uppercase letter.
+func isCapitalized(tok string) bool {
+	r, _ := utf8.DecodeRuneInString(tok)
+	return unicode.IsUpper(r)
+}
+
+// hasInnerUpper reports whether a token mixes case with an uppercase letter
+// after the first character, as in "ChatGPT" or "iPhone".
+func hasInnerUpper(tok string) bool {
+	_, size := utf8.DecodeRuneInString(tok)
+	rest := tok[size:]
+	return strings.IndexFunc(rest, unicode.IsUpper) >= 0 && strings.IndexFunc(tok, unicode.IsLower) >= 0
+}
+
+// isAcronym reports whether a token has at least two letters, all uppercase
+// (e.g., "AWS", "GPT4").
+func isAcronym(tok string) bool {
+	letters := 0
+	for _, r := range tok {
+		if unicode.IsLetter(r) {
+			if !unicode.IsUpper(r) {
+				return false
+			}
+			letters++
+		}
+	}
+	return letters >= 2
+}
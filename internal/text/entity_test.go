@@ -0,0 +1,133 @@
+package text
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func entityNames(mentions []EntityMention) []string {
+	names := make([]string, len(mentions))
+	for i, m := range mentions {
+		names[i] = m.Name
+	}
+	return names
+}
+
+func TestExtractEntities_Gazetteer(t *testing.T) {
+	g := DefaultGazetteer()
+
+	got := ExtractEntities("comparing chatgpt vs claude code and vscode", g)
+	want := []string{"ChatGPT", "Claude Code", "VS Code"}
+	if !reflect.DeepEqual(entityNames(got), want) {
+		t.Errorf("ExtractEntities() = %v, want %v", entityNames(got), want)
+	}
+	for _, m := range got {
+		if !m.Known {
+			t.Errorf("%q should be a gazetteer match", m.Name)
+		}
+	}
+}
+
+func TestExtractEntities_Capitalization(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		want []string
+	}{
+		{"multi-word name", "I interviewed Sam Altman about agents", []string{"Sam Altman"}},
+		{"sentence start continues", "Greg Brockman said it works. Then we shipped", []string{"Greg Brockman"}},
+		{"sentence start alone", "Today we build an app", nil},
+		{"inner capitals", "trying LangGraph for agents", []string{"LangGraph"}},
+		{"acronym", "deploying to AWS fast", []string{"AWS"}},
+		{"title case", "How I Built My First App With Supabook", nil},
+		{"title case inner capitals", "How I Built My First App With LangGraph", []string{"LangGraph"}},
+		{"all caps", "THIS CHANGES EVERYTHING FOREVER", nil},
+	}
+	for _, tt := range tests {
+		got := entityNames(ExtractEntities(tt.text, nil))
+		if len(got) == 0 && len(tt.want) == 0 {
+			continue
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s: ExtractEntities(%q) = %v, want %v", tt.name, tt.text, got, tt.want)
+		}
+	}
+}
+
+func TestExtractEntities_PrefersGazetteer(t *testing.T) {
+	g := NewGazetteer()
+	g.Add("Sam Altman")
+
+	got := ExtractEntities("A chat with Sam Altman Today", g)
+	if len(got) == 0 || got[0].Name != "Sam Altman" || !got[0].Known {
+		t.Errorf("ExtractEntities() = %+v, want gazetteer match first", got)
+	}
+}
+
+func TestGazetteer_Load(t *testing.T) {
+	g := NewGazetteer()
+	err := g.Load(strings.NewReader("# tools\nLangGraph | Lang Graph\n\nReplit Agent\n"))
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if g.Len() != 3 {
+		t.Errorf("Len() = %d, want 3", g.Len())
+	}
+
+	got := entityNames(ExtractEntities("building with lang graph and replit agent", g))
+	want := []string{"LangGraph", "Replit Agent"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ExtractEntities() = %v, want %v", got, want)
+	}
+}
+
+func TestExtractEntities_OrdinaryWords(t *testing.T) {
+	g := DefaultGazetteer()
+	for _, s := range []string{
+		"how i react when my cursor freezes",
+		"we will meta analyze this",
+		"Don't bolt your food",
+		"lovable kids",
+	} {
+		if got := ExtractEntities(s, g); len(got) != 0 {
+			t.Errorf("ExtractEntities(%q) = %v, want none", s, entityNames(got))
+		}
+	}
+	got := entityNames(ExtractEntities("why i switched from Cursor to windsurf and then to bolt.new", g))
+	if !reflect.DeepEqual(got, []string{"Cursor", "Bolt"}) {
+		t.Errorf("ExtractEntities() = %v, want capitalized names and unambiguous aliases", got)
+	}
+}
+
+func TestGazetteer_LoadCased(t *testing.T) {
+	g := NewGazetteer()
+	if err := g.Load(strings.NewReader("=Ruby | Ruby on Rails\n")); err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if got := entityNames(ExtractEntities("a ruby ring, Ruby gems and ruby on rails", g)); !reflect.DeepEqual(got, []string{"Ruby", "Ruby"}) {
+		t.Errorf("ExtractEntities() = %v, want [Ruby Ruby]", got)
+	}
+}
+
+func TestLoadGazetteer(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "gazetteer.txt")
+	if err := os.WriteFile(path, []byte("Kingmaker\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	g, err := LoadGazetteer(path)
+	if err != nil {
+		t.Fatalf("LoadGazetteer() error = %v", err)
+	}
+	got := entityNames(ExtractEntities("kingmaker and Cursor", g))
+	if !reflect.DeepEqual(got, []string{"Kingmaker", "Cursor"}) {
+		t.Errorf("expected file and bundled entries, got %v", got)
+	}
+
+	if _, err := LoadGazetteer(filepath.Join(t.TempDir(), "missing.txt")); err == nil {
+		t.Error("expected error for missing file")
+	}
+}
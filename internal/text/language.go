@@ -0,0 +1,220 @@
+package text
+
+import (
+	"embed"
+	"path"
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+//go:embed stopwords/*.txt
+var stopWordFiles embed.FS
+
+// stopWordLists maps a language code to its bundled stop words.
+var stopWordLists = loadStopWords()
+
+// loadStopWords reads the bundled stopwords/<lang>.txt files (one word per line).
+func loadStopWords() map[string]map[string]bool {
+	entries, err := stopWordFiles.ReadDir("stopwords")
+	if err != nil {
+		panic("text: missing bundled stop words: " + err.Error())
+	}
+
+	lists := make(map[string]map[string]bool, len(entries))
+	for _, e := range entries {
+		data, err := stopWordFiles.ReadFile(path.Join("stopwords", e.Name()))
+		if err != nil {
+			panic("text: reading bundled stop words: " + err.Error())
+		}
+		words := make(map[string]bool)
+		for _, line := range strings.Split(string(data), "\n") {
+			if w := strings.TrimSpace(line); w != "" {
+				words[w] = true
+			}
+		}
+		lists[strings.TrimSuffix(e.Name(), ".txt")] = words
+	}
+	return lists
+}
+
+// StopWords returns the bundled stop words for a language code (e.g., "es"
+// or "pt-BR"). Languages without a bundled list fall back to English.
+func StopWords(lang string) map[string]bool {
+	if words, ok := stopWordLists[NormalizeLanguage(lang)]; ok {
+		return words
+	}
+	return stopWords
+}
+
+// StopWordLanguages returns the language codes with a bundled stop-word list.
+func StopWordLanguages() []string {
+	langs := make([]string, 0, len(stopWordLists))
+	for lang := range stopWordLists {
+		langs = append(langs, lang)
+	}
+	sort.Strings(langs)
+	return langs
+}
+
+// NormalizeLanguage reduces a BCP-47 tag as reported by YouTube (e.g.,
+// "es-419", "pt_BR", "en-US") to its lowercase primary language subtag.
+// Tags meaning "no linguistic content" or "undetermined" yield "".
+func NormalizeLanguage(tag string) string {
+	tag = strings.ToLower(strings.TrimSpace(tag))
+	if i := strings.IndexAny(tag, "-_"); i >= 0 {
+		tag = tag[:i]
+	}
+	switch tag {
+	case "zxx", "und", "mul":
+		return ""
+	}
+	return tag
+}
+
+// latinLanguages are the Latin-script languages DetectLanguage tells apart
+// by stop-word hits, in tie-break order. "hi" covers romanized Hindi.
+var latinLanguages = []string{"en", "es", "pt", "fr", "de", "hi"}
+
+// minForeignStopWords is the number of stop-word hits a Latin-script text
+// needs before DetectLanguage picks a language other than English, which
+// must also have more hits than English. Single hits are too often English
+// words or names another list shares.
+const minForeignStopWords = 2
+
+// DetectLanguage guesses the language of a text and returns its code, or ""
+// when there is too little evidence. Non-Latin scripts are identified by
+// their letters (Devanagari as Hindi, kana as Japanese); Latin-script text
+// is assigned the language whose stop words it uses most, with English
+// preferred unless another language clearly wins. Kana only occurs
+// in Japanese, so Japanese text mixing in Latin names ("ChatGPTで稼ぐ方法") is
+// still Japanese.
+func DetectLanguage(text string) string {
+	var latin, devanagari, kana, han, hangul, cyrillic, arabic int
+	for _, r := range text {
+		switch {
+		case unicode.Is(unicode.Latin, r):
+			latin++
+		case unicode.Is(unicode.Devanagari, r):
+			devanagari++
+		case unicode.In(r, unicode.Hiragana, unicode.Katakana):
+			kana++
+		case unicode.Is(unicode.Han, r):
+			han++
+		case unicode.Is(unicode.Hangul, r):
+			hangul++
+		case unicode.Is(unicode.Cyrillic, r):
+			cyrillic++
+		case unicode.Is(unicode.Arabic, r):
+			arabic++
+		}
+	}
+
+	// Pick the dominant non-Latin script, if it outweighs Latin letters
+	scripts := []struct {
+		lang  string
+		count int
+	}{
+		{"hi", devanagari},
+		{"ja", kana + han},
+		{"ko", hangul},
+		{"ru", cyrillic},
+		{"ar", arabic},
+	}
+	best, bestCount := "", 0
+	for _, s := range scripts {
+		if s.count > bestCount {
+			best, bestCount = s.lang, s.count
+		}
+	}
+	if best == "ja" && kana == 0 {
+		best = "zh"
+	}
+	if best == "ja" || (bestCount > 0 && bestCount >= latin) {
+		return best
+	}
+
+	// Latin script: count stop-word hits per language
+	tokens := Tokenize(text)
+	hits := make(map[string]int, len(latinLanguages))
+	bestLang := ""
+	for _, lang := range latinLanguages {
+		words := stopWordLists[lang]
+		for _, t := range tokens {
+			if words[t] {
+				hits[lang]++
+			}
+		}
+		if hits[lang] > hits[bestLang] {
+			bestLang = lang
+		}
+	}
+	if bestLang != "en" && hits[bestLang] < minForeignStopWords {
+		bestLang = ""
+	}
+	if bestLang == "" && hits["en"] > 0 {
+		return "en"
+	}
+	return bestLang
+}
+
+// IsNoiseToken reports whether a token is too short to be a keyword: a
+// single character, except CJK ideographs, which are often whole words.
+func IsNoiseToken(word string) bool {
+	if utf8.RuneCountInString(word) >= 2 {
+		return false
+	}
+	r, _ := utf8.DecodeRuneInString(word)
+	return !unicode.Is(unicode.Han, r)
+}
+
+// japaneseScript classifies a rune for splitting unspaced Japanese text.
+// The prolonged sound mark and iteration marks continue the current script.
+func japaneseScript(r rune) *unicode.RangeTable {
+	switch {
+	case r == 'ー' || r == '々' || r == 'ゝ' || r == 'ヽ':
+		return nil
+	case unicode.Is(unicode.Han, r):
+		return unicode.Han
+	case unicode.Is(unicode.Hiragana, r):
+		return unicode.Hiragana
+	case unicode.Is(unicode.Katakana, r):
+		return unicode.Katakana
+	}
+	return unicode.Common
+}
+
+// splitScripts splits a word token where it switches between kanji,
+// hiragana, katakana and other scripts, approximating word boundaries in
+// Japanese, which is written without spaces. Hiragana following kanji stays
+// with it as the word's inflected ending (稼ぐ, 学ぶ) unless it is a particle
+// or other stop word (基本|を). Other tokens are returned whole.
+func splitScripts(token string) []string {
+	var parts []string
+	start := 0
+	var current, previous *unicode.RangeTable
+	split := func(end int) {
+		part := token[start:end]
+		if previous == unicode.Han && current == unicode.Hiragana && !stopWordLists["ja"][part] {
+			parts[len(parts)-1] += part
+			previous = nil // an ending closes the word
+		} else {
+			parts = append(parts, part)
+			previous = current
+		}
+		start = end
+	}
+	for i, r := range token {
+		script := japaneseScript(r)
+		if script == nil {
+			continue
+		}
+		if current != nil && script != current {
+			split(i)
+		}
+		current = script
+	}
+	split(len(token))
+	return parts
+}
@@ -0,0 +1,89 @@
+package text
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestStopWords(t *testing.T) {
+	if !StopWords("es")["para"] {
+		t.Error("Spanish stop words should include 'para'")
+	}
+	if !StopWords("pt-BR")["não"] {
+		t.Error("pt-BR should use the Portuguese list")
+	}
+	if !StopWords("xx")["the"] {
+		t.Error("unknown languages should fall back to English")
+	}
+
+	want := []string{"de", "en", "es", "fr", "hi", "ja", "pt"}
+	if got := StopWordLanguages(); !reflect.DeepEqual(got, want) {
+		t.Errorf("StopWordLanguages() = %v, want %v", got, want)
+	}
+}
+
+func TestRemoveStopWordsLang(t *testing.T) {
+	got := RemoveStopWordsLang(Tokenize("Cómo hacer pan de masa madre"), "es")
+	want := []string{"hacer", "pan", "masa", "madre"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("RemoveStopWordsLang() = %v, want %v", got, want)
+	}
+}
+
+func TestNormalizeLanguage(t *testing.T) {
+	tests := map[string]string{
+		"es-419": "es",
+		"pt_BR":  "pt",
+		"EN-us":  "en",
+		"hi":     "hi",
+		"zxx":    "",
+		"":       "",
+	}
+	for tag, want := range tests {
+		if got := NormalizeLanguage(tag); got != want {
+			t.Errorf("NormalizeLanguage(%q) = %q, want %q", tag, got, want)
+		}
+	}
+}
+
+func TestDetectLanguage(t *testing.T) {
+	tests := []struct {
+		text string
+		want string
+	}{
+		{"How to start coding with the best tools", "en"},
+		{"Cómo aprender a programar desde cero con los mejores trucos", "es"},
+		{"Como aprender a programar do zero com as melhores dicas", "pt"},
+		{"Comment apprendre à coder avec les meilleurs outils", "fr"},
+		{"Wie man mit den besten Tools programmieren lernt", "de"},
+		{"कोडिंग कैसे सीखें - पूरी जानकारी", "hi"},
+		{"coding kaise sikhe aur paise kamaye", "hi"},
+		{"プログラミングの基本を学ぶ", "ja"},
+		{"ChatGPTで稼ぐ方法", "ja"},
+		{"Secret AI tools", ""},
+		{"ChatGPT AI secret", ""},
+		{"AI vs AI: the best AI", "en"},
+		{"Programar en Python desde cero", "es"},
+		{"编程入门教程", "zh"},
+		{"Python 🔥", ""},
+	}
+	for _, tt := range tests {
+		if got := DetectLanguage(tt.text); got != tt.want {
+			t.Errorf("DetectLanguage(%q) = %q, want %q", tt.text, got, tt.want)
+		}
+	}
+}
+
+func TestIsNoiseToken(t *testing.T) {
+	tests := map[string]bool{
+		"a":  true,
+		"é":  true,
+		"ai": false,
+		"猫":  false,
+	}
+	for word, want := range tests {
+		if got := IsNoiseToken(word); got != want {
+			t.Errorf("IsNoiseToken(%q) = %v, want %v", word, got, want)
+		}
+	}
+}
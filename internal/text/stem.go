@@ -0,0 +1,331 @@
+package text
+
+import (
+	"strings"
+	"sync"
+)
+
+// Stemmer reduces an inflected lowercase word to its stem.
+type Stemmer interface {
+	Stem(word string) string
+}
+
+// StemmerFunc adapts an ordinary function to the Stemmer interface.
+type StemmerFunc func(word string) string
+
+// Stem calls f(word).
+func (f StemmerFunc) Stem(word string) string {
+	return f(word)
+}
+
+var (
+	stemmersMu sync.RWMutex
+	stemmers   = map[string]Stemmer{
+		"en": PorterStemmer{},
+	}
+)
+
+// RegisterStemmer registers the stemmer for a language code (e.g., "es"),
+// replacing any stemmer already registered for it.
+func RegisterStemmer(lang string, s Stemmer) {
+	stemmersMu.Lock()
+	defer stemmersMu.Unlock()
+	stemmers[strings.ToLower(lang)] = s
+}
+
+// StemmerFor returns the stemmer registered for a language code.
+func StemmerFor(lang string) (Stemmer, bool) {
+	stemmersMu.RLock()
+	defer stemmersMu.RUnlock()
+	s, ok := stemmers[strings.ToLower(lang)]
+	return s, ok
+}
+
+// irregularLemmas maps common irregular English inflections to their lemma,
+// covering forms a suffix-stripping stemmer cannot relate.
+var irregularLemmas = map[string]string{
+	"went": "go", "gone": "go", "made": "make", "built": "build",
+	"bought": "buy", "taught": "teach", "thought": "think", "got": "get",
+	"gotten": "get", "done": "do", "said": "say", "took": "take",
+	"taken": "take", "came": "come", "saw": "see", "seen": "see",
+	"knew": "know", "known": "know", "found": "find", "gave": "give",
+	"given": "give", "told": "tell", "felt": "feel", "kept": "keep",
+	"won": "win", "lost": "lose", "paid": "pay", "sold": "sell",
+	"wrote": "write", "written": "write", "ate": "eat", "eaten": "eat",
+	"ran": "run", "began": "begin", "begun": "begin", "broke": "break",
+	"broken": "break", "chose": "choose", "chosen": "choose", "drew": "draw",
+	"drawn": "draw", "drove": "drive", "driven": "drive", "flew": "fly",
+	"flown": "fly", "grew": "grow", "grown": "grow", "spent": "spend",
+	"stole": "steal", "stolen": "steal", "threw": "throw", "thrown": "throw",
+	"children": "child", "men": "man", "women": "woman", "mice": "mouse",
+	"feet": "foot", "teeth": "tooth", "geese": "goose", "lives": "life",
+	"knives": "knife", "wives": "wife", "leaves": "leaf", "halves": "half",
+}
+
+// Lemmatize maps an irregular English inflection (e.g., "built", "children")
+// to its dictionary form. Regular forms are returned unchanged; combine with
+// a Stemmer to group regular inflections.
+func Lemmatize(word string) string {
+	if lemma, ok := irregularLemmas[word]; ok {
+		return lemma
+	}
+	return word
+}
+
+// PorterStemmer implements the Porter (1980) stemming algorithm for English.
+// Words that are not plain lowercase ASCII letters are returned unchanged.
+type PorterStemmer struct{}
+
+// Stem returns the Porter stem of a lowercase English word.
+func (PorterStemmer) Stem(word string) string {
+	if len(word) <= 2 {
+		return word
+	}
+	for i := 0; i < len(word); i++ {
+		if word[i] < 'a' || word[i] > 'z' {
+			return word
+		}
+	}
+
+	p := &porter{b: []byte(word), k: len(word) - 1}
+	p.step1ab()
+	if p.k > 0 {
+		p.step1c()
+		p.step2()
+		p.step3()
+		p.step4()
+		p.step5()
+	}
+	return string(p.b[:p.k+1])
+}
+
+// porter holds the word being stemmed: b[0..k] is the current word and
+// b[0..j] the stem left when a suffix matched by ends is removed.
+type porter struct {
+	b    []byte
+	k, j int
+}
+
+// cons reports whether b[i] is a consonant.
+func (p *porter) cons(i int) bool {
+	switch p.b[i] {
+	case 'a', 'e', 'i', 'o', 'u':
+		return false
+	case 'y':
+		return i == 0 || !p.cons(i-1)
+	}
+	return true
+}
+
+// m measures the number of consonant-vowel sequences in b[0..j].
+func (p *porter) m() int {
+	n, i := 0, 0
+	for {
+		if i > p.j {
+			return n
+		}
+		if !p.cons(i) {
+			break
+		}
+		i++
+	}
+	i++
+	for {
+		for {
+			if i > p.j {
+				return n
+			}
+			if p.cons(i) {
+				break
+			}
+			i++
+		}
+		i++
+		n++
+		for {
+			if i > p.j {
+				return n
+			}
+			if !p.cons(i) {
+				break
+			}
+			i++
+		}
+		i++
+	}
+}
+
+// vowelInStem reports whether b[0..j] contains a vowel.
+func (p *porter) vowelInStem() bool {
+	for i := 0; i <= p.j; i++ {
+		if !p.cons(i) {
+			return true
+		}
+	}
+	return false
+}
+
+// doubleC reports whether b[i-1..i] is a double consonant.
+func (p *porter) doubleC(i int) bool {
+	return i >= 1 && p.b[i] == p.b[i-1] && p.cons(i)
+}
+
+// cvc reports whether b[i-2..i] is consonant-vowel-consonant and the final
+// consonant is not w, x or y (e.g., "hop" but not "snow").
+func (p *porter) cvc(i int) bool {
+	if i < 2 || !p.cons(i) || p.cons(i-1) || !p.cons(i-2) {
+		return false
+	}
+	switch p.b[i] {
+	case 'w', 'x', 'y':
+		return false
+	}
+	return true
+}
+
+// ends reports whether b[0..k] ends with s, setting j to the end of the stem.
+func (p *porter) ends(s string) bool {
+	l := len(s)
+	if l > p.k+1 || string(p.b[p.k-l+1:p.k+1]) != s {
+		return false
+	}
+	p.j = p.k - l
+	return true
+}
+
+// setTo replaces b[j+1..k] with s.
+func (p *porter) setTo(s string) {
+	p.b = append(p.b[:p.j+1], s...)
+	p.k = p.j + len(s)
+}
+
+// r replaces the matched suffix with s when the stem has measure > 0.
+func (p *porter) r(s string) {
+	if p.m() > 0 {
+		p.setTo(s)
+	}
+}
+
+// step1ab removes plurals and -ed or -ing.
+func (p *porter) step1ab() {
+	if p.b[p.k] == 's' {
+		switch {
+		case p.ends("sses"):
+			p.k -= 2
+		case p.ends("ies"):
+			p.setTo("i")
+		case p.b[p.k-1] != 's':
+			p.k--
+		}
+	}
+
+	if p.ends("eed") {
+		if p.m() > 0 {
+			p.k--
+		}
+		return
+	}
+	if (p.ends("ed") || p.ends("ing")) && p.vowelInStem() {
+		p.k = p.j
+		switch {
+		case p.ends("at"):
+			p.setTo("ate")
+		case p.ends("bl"):
+			p.setTo("ble")
+		case p.ends("iz"):
+			p.setTo("ize")
+		case p.doubleC(p.k):
+			switch p.b[p.k] {
+			case 'l', 's', 'z':
+			default:
+				p.k--
+			}
+		default:
+			p.j = p.k
+			if p.m() == 1 && p.cvc(p.k) {
+				p.setTo("e")
+			}
+		}
+	}
+}
+
+// step1c turns a terminal y into i when there is another vowel in the stem.
+func (p *porter) step1c() {
+	if p.ends("y") && p.vowelInStem() {
+		p.b[p.k] = 'i'
+	}
+}
+
+// suffixRule maps a suffix to its replacement.
+type suffixRule struct {
+	suffix, replacement string
+}
+
+var step2Rules = []suffixRule{
+	{"ational", "ate"}, {"tional", "tion"}, {"enci", "ence"}, {"anci", "ance"},
+	{"izer", "ize"}, {"bli", "ble"}, {"alli", "al"}, {"entli", "ent"},
+	{"eli", "e"}, {"ousli", "ous"}, {"ization", "ize"}, {"ation", "ate"},
+	{"ator", "ate"}, {"alism", "al"}, {"iveness", "ive"}, {"fulness", "ful"},
+	{"ousness", "ous"}, {"aliti", "al"}, {"iviti", "ive"}, {"biliti", "ble"},
+	{"logi", "log"},
+}
+
+var step3Rules = []suffixRule{
+	{"icate", "ic"}, {"ative", ""}, {"alize", "al"}, {"iciti", "ic"},
+	{"ical", "ic"}, {"ful", ""}, {"ness", ""},
+}
+
+var step4Suffixes = []string{
+	"al", "ance", "ence", "er", "ic", "able", "ible", "ant", "ement", "ment",
+	"ent", "ion", "ou", "ism", "ate", "iti", "ous", "ive", "ize",
+}
+
+// applyRules applies the first matching rule when the stem has measure > 0.
+func (p *porter) applyRules(rules []suffixRule) {
+	for _, rule := range rules {
+		if p.ends(rule.suffix) {
+			p.r(rule.replacement)
+			return
+		}
+	}
+}
+
+// step2 maps double suffixes to single ones (e.g., -ization to -ize).
+func (p *porter) step2() {
+	p.applyRules(step2Rules)
+}
+
+// step3 handles -ic-, -full, -ness and similar suffixes.
+func (p *porter) step3() {
+	p.applyRules(step3Rules)
+}
+
+// step4 removes -ant, -ence and similar suffixes when the measure is > 1.
+func (p *porter) step4() {
+	for _, suffix := range step4Suffixes {
+		if !p.ends(suffix) {
+			continue
+		}
+		if suffix == "ion" && (p.j < 0 || (p.b[p.j] != 's' && p.b[p.j] != 't')) {
+			return
+		}
+		if p.m() > 1 {
+			p.k = p.j
+		}
+		return
+	}
+}
+
+// step5 removes a final -e and reduces a final -ll when the measure is > 1.
+func (p *porter) step5() {
+	p.j = p.k
+	if p.b[p.k] == 'e' {
+		a := p.m()
+		if a > 1 || (a == 1 && !p.cvc(p.k-1)) {
+			p.k--
+		}
+	}
+	if p.b[p.k] == 'l' && p.doubleC(p.k) && p.m() > 1 {
+		p.k--
+	}
+}
@@ -0,0 +1,77 @@
+package text
+
+import (
+	"testing"
+)
+
+func TestPorterStemmer(t *testing.T) {
+	tests := map[string]string{
+		"caresses":       "caress",
+		"ponies":         "poni",
+		"cats":           "cat",
+		"feed":           "feed",
+		"agreed":         "agre",
+		"plastered":      "plaster",
+		"motoring":       "motor",
+		"sing":           "sing",
+		"conflated":      "conflat",
+		"troubled":       "troubl",
+		"sized":          "size",
+		"hopping":        "hop",
+		"falling":        "fall",
+		"filing":         "file",
+		"happy":          "happi",
+		"relational":     "relat",
+		"generalization": "gener",
+		"hopefulness":    "hope",
+		"adjustment":     "adjust",
+		"electricity":    "electr",
+		"controlling":    "control",
+		"adoption":       "adopt",
+		"code":           "code",
+		"coding":         "code",
+		"coded":          "code",
+		"codes":          "code",
+		"is":             "is",
+		"ai":             "ai",
+		"café":           "café",
+		"gpt4":           "gpt4",
+	}
+
+	var s PorterStemmer
+	for word, want := range tests {
+		if got := s.Stem(word); got != want {
+			t.Errorf("Stem(%q) = %q, want %q", word, got, want)
+		}
+	}
+}
+
+func TestLemmatize(t *testing.T) {
+	tests := map[string]string{
+		"built":    "build",
+		"children": "child",
+		"went":     "go",
+		"coding":   "coding",
+	}
+
+	for word, want := range tests {
+		if got := Lemmatize(word); got != want {
+			t.Errorf("Lemmatize(%q) = %q, want %q", word, got, want)
+		}
+	}
+}
+
+func TestStemmerRegistry(t *testing.T) {
+	if _, ok := StemmerFor("EN"); !ok {
+		t.Fatal("expected English stemmer to be registered")
+	}
+	if _, ok := StemmerFor("xx"); ok {
+		t.Fatal("unexpected stemmer for unknown language")
+	}
+
+	RegisterStemmer("xx", StemmerFunc(func(word string) string { return word[:1] }))
+	s, ok := StemmerFor("xx")
+	if !ok || s.Stem("hello") != "h" {
+		t.Error("registered stemmer not returned")
+	}
+}
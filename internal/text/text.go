@@ -5,68 +5,60 @@ import (
 	"strings"
 )
 
-var stopWords = map[string]bool{
-	"a": true, "an": true, "the": true, "is": true, "are": true,
-	"was": true, "were": true, "be": true, "been": true, "being": true,
-	"have": true, "has": true, "had": true, "do": true, "does": true,
-	"did": true, "will": true, "would": true, "could": true, "should": true,
-	"may": true, "might": true, "must": true, "shall": true,
-	"i": true, "me": true, "my": true, "myself": true, "we": true,
-	"our": true, "ours": true, "ourselves": true, "you": true, "your": true,
-	"yours": true, "yourself": true, "yourselves": true, "he": true, "him": true,
-	"his": true, "himself": true, "she": true, "her": true, "hers": true,
-	"herself": true, "it": true, "its": true, "itself": true, "they": true,
-	"them": true, "their": true, "theirs": true, "themselves": true,
-	"what": true, "which": true, "who": true, "whom": true, "this": true,
-	"that": true, "these": true, "those": true, "am": true,
-	"and": true, "but": true, "if": true, "or": true, "because": true,
-	"as": true, "until": true, "while": true, "of": true, "at": true,
-	"by": true, "for": true, "with": true, "about": true, "against": true,
-	"between": true, "into": true, "through": true, "during": true,
-	"before": true, "after": true, "above": true, "below": true, "to": true,
-	"from": true, "up": true, "down": true, "in": true, "out": true,
-	"on": true, "off": true, "over": true, "under": true, "again": true,
-	"further": true, "then": true, "once": true, "here": true, "there": true,
-	"when": true, "where": true, "why": true, "how": true, "all": true,
-	"each": true, "few": true, "more": true, "most": true, "other": true,
-	"some": true, "such": true, "no": true, "nor": true, "not": true,
-	"only": true, "own": true, "same": true, "so": true, "than": true,
-	"too": true, "very": true, "s": true, "t": true, "can": true,
-	"just": true, "don": true, "now": true,
-}
+// stopWords is the English stop-word list, used when no language is given.
+var stopWords = stopWordLists["en"]
 
-var wordRegex = regexp.MustCompile(`[a-zA-Z0-9]+`)
-var hashtagRegex = regexp.MustCompile(`#([a-zA-Z0-9]+)`)
+var wordRegex = regexp.MustCompile(`[\p{L}\p{N}][\p{L}\p{M}\p{N}]*`)
+var hashtagRegex = regexp.MustCompile(`#([\p{L}\p{N}][\p{L}\p{M}\p{N}]*)`)
 var whitespaceRegex = regexp.MustCompile(`\s+`)
+var urlRegex = regexp.MustCompile(`(?i)\b(?:https?://|www\.)[^\s<>"']+`)
+var phraseTokenRegex = regexp.MustCompile(`[\p{L}\p{N}][\p{L}\p{M}\p{N}]*|[^\s\p{L}\p{M}\p{N}]`)
+
+// emojiJoinerRegex matches variation selectors, zero-width joiners and the
+// keycap mark, which would otherwise glue emoji onto adjacent words.
+var emojiJoinerRegex = regexp.MustCompile(`[\x{FE0E}\x{FE0F}\x{200D}\x{20E3}]`)
 
-// Tokenize splits text into lowercase tokens, removing punctuation.
+// Tokenize splits text into lowercase tokens, removing punctuation and
+// emoji. Tokens are runs of Unicode letters, combining marks and digits, so
+// accented and Devanagari words stay whole; Japanese runs are further split
+// where the script changes between kanji, hiragana and katakana.
 func Tokenize(text string) []string {
 	if text == "" {
 		return []string{}
 	}
 
+	text = emojiJoinerRegex.ReplaceAllString(text, " ")
 	matches := wordRegex.FindAllString(text, -1)
 	if matches == nil {
 		return []string{}
 	}
 
-	tokens := make([]string, len(matches))
-	for i, match := range matches {
-		tokens[i] = strings.ToLower(match)
+	tokens := make([]string, 0, len(matches))
+	for _, match := range matches {
+		for _, part := range splitScripts(match) {
+			tokens = append(tokens, strings.ToLower(part))
+		}
 	}
 	return tokens
 }
 
-// RemoveStopWords filters out common stop words from a token slice.
+// RemoveStopWords filters out common English stop words from a token slice.
 func RemoveStopWords(tokens []string) []string {
+	return RemoveStopWordsLang(tokens, "en")
+}
+
+// RemoveStopWordsLang filters out the stop words of a language from a token
+// slice. Languages without a bundled list fall back to English.
+func RemoveStopWordsLang(tokens []string, lang string) []string {
 	if len(tokens) == 0 {
 		return []string{}
 	}
 
+	words := StopWords(lang)
 	result := make([]string, 0, len(tokens))
 	for _, token := range tokens {
 		lower := strings.ToLower(token)
-		if !stopWords[lower] {
+		if !words[lower] {
 			result = append(result, lower)
 		}
 	}
@@ -91,6 +83,21 @@ func ExtractHashtags(text string) []string {
 	return tags
 }
 
+// ExtractURLs finds all http(s) and www links in text. Trailing sentence
+// punctuation is not included in the link.
+func ExtractURLs(text string) []string {
+	if text == "" {
+		return []string{}
+	}
+
+	matches := urlRegex.FindAllString(text, -1)
+	urls := make([]string, 0, len(matches))
+	for _, m := range matches {
+		urls = append(urls, strings.TrimRight(m, ".,;:!?)]"))
+	}
+	return urls
+}
+
 // NGrams generates n-grams from a slice of tokens.
 func NGrams(tokens []string, n int) []string {
 	if len(tokens) < n || n < 1 {
@@ -105,6 +112,42 @@ func NGrams(tokens []string, n int) []string {
 	return ngrams
 }
 
+// PhraseCandidates splits text into runs of consecutive lowercase content
+// words. Runs break at stop words of lang, single-character words and any
+// punctuation, so candidate phrases never span a sentence boundary or a
+// function word.
+func PhraseCandidates(text, lang string) [][]string {
+	var runs [][]string
+	var current []string
+
+	flush := func() {
+		if len(current) > 0 {
+			runs = append(runs, current)
+			current = nil
+		}
+	}
+
+	words := StopWords(lang)
+	text = emojiJoinerRegex.ReplaceAllString(text, " ")
+	for _, tok := range phraseTokenRegex.FindAllString(text, -1) {
+		if !wordRegex.MatchString(tok) {
+			flush()
+			continue
+		}
+		for _, part := range splitScripts(tok) {
+			lower := strings.ToLower(part)
+			if IsNoiseToken(lower) || words[lower] {
+				flush()
+				continue
+			}
+			current = append(current, lower)
+		}
+	}
+	flush()
+
+	return runs
+}
+
 // NormalizeText lowercases, trims, and collapses whitespace.
 func NormalizeText(text string) string {
 	text = strings.ToLower(text)
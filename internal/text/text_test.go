@@ -17,6 +17,14 @@ func TestTokenize(t *testing.T) {
 		{"empty string", "", []string{}},
 		{"numbers mixed", "top 10 tips", []string{"top", "10", "tips"}},
 		{"special chars", "AI-powered coding", []string{"ai", "powered", "coding"}},
+		{"spanish accents", "Cómo programar en Español", []string{"cómo", "programar", "en", "español"}},
+		{"portuguese", "Programação não é difícil", []string{"programação", "não", "é", "difícil"}},
+		{"hindi combining marks", "कोडिंग सीखें", []string{"कोडिंग", "सीखें"}},
+		{"japanese script split", "プログラミングの基本", []string{"プログラミング", "の", "基本"}},
+		{"japanese inflected ending", "ChatGPTで稼ぐ方法", []string{"chatgpt", "で", "稼ぐ", "方法"}},
+		{"japanese particle after kanji", "基本を学ぶ", []string{"基本", "を", "学ぶ"}},
+		{"emoji dropped", "🔥Fire tips❤️ 1️⃣ fast", []string{"fire", "tips", "1", "fast"}},
+		{"emoji zwj sequence", "coding👨‍💻life", []string{"coding", "life"}},
 	}
 
 	for _, tt := range tests {
@@ -65,6 +73,8 @@ func TestExtractHashtags(t *testing.T) {
 		{"adjacent hashtags", "#tech#tips", []string{"tech", "tips"}},
 		{"empty string", "", []string{}},
 		{"hashtag with numbers", "#top10 tips", []string{"top10"}},
+		{"unicode hashtags", "#programación #कोडिंग #日本語", []string{"programación", "कोडिंग", "日本語"}},
+		{"keycap emoji is not a hashtag", "#️⃣ #tips", []string{"tips"}},
 	}
 
 	for _, tt := range tests {
@@ -101,6 +111,39 @@ func TestNGrams(t *testing.T) {
 	}
 }
 
+func TestPhraseCandidates(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		want [][]string
+	}{
+		{"single run", "Vibe Coding Tutorial", [][]string{{"vibe", "coding", "tutorial"}}},
+		{"stop words split", "vibe coding for beginners", [][]string{{"vibe", "coding"}, {"beginners"}}},
+		{"punctuation splits", "vibe coding. claude code", [][]string{{"vibe", "coding"}, {"claude", "code"}}},
+		{"dash splits", "vibe coding - full guide", [][]string{{"vibe", "coding"}, {"full", "guide"}}},
+		{"hashtag splits", "vibe coding #ai tools", [][]string{{"vibe", "coding"}, {"ai", "tools"}}},
+		{"single characters split", "plan a b test", [][]string{{"plan"}, {"test"}}},
+		{"empty", "", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := PhraseCandidates(tt.text, "en")
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("PhraseCandidates(%q) = %v, want %v", tt.text, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPhraseCandidates_Language(t *testing.T) {
+	got := PhraseCandidates("Aprende Python con ejemplos 🔥 masa madre", "es")
+	want := [][]string{{"aprende", "python"}, {"ejemplos"}, {"masa", "madre"}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("PhraseCandidates() = %v, want %v", got, want)
+	}
+}
+
 func TestNormalizeText(t *testing.T) {
 	tests := []struct {
 		name  string
@@ -124,3 +167,26 @@ func TestNormalizeText(t *testing.T) {
 		})
 	}
 }
+
+func TestExtractURLs(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  []string
+	}{
+		{"none", "no links here", []string{}},
+		{"https", "Get it at https://example.com/tool now", []string{"https://example.com/tool"}},
+		{"www", "Visit www.example.com.", []string{"www.example.com"}},
+		{"multiple", "https://a.io and http://b.io/x?y=1", []string{"https://a.io", "http://b.io/x?y=1"}},
+		{"parenthesized", "(see https://c.dev)", []string{"https://c.dev"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ExtractURLs(tt.input)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ExtractURLs(%q) = %v, want %v", tt.input, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,151 @@
+package text
+
+import (
+	"strings"
+	"unicode"
+)
+
+const (
+	zeroWidthJoiner = '‍'
+	keycapCombiner  = '⃣'
+	emojiSelector   = '\uFE0F' // VS16, requests emoji presentation
+)
+
+// IsEmoji reports whether r is an emoji or pictographic symbol. Symbols
+// that are ordinary punctuation unless followed by VS16 (™, ℹ, ↔) are not;
+// see isTextPictograph.
+func IsEmoji(r rune) bool {
+	switch {
+	case r >= 0x1F300 && r <= 0x1FAFF: // Pictographs, emoticons, transport, supplemental symbols
+		return true
+	case r >= 0x2600 && r <= 0x27BF: // Miscellaneous symbols and dingbats
+		return true
+	case r >= 0x1F1E6 && r <= 0x1F1FF: // Regional indicators (flags)
+		return true
+	case r >= 0x2B05 && r <= 0x2B07, r == 0x2B1B, r == 0x2B1C, r == 0x2B50, r == 0x2B55: // ⬅ ⬆ ⬇ ⬛ ⬜ ⭐ ⭕
+		return true
+	case r >= 0x1F000 && r <= 0x1F2FF: // Mahjong, cards, enclosed supplements
+		return true
+	}
+	return false
+}
+
+// isTextPictograph reports whether r is a pictographic symbol shown as
+// text by default (‼, ⁉, ™, ℹ and the ↔ ↕ ↖ ↗ ↘ ↙ ↩ ↪ arrows). These are
+// emoji only when followed by VS16.
+func isTextPictograph(r rune) bool {
+	switch {
+	case r == 0x203C || r == 0x2049 || r == 0x2122 || r == 0x2139:
+		return true
+	case r >= 0x2194 && r <= 0x2199, r == 0x21A9, r == 0x21AA:
+		return true
+	}
+	return false
+}
+
+// isGraphemeExtender reports whether r attaches to the previous character
+// instead of starting a new user-perceived character.
+func isGraphemeExtender(r rune) bool {
+	switch {
+	case unicode.In(r, unicode.Mn, unicode.Me, unicode.Mc):
+		return true
+	case r >= 0xFE00 && r <= 0xFE0F: // Variation selectors
+		return true
+	case r >= 0x1F3FB && r <= 0x1F3FF: // Skin tone modifiers
+		return true
+	case r >= 0xE0020 && r <= 0xE007F: // Tag characters (subdivision flags)
+		return true
+	case r == keycapCombiner:
+		return true
+	}
+	return false
+}
+
+// Graphemes splits s into approximate user-perceived characters.
+// Combining marks, variation selectors, skin tone modifiers and ZWJ
+// sequences are joined to their base character, and regional indicator
+// pairs form a single flag.
+func Graphemes(s string) []string {
+	if s == "" {
+		return []string{}
+	}
+
+	var clusters []string
+	var current strings.Builder
+	var prev rune
+	joinNext := false
+	regionalPending := false
+
+	for _, r := range s {
+		isRegional := r >= 0x1F1E6 && r <= 0x1F1FF
+		attach := current.Len() > 0 && (joinNext || isGraphemeExtender(r) || r == zeroWidthJoiner ||
+			(isRegional && regionalPending))
+
+		if !attach && current.Len() > 0 {
+			clusters = append(clusters, current.String())
+			current.Reset()
+		}
+		current.WriteRune(r)
+
+		switch {
+		case isRegional:
+			// A flag is exactly two regional indicators
+			regionalPending = !(attach && prev >= 0x1F1E6 && prev <= 0x1F1FF)
+		default:
+			regionalPending = false
+		}
+		joinNext = r == zeroWidthJoiner
+		prev = r
+	}
+
+	if current.Len() > 0 {
+		clusters = append(clusters, current.String())
+	}
+	return clusters
+}
+
+// GraphemeCount returns the number of user-perceived characters in s.
+// Unlike len(s) it does not count bytes, and unlike a rune count it treats
+// accented letters and multi-codepoint emoji as a single character.
+func GraphemeCount(s string) int {
+	return len(Graphemes(s))
+}
+
+// EmojiIndexes returns the grapheme positions of emoji in s.
+func EmojiIndexes(s string) []int {
+	indexes := []int{}
+	for i, g := range Graphemes(s) {
+		for _, r := range g {
+			if IsEmoji(r) || (isTextPictograph(r) && strings.ContainsRune(g, emojiSelector)) {
+				indexes = append(indexes, i)
+				break
+			}
+		}
+	}
+	return indexes
+}
+
+// CountEmoji returns the number of emoji in s. Multi-codepoint emoji
+// (flags, ZWJ sequences, skin tones) count once.
+func CountEmoji(s string) int {
+	return len(EmojiIndexes(s))
+}
+
+// TruncateGraphemes shortens s to at most maxLen user-perceived characters
+// without splitting a multi-byte character. The returned string ends with
+// "..." when truncation occurred, preferring to cut at a word boundary.
+func TruncateGraphemes(s string, maxLen int) string {
+	clusters := Graphemes(s)
+	if len(clusters) <= maxLen {
+		return s
+	}
+	if maxLen <= 3 {
+		return strings.Join(clusters[:max(maxLen, 0)], "")
+	}
+
+	truncated := strings.Join(clusters[:maxLen-3], "")
+	if lastSpace := strings.LastIndex(truncated, " "); lastSpace >= 0 && GraphemeCount(truncated[:lastSpace]) > maxLen/2 {
+		truncated = truncated[:lastSpace]
+	}
+	return truncated + "..."
+}
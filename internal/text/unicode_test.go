@@ -0,0 +1,103 @@
+package text
+
+import (
+	"testing"
+	"unicode/utf8"
+)
+
+func TestGraphemeCount(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  int
+	}{
+		{"ascii", "hello", 5},
+		{"empty", "", 0},
+		{"precomposed accent", "café", 4},
+		{"combining accent", "café", 4},
+		{"single emoji", "fire 🔥", 6},
+		{"skin tone", "👍🏽", 1},
+		{"zwj family", "👨‍👩‍👧", 1},
+		{"flag", "🇲🇽🇧🇷", 2},
+		{"variation selector", "❤️", 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := GraphemeCount(tt.input); got != tt.want {
+				t.Errorf("GraphemeCount(%q) = %d, want %d", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCountEmoji(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  int
+	}{
+		{"none", "plain title", 0},
+		{"two", "🔥 hot take 🚀", 2},
+		{"zwj counts once", "family 👨‍👩‍👧 time", 1},
+		{"flag counts once", "Brasil 🇧🇷", 1},
+		{"text symbols", "Go™ ℹ tips → faster ↔ ← ⬌", 0},
+		{"text symbols as emoji", "ℹ️ info ↔️ swap ‼️", 3},
+		{"pictographic arrows and stars", "⬆ up ⭐ star", 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := CountEmoji(tt.input); got != tt.want {
+				t.Errorf("CountEmoji(%q) = %d, want %d", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEmojiIndexes(t *testing.T) {
+	got := EmojiIndexes("🔥ab🚀")
+	if len(got) != 2 || got[0] != 0 || got[1] != 3 {
+		t.Errorf("EmojiIndexes = %v, want [0 3]", got)
+	}
+}
+
+func TestTruncateGraphemes(t *testing.T) {
+	tests := []struct {
+		name   string
+		input  string
+		maxLen int
+		want   string
+	}{
+		{"short unchanged", "hello", 10, "hello"},
+		{"word boundary", "hello wonderful world", 20, "hello wonderful..."},
+		{"mid word when boundary too early", "hello wonderful world", 15, "hello wonder..."},
+		{"multibyte not split", "ñañañañañañañañ", 8, "ñañañ..."},
+		{"emoji not split", "🔥🔥🔥🔥🔥🔥", 5, "🔥🔥..."},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := TruncateGraphemes(tt.input, tt.maxLen)
+			if got != tt.want {
+				t.Errorf("TruncateGraphemes(%q, %d) = %q, want %q", tt.input, tt.maxLen, got, tt.want)
+			}
+			if !utf8.ValidString(got) {
+				t.Errorf("TruncateGraphemes produced invalid UTF-8: %q", got)
+			}
+		})
+	}
+}
+
+func TestIsEmoji(t *testing.T) {
+	for _, r := range "🔥🚀☕✅⭐🇧" {
+		if !IsEmoji(r) {
+			t.Errorf("IsEmoji(%q) = false, want true", r)
+		}
+	}
+	for _, r := range "a1™ℹ→↔←⇒⬌é" {
+		if IsEmoji(r) {
+			t.Errorf("IsEmoji(%q) = true, want false", r)
+		}
+	}
+}
@@ -0,0 +1,476 @@
+// Package variants generates title ideas for a topic by filling the niche's
+// title formulas with its keywords, entities, hooks and numbers, without an
+// LLM. Variants are ranked with the draft title scorer.
+package variants
+
+import (
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/mikelady/kingmaker/internal/analyzer"
+	"github.com/mikelady/kingmaker/internal/hooks"
+	"github.com/mikelady/kingmaker/internal/score"
+	"github.com/mikelady/kingmaker/internal/text"
+)
+
+// Formula slots. The niche's title templates use the analyzer's slots ([N],
+// [UNIT], [YEAR], [THING], [QUOTE]); hook formulas add [TOPIC] and [HOOK].
+const (
+	slotTopic  = "[TOPIC]"
+	slotThing  = "[THING]"
+	slotThing2 = "[THING2]"
+	slotHook   = "[HOOK]"
+	slotNumber = "[N]"
+	slotUnit   = "[UNIT]"
+	slotYear   = "[YEAR]"
+	slotQuote  = "[QUOTE]"
+)
+
+// hookFormulas are title formulas for each hook type. Types the niche uses
+// are tried, in order of how often the niche uses them. [HOOK] is filled
+// with the niche's most frequent pattern of the type.
+var hookFormulas = map[hooks.HookType][]string{
+	hooks.Question:     {"Is [THING] Worth It for [TOPIC]?", "Why Is Everyone Learning [TOPIC]?"},
+	hooks.Numerical:    {"[N] [TOPIC] Mistakes to Avoid", "[N] [TOPIC] Tips You Need"},
+	hooks.PowerWord:    {"[HOOK] [TOPIC] Tips", "The [HOOK] [TOPIC] Trick Nobody Uses"},
+	hooks.CuriosityGap: {"What Nobody Tells You About [TOPIC]"},
+	hooks.Comparison:   {"[THING] vs [THING2] for [TOPIC]"},
+	hooks.Challenge:    {"I Tried [TOPIC] for [N] Days"},
+	hooks.Story:        {"How I Learned [TOPIC] in [N] [UNIT]"},
+	hooks.Warning:      {"Stop Making These [TOPIC] Mistakes", "Don't Start [TOPIC] Before Watching This"},
+	hooks.Superlative:  {"The [HOOK] Way to Learn [TOPIC]"},
+	hooks.TimeBound:    {"[TOPIC] in [N] [UNIT]", "[TOPIC] in [YEAR]"},
+}
+
+// hookSlotTypes are the hook types whose patterns are single words that can
+// fill [HOOK].
+var hookSlotTypes = map[hooks.HookType]bool{
+	hooks.PowerWord:   true,
+	hooks.Superlative: true,
+}
+
+const (
+	maxThings       = 3    // Entities and keywords tried per [THING] slot
+	maxNumbers      = 2    // Numbers tried per [N] slot
+	nearDuplicateAt = 0.75 // Token overlap from which two variants count as the same idea
+)
+
+// Default fillers when the niche's titles provide none.
+var (
+	defaultNumbers   = []string{"5", "7"}
+	defaultDurations = [][2]string{{"10", "minutes"}, {"30", "days"}}
+)
+
+var (
+	durationRe = regexp.MustCompile(`(?i)\b(\d+)\s*(seconds?|minutes?|hours?|days?|weeks?|months?)\b`)
+	numberRe   = regexp.MustCompile(`\b\d{1,3}\b`)
+	yearRe     = regexp.MustCompile(`\b20\d{2}\b`)
+)
+
+// Variant is a generated title with its score.
+type Variant struct {
+	Title   string       // Generated title
+	Formula string       // Formula it was filled from
+	Score   score.Result // Draft score breakdown
+}
+
+// Options configures variant generation.
+type Options struct {
+	Count     int             // Number of variants to return (default 10)
+	Year      int             // Year for [YEAR] slots (default: the latest year in the niche's titles, else the current year)
+	Hooks     *hooks.Matcher  // Hook definitions (default bundled hook packs)
+	Gazetteer *text.Gazetteer // Known entity names (default bundled gazetteer)
+}
+
+// DefaultOptions returns the default generation options.
+func DefaultOptions() Options {
+	return Options{
+		Count:     10,
+		Hooks:     hooks.DefaultMatcher(),
+		Gazetteer: text.DefaultGazetteer(),
+	}
+}
+
+// Generate fills the niche's title templates and the formulas of its hook
+// types with topic and the niche's top entities, keywords, hooks and numbers.
+// Variants are ranked by draft score, and near-identical ones are dropped in
+// favor of the higher-scoring one.
+func Generate(topic string, patterns analyzer.Patterns, opts Options) []Variant {
+	if opts.Count <= 0 {
+		opts.Count = 10
+	}
+	topic = strings.TrimSpace(topic)
+	if topic == "" {
+		return nil
+	}
+
+	f := newFillers(topic, patterns, opts.Year)
+	scoreOpts := score.Options{Hooks: opts.Hooks, Gazetteer: opts.Gazetteer}
+
+	// Titles the niche already uses are not new ideas
+	var candidates []Variant
+	seen := make(map[string]bool)
+	for _, t := range patterns.TitleTemplates {
+		for _, example := range t.Examples {
+			seen[strings.ToLower(example)] = true
+		}
+	}
+	for _, fm := range formulas(patterns) {
+		for _, title := range f.fill(fm) {
+			key := strings.ToLower(title)
+			if seen[key] {
+				continue
+			}
+			seen[key] = true
+			candidates = append(candidates, Variant{
+				Title:   title,
+				Formula: fm.text,
+				Score:   score.Title(title, patterns, scoreOpts),
+			})
+		}
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		if candidates[i].Score.Score != candidates[j].Score.Score {
+			return candidates[i].Score.Score > candidates[j].Score.Score
+		}
+		return candidates[i].Title < candidates[j].Title
+	})
+
+	var result []Variant
+	for _, c := range candidates {
+		if len(result) == opts.Count {
+			break
+		}
+		duplicate := false
+		for _, kept := range result {
+			if similarity(c.Title, kept.Title) >= nearDuplicateAt {
+				duplicate = true
+				break
+			}
+		}
+		if !duplicate {
+			result = append(result, c)
+		}
+	}
+	return result
+}
+
+// formula is a title formula with the hook pattern for its [HOOK] slot.
+type formula struct {
+	text string
+	hook string
+}
+
+// formulas returns the niche's title templates with a slot the topic fills
+// grammatically, best first, then the formulas of each hook type: the types the niche uses
+// most first, then the types it does not use. Types whose hooks only
+// underperform in the niche are left out.
+func formulas(patterns analyzer.Patterns) []formula {
+	templates := append([]analyzer.TitleTemplate(nil), patterns.TitleTemplates...)
+	sort.SliceStable(templates, func(i, j int) bool {
+		return templates[i].MedianViews > templates[j].MedianViews
+	})
+
+	var result []formula
+	for _, t := range templates {
+		if (strings.Contains(t.Template, slotThing) || strings.Contains(t.Template, slotQuote)) && !articleSlot(t.Template) {
+			result = append(result, formula{text: t.Template})
+		}
+	}
+
+	// Hook types by total frequency, each with its most frequent pattern.
+	// Hooks whose titles underperform the niche are not worth copying.
+	usage := make(map[hooks.HookType]int)
+	underperforming := make(map[hooks.HookType]bool)
+	topPattern := make(map[hooks.HookType]hooks.Hook)
+	for _, h := range patterns.TopHooks {
+		if h.Lift > 0 && h.Lift < 1 {
+			underperforming[h.Type] = true
+			continue
+		}
+		usage[h.Type] += h.Frequency
+		if best, ok := topPattern[h.Type]; !ok || h.Frequency > best.Frequency {
+			topPattern[h.Type] = h
+		}
+	}
+	var types []hooks.HookType
+	for _, t := range hooks.HookTypes {
+		if usage[t] > 0 || !underperforming[t] {
+			types = append(types, t)
+		}
+	}
+	sort.SliceStable(types, func(i, j int) bool {
+		return usage[types[i]] > usage[types[j]]
+	})
+
+	for _, t := range types {
+		hook := ""
+		if h, ok := topPattern[t]; ok && hookSlotTypes[t] {
+			hook = h.Pattern
+		}
+		for _, ft := range hookFormulas[t] {
+			if strings.Contains(ft, slotHook) && hook == "" {
+				hook = defaultHook(t)
+			}
+			result = append(result, formula{text: ft, hook: hook})
+		}
+	}
+	return result
+}
+
+// defaultHook fills [HOOK] when the niche has no pattern of the type.
+func defaultHook(t hooks.HookType) string {
+	if t == hooks.Superlative {
+		return "best"
+	}
+	return "secret"
+}
+
+// fillers holds the values tried for each slot.
+type fillers struct {
+	topic     string
+	things    []string    // Entities, then keywords, not already in the topic
+	numbers   []string    // Small numbers used in the niche's titles
+	durations [][2]string // Number and unit pairs used in the niche's titles
+	year      string
+}
+
+func newFillers(topic string, patterns analyzer.Patterns, year int) fillers {
+	f := fillers{topic: topic}
+
+	topicTokens := make(map[string]bool)
+	for _, tok := range text.Tokenize(topic) {
+		topicTokens[tok] = true
+	}
+	// Things overlapping the topic or an earlier thing ("code" after
+	// "VS Code") are skipped
+	addThing := func(name string) {
+		if len(f.things) >= maxThings {
+			return
+		}
+		tokens := text.Tokenize(name)
+		for _, tok := range tokens {
+			if topicTokens[tok] {
+				return
+			}
+		}
+		for _, tok := range tokens {
+			topicTokens[tok] = true
+		}
+		f.things = append(f.things, name)
+	}
+	for _, e := range patterns.TopEntities {
+		addThing(e.Name)
+	}
+	for _, kw := range patterns.TopKeywords {
+		addThing(kw.Word)
+	}
+
+	// Numbers, durations and years from the niche's example titles
+	var examples []string
+	for _, t := range patterns.TitleTemplates {
+		examples = append(examples, t.Examples...)
+	}
+	for _, h := range patterns.TopHooks {
+		examples = append(examples, h.Examples...)
+	}
+	latestYear := 0
+	for _, title := range examples {
+		for _, y := range yearRe.FindAllString(title, -1) {
+			if n, _ := strconv.Atoi(y); n > latestYear {
+				latestYear = n
+			}
+		}
+		for _, m := range durationRe.FindAllStringSubmatch(title, -1) {
+			f.durations = appendUnique(f.durations, [2]string{m[1], strings.ToLower(m[2])})
+		}
+		for _, n := range numberRe.FindAllString(yearRe.ReplaceAllString(title, ""), -1) {
+			if len(f.numbers) < maxNumbers && !contains(f.numbers, n) {
+				f.numbers = append(f.numbers, n)
+			}
+		}
+	}
+	if len(f.numbers) == 0 {
+		f.numbers = defaultNumbers
+	}
+	if len(f.durations) == 0 {
+		f.durations = defaultDurations
+	}
+	if len(f.durations) > maxNumbers {
+		f.durations = f.durations[:maxNumbers]
+	}
+
+	switch {
+	case year > 0:
+		f.year = strconv.Itoa(year)
+	case latestYear > 0:
+		f.year = strconv.Itoa(latestYear)
+	default:
+		f.year = strconv.Itoa(time.Now().Year())
+	}
+	return f
+}
+
+// fill returns every title the formula yields. Formulas with a slot that has
+// no fillers yield nothing.
+func (f fillers) fill(fm formula) []string {
+	titles := []string{fm.text}
+
+	expand := func(slot string, values []string) {
+		var next []string
+		for _, t := range titles {
+			if !strings.Contains(t, slot) {
+				next = append(next, t)
+				continue
+			}
+			for _, v := range values {
+				next = append(next, strings.Replace(t, slot, v, 1))
+			}
+		}
+		titles = next
+	}
+
+	// A duration's number and unit are filled together
+	var durations []string
+	for _, d := range f.durations {
+		durations = append(durations, d[0]+" "+d[1])
+	}
+	expand(slotNumber+" "+slotUnit, durations)
+	expand(slotNumber, f.numbers)
+	expand(slotUnit, []string{f.durations[0][1]})
+	expand(slotYear, []string{f.year})
+	expand(slotHook, []string{fm.hook})
+	expand(slotQuote, []string{`"` + f.topic + `"`})
+
+	// The topic fills the first [THING] of a niche template; hook formulas
+	// name it explicitly
+	if !strings.Contains(fm.text, slotTopic) {
+		expand(slotThing, []string{f.topic})
+	}
+	expand(slotTopic, []string{f.topic})
+	expand(slotThing, f.things)
+	if len(f.things) > 1 {
+		expand(slotThing2, f.things[1:])
+	} else {
+		expand(slotThing2, nil)
+	}
+
+	var result []string
+	for _, t := range titles {
+		if strings.Contains(t, "[") {
+			continue // A slot was left unfilled
+		}
+		if strings.Contains(fm.text, slotThing) && strings.Contains(fm.text, slotThing2) && repeatsThing(t, f.things) {
+			continue
+		}
+		result = append(result, titleCase(t))
+	}
+	return result
+}
+
+// articles are the words before a slot that call for a countable noun, as
+// in "I built a [THING]". Topics, keywords and entities are rarely one.
+var articles = map[string]bool{"a": true, "an": true}
+
+// articleSlot reports whether a template puts an indefinite article before
+// a [THING] slot, which the topic would fill ungrammatically.
+func articleSlot(template string) bool {
+	words := strings.Fields(template)
+	for i := 1; i < len(words); i++ {
+		if strings.HasPrefix(words[i], slotThing) && articles[strings.ToLower(words[i-1])] {
+			return true
+		}
+	}
+	return false
+}
+
+// repeatsThing reports whether a comparison names the same thing twice.
+func repeatsThing(title string, things []string) bool {
+	for _, thing := range things {
+		if strings.Count(title, thing) > 1 {
+			return true
+		}
+	}
+	return false
+}
+
+// minorWords stay lowercase inside a title.
+var minorWords = map[string]bool{
+	"a": true, "an": true, "and": true, "as": true, "at": true, "by": true,
+	"for": true, "in": true, "of": true, "on": true, "or": true, "the": true,
+	"to": true, "vs": true, "with": true,
+}
+
+// titleCase capitalizes the lowercase words of a title, except minor words
+// after the first. Words with any uppercase letter (names, acronyms) are
+// kept as written.
+func titleCase(title string) string {
+	words := strings.Fields(title)
+	for i, w := range words {
+		if strings.IndexFunc(w, unicode.IsUpper) >= 0 {
+			continue
+		}
+		if i > 0 && minorWords[w] {
+			continue
+		}
+		runes := []rune(w)
+		for j, r := range runes {
+			if unicode.IsLetter(r) {
+				runes[j] = unicode.ToUpper(r)
+				break
+			}
+		}
+		words[i] = string(runes)
+	}
+	return strings.Join(words, " ")
+}
+
+// similarity is the Jaccard overlap of two titles' token sets, ignoring
+// numbers so that "5 Go Tips" and "7 Go Tips" count as the same idea.
+func similarity(a, b string) float64 {
+	ta, tb := tokenSet(a), tokenSet(b)
+	if len(ta) == 0 && len(tb) == 0 {
+		return 1
+	}
+	shared := 0
+	for tok := range ta {
+		if tb[tok] {
+			shared++
+		}
+	}
+	return float64(shared) / float64(len(ta)+len(tb)-shared)
+}
+
+func tokenSet(title string) map[string]bool {
+	set := make(map[string]bool)
+	for _, tok := range text.Tokenize(title) {
+		if _, err := strconv.Atoi(tok); err == nil {
+			continue
+		}
+		set[tok] = true
+	}
+	return set
+}
+
+func contains(items []string, s string) bool {
+	for _, item := range items {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
+
+func appendUnique(pairs [][2]string, p [2]string) [][2]string {
+	for _, existing := range pairs {
+		if existing == p {
+			return pairs
+		}
+	}
+	return append(pairs, p)
+}
@@ -0,0 +1,176 @@
+package variants
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/mikelady/kingmaker/internal/analyzer"
+	"github.com/mikelady/kingmaker/internal/hooks"
+	"github.com/mikelady/kingmaker/internal/keywords"
+)
+
+func nichePatterns() analyzer.Patterns {
+	return analyzer.Patterns{
+		TopHooks: []hooks.Hook{
+			{Type: hooks.PowerWord, Pattern: "secret", Frequency: 6, Lift: 2.5},
+			{Type: hooks.TimeBound, Pattern: "in n minutes", Frequency: 3, Lift: 1.4, Examples: []string{"Rust in 15 minutes"}},
+		},
+		TopKeywords: []keywords.Keyword{
+			{Word: "channels", Frequency: 8},
+			{Word: "golang", Frequency: 12},
+		},
+		TopEntities: []analyzer.Entity{
+			{Name: "VS Code", Frequency: 4, Lift: 2},
+		},
+		TitleMetrics: analyzer.TitleMetrics{TopLengthMin: 15, TopLengthMax: 40},
+		TitleTemplates: []analyzer.TitleTemplate{
+			{Template: "I built a [THING] in [N] [UNIT]", Count: 3, MedianViews: 8000, Examples: []string{"I built a Chess Engine in 2 hours"}},
+			{Template: "[N] go mistakes beginners make", Count: 2, MedianViews: 9000, Examples: []string{"5 Go Mistakes Beginners Make"}},
+			{Template: "everything you need to know about [THING]", Count: 2, MedianViews: 7000, Examples: []string{"Everything You Need to Know About Generics"}},
+		},
+	}
+}
+
+func TestGenerate_Empty(t *testing.T) {
+	if got := Generate("  ", nichePatterns(), DefaultOptions()); got != nil {
+		t.Errorf("Generate(blank topic) = %+v, want nil", got)
+	}
+}
+
+func TestGenerate_FillsFormulas(t *testing.T) {
+	opts := DefaultOptions()
+	opts.Count = 50
+	got := Generate("Go Concurrency", nichePatterns(), opts)
+
+	titles := make(map[string]Variant)
+	for _, v := range got {
+		titles[v.Title] = v
+	}
+	for _, want := range []string{
+		"Everything You Need to Know About Go Concurrency", // Niche template
+		"Secret Go Concurrency Tips",                       // Power word formula with the niche's top pattern
+		"Go Concurrency in 15 Minutes",                     // Time-bound formula, with the niche's duration
+	} {
+		if _, ok := titles[want]; !ok {
+			t.Errorf("missing variant %q in %v", want, variantTitles(got))
+		}
+	}
+	if v, ok := titles["Everything You Need to Know About Go Concurrency"]; ok && v.Formula != "everything you need to know about [THING]" {
+		t.Errorf("Formula = %q, want the niche template", v.Formula)
+	}
+	for _, v := range got {
+		if strings.Contains(v.Title, "[") {
+			t.Errorf("variant %q has an unfilled slot", v.Title)
+		}
+		if v.Formula == "I built a [THING] in [N] [UNIT]" {
+			t.Errorf("variant %q puts the topic after an article (\"I built a Go Concurrency\")", v.Title)
+		}
+		if strings.Contains(v.Title, "Beginners Make") {
+			t.Errorf("variant %q comes from a template with no slot for the topic", v.Title)
+		}
+		if v.Formula == "" || v.Score.Title != v.Title {
+			t.Errorf("variant %+v lacks its formula or score", v)
+		}
+	}
+}
+
+func TestGenerate_RankedAndLimited(t *testing.T) {
+	opts := DefaultOptions()
+	opts.Count = 3
+	got := Generate("Go Concurrency", nichePatterns(), opts)
+
+	if len(got) != 3 {
+		t.Fatalf("Generate() returned %d variants, want 3", len(got))
+	}
+	for i := 1; i < len(got); i++ {
+		if got[i].Score.Score > got[i-1].Score.Score {
+			t.Errorf("variants not ranked by score: %d before %d", got[i-1].Score.Score, got[i].Score.Score)
+		}
+	}
+}
+
+func TestGenerate_Deterministic(t *testing.T) {
+	first := variantTitles(Generate("Go Concurrency", nichePatterns(), DefaultOptions()))
+	for i := 0; i < 5; i++ {
+		if got := variantTitles(Generate("Go Concurrency", nichePatterns(), DefaultOptions())); !reflect.DeepEqual(got, first) {
+			t.Fatalf("Generate() = %v, want %v on identical input", got, first)
+		}
+	}
+}
+
+func TestGenerate_DropsNearDuplicates(t *testing.T) {
+	opts := DefaultOptions()
+	opts.Count = 50
+	got := Generate("Go Concurrency", nichePatterns(), opts)
+
+	for i := range got {
+		for j := i + 1; j < len(got); j++ {
+			if s := similarity(got[i].Title, got[j].Title); s >= nearDuplicateAt {
+				t.Errorf("%q and %q are near-identical (%.2f)", got[i].Title, got[j].Title, s)
+			}
+		}
+	}
+}
+
+func TestGenerate_WithoutNicheData(t *testing.T) {
+	got := Generate("sourdough", analyzer.Patterns{}, DefaultOptions())
+	if len(got) == 0 {
+		t.Fatal("Generate() without niche data returned nothing, want hook formula variants")
+	}
+	for _, v := range got {
+		if !strings.Contains(v.Title, "Sourdough") {
+			t.Errorf("variant %q does not mention the topic", v.Title)
+		}
+	}
+}
+
+func TestTitleCase(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{"i built a chess engine in 2 hours", "I Built a Chess Engine in 2 Hours"},
+		{"the best way to learn VS Code", "The Best Way to Learn VS Code"},
+		{"top [N] tools for LangGraph", "Top [N] Tools for LangGraph"},
+	}
+
+	for _, tt := range tests {
+		if got := titleCase(tt.in); got != tt.want {
+			t.Errorf("titleCase(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestArticleSlot(t *testing.T) {
+	tests := map[string]bool{
+		"I built a [THING] in [N] [UNIT]":           true,
+		"An [THING] nobody talks about":             true,
+		"why I ditched an [THING]":                  true,
+		"everything you need to know about [THING]": false,
+		"[THING] vs [THING2]":                       false,
+		"a [QUOTE] moment":                          false,
+	}
+	for template, want := range tests {
+		if got := articleSlot(template); got != want {
+			t.Errorf("articleSlot(%q) = %v, want %v", template, got, want)
+		}
+	}
+}
+
+func TestSimilarity(t *testing.T) {
+	if s := similarity("5 Go Tips", "7 Go Tips"); s != 1 {
+		t.Errorf("similarity ignoring numbers = %v, want 1", s)
+	}
+	if s := similarity("Go Tips", "Rust Debugging"); s != 0 {
+		t.Errorf("similarity of unrelated titles = %v, want 0", s)
+	}
+}
+
+func variantTitles(variants []Variant) []string {
+	titles := make([]string, len(variants))
+	for i, v := range variants {
+		titles[i] = v.Title
+	}
+	return titles
+}
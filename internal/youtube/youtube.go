@@ -195,6 +195,8 @@ func convertVideo(v *youtube.Video) model.Video {
 	if v.Snippet != nil {
 		video.Title = v.Snippet.Title
 		video.Description = v.Snippet.Description
+		video.Tags = v.Snippet.Tags
+		video.DefaultAudioLanguage = v.Snippet.DefaultAudioLanguage
 		video.Channel = v.Snippet.ChannelTitle
 		video.ChannelID = v.Snippet.ChannelId
 
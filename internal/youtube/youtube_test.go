@@ -261,11 +261,13 @@ func TestConvertVideo(t *testing.T) {
 	ytVideo := &youtube.Video{
 		Id: "xyz789",
 		Snippet: &youtube.VideoSnippet{
-			Title:        "Amazing Video",
-			Description:  "Great content #trending",
-			ChannelId:    "UCtest",
-			ChannelTitle: "Test Channel",
-			PublishedAt:  "2024-03-20T15:00:00Z",
+			Title:                "Amazing Video",
+			Description:          "Great content #trending",
+			Tags:                 []string{"trending", "viral"},
+			DefaultAudioLanguage: "es-419",
+			ChannelId:            "UCtest",
+			ChannelTitle:         "Test Channel",
+			PublishedAt:          "2024-03-20T15:00:00Z",
 		},
 		Statistics: &youtube.VideoStatistics{
 			ViewCount: 10000,
@@ -284,6 +286,12 @@ func TestConvertVideo(t *testing.T) {
 	if video.Title != "Amazing Video" {
 		t.Errorf("Title = %s, want 'Amazing Video'", video.Title)
 	}
+	if len(video.Tags) != 2 || video.Tags[0] != "trending" {
+		t.Errorf("Tags = %v, want [trending viral]", video.Tags)
+	}
+	if video.DefaultAudioLanguage != "es-419" {
+		t.Errorf("DefaultAudioLanguage = %s, want es-419", video.DefaultAudioLanguage)
+	}
 	if video.Channel != "Test Channel" {
 		t.Errorf("Channel = %s, want 'Test Channel'", video.Channel)
 	}